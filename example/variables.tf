@@ -53,8 +53,11 @@ variable "index_name" {
 }
 
 variable "index_keys" {
-  description = "Index keys configuration as key-value map (field = index_type)"
-  type        = map(string)
+  description = "Index keys configuration in the order of the compound index"
+  type = list(object({
+    field = string
+    type  = string
+  }))
 }
 
 variable "index_unique" {
@@ -0,0 +1,182 @@
+package mongodb
+
+// ActionScope is the kind of privilege resource an action can be granted on.
+type ActionScope int
+
+const (
+	// ActionScopeDatabase actions apply to database or collection resources.
+	ActionScopeDatabase ActionScope = iota + 1
+	// ActionScopeCluster actions apply to the cluster resource only.
+	ActionScopeCluster
+	// ActionScopeAny actions apply to both the cluster and database or collection resources.
+	ActionScopeAny
+)
+
+func (s ActionScope) String() string {
+	switch s {
+	case ActionScopeDatabase:
+		return "database or collection"
+	case ActionScopeCluster:
+		return "cluster"
+	case ActionScopeAny:
+		return "cluster, database or collection"
+	default:
+		return "unknown"
+	}
+}
+
+// actionScopes are the privilege actions and the resources they apply to,
+// see https://www.mongodb.com/docs/manual/reference/privilege-actions/
+var actionScopes = map[string]ActionScope{
+	// Query and write actions
+	"find":                     ActionScopeDatabase,
+	"insert":                   ActionScopeDatabase,
+	"remove":                   ActionScopeDatabase,
+	"update":                   ActionScopeDatabase,
+	"bypassDocumentValidation": ActionScopeDatabase,
+	"bypassDefaultMaxTimeMS":   ActionScopeCluster,
+	"useUUID":                  ActionScopeCluster,
+
+	// Database management actions
+	"changeCustomData":                ActionScopeDatabase,
+	"changeOwnCustomData":             ActionScopeDatabase,
+	"changeOwnPassword":               ActionScopeDatabase,
+	"changePassword":                  ActionScopeDatabase,
+	"changeStream":                    ActionScopeDatabase,
+	"compactStructuredEncryptionData": ActionScopeDatabase,
+	"createCollection":                ActionScopeDatabase,
+	"createIndex":                     ActionScopeDatabase,
+	"createRole":                      ActionScopeDatabase,
+	"createSearchIndexes":             ActionScopeDatabase,
+	"createUser":                      ActionScopeDatabase,
+	"dropCollection":                  ActionScopeDatabase,
+	"dropRole":                        ActionScopeDatabase,
+	"dropSearchIndex":                 ActionScopeDatabase,
+	"dropUser":                        ActionScopeDatabase,
+	"enableProfiler":                  ActionScopeDatabase,
+	"grantRole":                       ActionScopeDatabase,
+	"killCursors":                     ActionScopeDatabase,
+	"killAnyCursor":                   ActionScopeDatabase,
+	"listSearchIndexes":               ActionScopeDatabase,
+	"planCacheIndexFilter":            ActionScopeDatabase,
+	"planCacheRead":                   ActionScopeDatabase,
+	"planCacheWrite":                  ActionScopeDatabase,
+	"revokeRole":                      ActionScopeDatabase,
+	"setAuthenticationRestriction":    ActionScopeDatabase,
+	"unlock":                          ActionScopeCluster,
+	"updateSearchIndex":               ActionScopeDatabase,
+	"viewRole":                        ActionScopeDatabase,
+	"viewUser":                        ActionScopeDatabase,
+
+	// Deployment management actions
+	"authSchemaUpgrade":       ActionScopeCluster,
+	"cleanupOrphaned":         ActionScopeCluster,
+	"cpuProfiler":             ActionScopeCluster,
+	"impersonate":             ActionScopeCluster,
+	"inprog":                  ActionScopeCluster,
+	"invalidateUserCache":     ActionScopeCluster,
+	"killAnySession":          ActionScopeCluster,
+	"killop":                  ActionScopeCluster,
+	"listSessions":            ActionScopeCluster,
+	"oidcListKeys":            ActionScopeCluster,
+	"oidcRefreshKeys":         ActionScopeCluster,
+	"rotateCertificates":      ActionScopeCluster,
+	"rotateFTDC":              ActionScopeCluster,
+	"setUserWriteBlockMode":   ActionScopeCluster,
+	"bypassWriteBlockingMode": ActionScopeCluster,
+
+	// Replication actions
+	"appendOplogNote":    ActionScopeCluster,
+	"replSetConfigure":   ActionScopeCluster,
+	"replSetGetConfig":   ActionScopeCluster,
+	"replSetGetStatus":   ActionScopeCluster,
+	"replSetHeartbeat":   ActionScopeCluster,
+	"replSetStateChange": ActionScopeCluster,
+	"resync":             ActionScopeCluster,
+
+	// Sharding actions
+	"addShard":                            ActionScopeCluster,
+	"analyzeShardKey":                     ActionScopeDatabase,
+	"checkMetadataConsistency":            ActionScopeAny,
+	"clearJumboFlag":                      ActionScopeDatabase,
+	"configureQueryAnalyzer":              ActionScopeDatabase,
+	"enableSharding":                      ActionScopeAny,
+	"flushRouterConfig":                   ActionScopeAny,
+	"getClusterParameter":                 ActionScopeCluster,
+	"getDefaultRWConcern":                 ActionScopeCluster,
+	"getShardMap":                         ActionScopeCluster,
+	"getShardVersion":                     ActionScopeDatabase,
+	"getDatabaseVersion":                  ActionScopeDatabase,
+	"issueDirectShardOperations":          ActionScopeCluster,
+	"listShards":                          ActionScopeCluster,
+	"moveChunk":                           ActionScopeAny,
+	"moveCollection":                      ActionScopeDatabase,
+	"movePrimary":                         ActionScopeDatabase,
+	"refineCollectionShardKey":            ActionScopeDatabase,
+	"removeShard":                         ActionScopeCluster,
+	"reshardCollection":                   ActionScopeDatabase,
+	"setClusterParameter":                 ActionScopeCluster,
+	"setDefaultRWConcern":                 ActionScopeCluster,
+	"shardCollection":                     ActionScopeDatabase,
+	"shardedDataDistribution":             ActionScopeCluster,
+	"shardingState":                       ActionScopeCluster,
+	"splitChunk":                          ActionScopeDatabase,
+	"splitVector":                         ActionScopeDatabase,
+	"transitionFromDedicatedConfigServer": ActionScopeCluster,
+	"transitionToDedicatedConfigServer":   ActionScopeCluster,
+	"unshardCollection":                   ActionScopeDatabase,
+
+	// Server administration actions
+	"applicationMessage":             ActionScopeCluster,
+	"checkFreeMonitoringStatus":      ActionScopeCluster,
+	"collMod":                        ActionScopeDatabase,
+	"compact":                        ActionScopeDatabase,
+	"connPoolSync":                   ActionScopeCluster,
+	"convertToCapped":                ActionScopeDatabase,
+	"dropConnections":                ActionScopeCluster,
+	"dropDatabase":                   ActionScopeDatabase,
+	"dropIndex":                      ActionScopeDatabase,
+	"forceUUID":                      ActionScopeCluster,
+	"fsync":                          ActionScopeCluster,
+	"getParameter":                   ActionScopeCluster,
+	"hostInfo":                       ActionScopeCluster,
+	"logRotate":                      ActionScopeCluster,
+	"oidReset":                       ActionScopeCluster,
+	"reIndex":                        ActionScopeDatabase,
+	"renameCollectionSameDB":         ActionScopeDatabase,
+	"getChangeStreamState":           ActionScopeCluster,
+	"setChangeStreamState":           ActionScopeCluster,
+	"setFeatureCompatibilityVersion": ActionScopeCluster,
+	"setFreeMonitoring":              ActionScopeCluster,
+	"setParameter":                   ActionScopeCluster,
+	"shutdown":                       ActionScopeCluster,
+
+	// Diagnostic actions
+	"collStats":                 ActionScopeDatabase,
+	"connPoolStats":             ActionScopeCluster,
+	"dbHash":                    ActionScopeDatabase,
+	"dbStats":                   ActionScopeDatabase,
+	"getCmdLineOpts":            ActionScopeCluster,
+	"getLog":                    ActionScopeCluster,
+	"indexStats":                ActionScopeDatabase,
+	"listCollections":           ActionScopeDatabase,
+	"listDatabases":             ActionScopeCluster,
+	"listIndexes":               ActionScopeDatabase,
+	"netstat":                   ActionScopeCluster,
+	"queryStatsRead":            ActionScopeCluster,
+	"queryStatsReadTransformed": ActionScopeCluster,
+	"serverStatus":              ActionScopeCluster,
+	"top":                       ActionScopeCluster,
+	"validate":                  ActionScopeDatabase,
+
+	// Internal actions
+	"anyAction": ActionScopeCluster,
+	"internal":  ActionScopeCluster,
+}
+
+// GetActionScope returns the resources the privilege action applies to. The second value is false for unknown actions.
+func GetActionScope(action string) (ActionScope, bool) {
+	scope, ok := actionScopes[action]
+
+	return scope, ok
+}
@@ -1,51 +1,304 @@
 package mongodb
 
 import (
+	"cmp"
 	"context"
 	"crypto/tls"
 	"crypto/x509"
 	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"go.mongodb.org/mongo-driver/v2/bson"
 	"go.mongodb.org/mongo-driver/v2/mongo"
 	mongooptions "go.mongodb.org/mongo-driver/v2/mongo/options"
+	"go.mongodb.org/mongo-driver/v2/mongo/readconcern"
+	"go.mongodb.org/mongo-driver/v2/mongo/readpref"
+	"go.mongodb.org/mongo-driver/v2/mongo/writeconcern"
+	"go.mongodb.org/mongo-driver/v2/x/mongo/driver/connstring"
 )
 
+// primaryRunCmdOptions routes admin commands to the primary regardless of the configured read preference,
+// as usersInfo and rolesInfo must return the authoritative user and role definitions.
+var primaryRunCmdOptions = mongooptions.RunCmd().SetReadPreference(readpref.Primary())
+
+// database returns the database using the configured read preference, or the primary for the reads
+// which must see the preceding writes, e.g. the object read back after its creation.
+func (c *Client) database(name string, primary bool) *mongo.Database {
+	if primary {
+		return c.mongo.Database(name, mongooptions.Database().SetReadPreference(readpref.Primary()))
+	}
+
+	return c.mongo.Database(name)
+}
+
+// WriteConcern describes the acknowledgment requested from the server for write operations.
+type WriteConcern struct {
+	// W is a number of nodes, "majority" or a custom tag name.
+	W        string
+	Journal  *bool
+	WTimeout time.Duration
+}
+
+// w converts W into the type expected by the server: number of nodes or a string.
+func (wc *WriteConcern) w() interface{} {
+	if n, err := strconv.Atoi(wc.W); err == nil {
+		return n
+	}
+
+	return wc.W
+}
+
+func (wc *WriteConcern) toDriver() *writeconcern.WriteConcern {
+	out := &writeconcern.WriteConcern{
+		Journal: wc.Journal,
+	}
+
+	if wc.W != "" {
+		out.W = wc.w()
+	}
+
+	return out
+}
+
+// toBson builds the writeConcern document for the commands executed with RunCommand,
+// as the client write concern is not applied to them by the driver.
+func (wc *WriteConcern) toBson() bson.D {
+	out := bson.D{}
+
+	if wc.W != "" {
+		out = append(out, bson.E{Key: "w", Value: wc.w()})
+	}
+
+	if wc.Journal != nil {
+		out = append(out, bson.E{Key: "j", Value: *wc.Journal})
+	}
+
+	if wc.WTimeout > 0 {
+		out = append(out, bson.E{Key: "wtimeout", Value: wc.WTimeout.Milliseconds()})
+	}
+
+	return out
+}
+
 type ClientOptions struct {
-	Hosts              []string
-	Username           string
-	Password           string
+	// URI is the connection string. Hosts and credentials set explicitly take precedence over it.
+	URI      string
+	Hosts    []string
+	Username string
+	Password string
+	// AuthSource defaults to the authSource of the connection string, otherwise to admin
 	AuthSource         string
 	ReplicaSet         string
 	TLS                bool
 	InsecureSkipVerify bool
 	Certificate        string
+	// CertificateFile is the path to the CA certificate PEM file, used instead of Certificate.
+	CertificateFile string
+	// ClientCertificateFile and ClientKeyFile are the key pair presented to the server for mutual TLS.
+	ClientCertificateFile string
+	ClientKeyFile         string
+	WriteConcern          *WriteConcern
+	// ReadPreference is applied to the plain reads like listing indexes.
+	ReadPreference string
+	// ReadConcern is the level of the plain reads, e.g. majority. The server default is used when empty.
+	ReadConcern string
+	// Compressors is the list of wire protocol compressors in the order of preference.
+	Compressors []string
+	ZlibLevel   *int
+	// Direct connects to the single host without the topology discovery.
+	Direct bool
+	// RetryWrites and RetryReads keep the setting of the connection string, or the driver default, when nil.
+	RetryWrites *bool
+	RetryReads  *bool
+	// Zero values keep the driver defaults.
+	MaxPoolSize     uint64
+	MinPoolSize     uint64
+	MaxConnIdleTime time.Duration
+	// HeartbeatInterval is the time between the server monitoring checks, at least MinHeartbeatInterval.
+	HeartbeatInterval time.Duration
+	// LocalThreshold is the latency window above the fastest eligible server, the driver picks among
+	// the servers inside it.
+	LocalThreshold time.Duration
+	// RetryableCommands are the admin commands retried on transient errors.
+	// DefaultRetryableCommands are used when nil.
+	RetryableCommands []string
+	// CommandRetries is the number of additional attempts of a retryable command.
+	// The interval is doubled after every attempt, defaultCommandRetryInterval is used when zero.
+	CommandRetries       int
+	CommandRetryInterval time.Duration
+	// ConnectRetries is the number of additional Ping attempts on connect.
+	// The interval is doubled after every attempt, defaultConnectRetryInterval is used when zero.
+	ConnectRetries       int
+	ConnectRetryInterval time.Duration
+	// SkipPing connects without any round trip, the connection errors are returned by the first operation.
+	// The Comment is then sent without checking the server version, except on DocumentDB.
+	SkipPing bool
+	// PoolMetrics counts and logs the connection pool events, see Client.PoolStats.
+	PoolMetrics bool
+	// ServerType is ServerTypeMongoDB or ServerTypeDocumentDB, MongoDB is assumed when empty.
+	ServerType string
+	// OperationTimeout limits every client operation including its retries. Zero means no limit.
+	// WaitForIndexBuild has its own timeout, the operation timeout limits each of its checks.
+	OperationTimeout time.Duration
+	// Comment is attached to the write commands, so they can be found in the server logs and the profiler.
+	// It's dropped for the servers which don't accept it on every command, see commentMinVersion.
+	Comment string
 }
 
+// MinHeartbeatInterval is the shortest heartbeat interval accepted by the driver.
+const MinHeartbeatInterval = 500 * time.Millisecond
+
+// adminDatabase runs the server wide commands.
+const adminDatabase = "admin"
+
+const (
+	ServerTypeMongoDB    = "mongodb"
+	ServerTypeDocumentDB = "documentdb"
+)
+
 type Client struct {
 	mongo *mongo.Client
+	pool  *poolMetrics
 
 	ClientOptions
 }
 
+// IsDocumentDB reports whether the server is Amazon DocumentDB, which omits some fields like the user mechanisms.
+func (c *Client) IsDocumentDB() bool {
+	return c.ServerType == ServerTypeDocumentDB
+}
+
+// authSource returns the configured auth source, otherwise the one of the connection string, which is lost
+// when the credentials are set explicitly. Like the connection string credentials, it defaults to
+// the database of the connection string, then to admin.
+func authSource(configured, uri string) (string, error) {
+	if configured != "" || uri == "" {
+		return cmp.Or(configured, adminDatabase), nil
+	}
+
+	connString, err := connstring.Parse(uri)
+	if err != nil {
+		return "", err
+	}
+
+	return cmp.Or(connString.AuthSource, connString.Database, adminDatabase), nil
+}
+
 func New(ctx context.Context, options *ClientOptions) (*Client, error) {
-	opt := mongooptions.Client().
-		SetHosts(options.Hosts).
-		SetAuth(mongooptions.Credential{
+	opt := mongooptions.Client()
+
+	// The explicit options override the ones from the connection string
+	if options.URI != "" {
+		opt.ApplyURI(options.URI)
+	}
+
+	if len(options.Hosts) > 0 {
+		opt.SetHosts(options.Hosts)
+	}
+
+	if options.Username != "" {
+		source, err := authSource(options.AuthSource, options.URI)
+		if err != nil {
+			return nil, err
+		}
+
+		opt.SetAuth(mongooptions.Credential{
 			Username:   options.Username,
 			Password:   options.Password,
-			AuthSource: options.AuthSource,
-		}).
-		SetReplicaSet(options.ReplicaSet)
+			AuthSource: source,
+		})
+	}
+
+	if options.ReplicaSet != "" {
+		opt.SetReplicaSet(options.ReplicaSet)
+	}
+
+	if options.RetryWrites != nil {
+		opt.SetRetryWrites(*options.RetryWrites)
+	}
+
+	if options.RetryReads != nil {
+		opt.SetRetryReads(*options.RetryReads)
+	}
+
+	if options.Direct {
+		opt.SetDirect(true)
+	}
+
+	if options.MaxPoolSize > 0 {
+		opt.SetMaxPoolSize(options.MaxPoolSize)
+	}
+
+	if options.MinPoolSize > 0 {
+		opt.SetMinPoolSize(options.MinPoolSize)
+	}
+
+	if options.MaxConnIdleTime > 0 {
+		opt.SetMaxConnIdleTime(options.MaxConnIdleTime)
+	}
+
+	if options.HeartbeatInterval > 0 {
+		opt.SetHeartbeatInterval(options.HeartbeatInterval)
+	}
+
+	if options.LocalThreshold > 0 {
+		opt.SetLocalThreshold(options.LocalThreshold)
+	}
+
+	if len(options.Compressors) > 0 {
+		opt.SetCompressors(options.Compressors)
+	}
+
+	if options.ZlibLevel != nil {
+		opt.SetZlibLevel(*options.ZlibLevel)
+	}
+
+	if options.ReadPreference != "" {
+		mode, err := readpref.ModeFromString(options.ReadPreference)
+		if err != nil {
+			return nil, err
+		}
+
+		readPreference, err := readpref.New(mode)
+		if err != nil {
+			return nil, err
+		}
+
+		opt.SetReadPreference(readPreference)
+	}
+
+	if options.ReadConcern != "" {
+		opt.SetReadConcern(&readconcern.ReadConcern{Level: options.ReadConcern})
+	}
+
+	if options.WriteConcern != nil {
+		opt.SetWriteConcern(options.WriteConcern.toDriver())
+	}
 
 	if options.TLS {
 		tlsConfig := &tls.Config{
 			InsecureSkipVerify: options.InsecureSkipVerify,
 		}
 
-		if options.Certificate != "" {
+		certificate := []byte(options.Certificate)
+
+		if options.CertificateFile != "" {
+			var err error
+
+			certificate, err = os.ReadFile(options.CertificateFile)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read certificate file: %w", err)
+			}
+		}
+
+		if len(certificate) > 0 {
 			certPool := x509.NewCertPool()
 
-			ok := certPool.AppendCertsFromPEM([]byte(options.Certificate))
+			ok := certPool.AppendCertsFromPEM(certificate)
 			if !ok {
 				return nil, errors.New("failed to parse certificate")
 			}
@@ -53,23 +306,156 @@ func New(ctx context.Context, options *ClientOptions) (*Client, error) {
 			tlsConfig.RootCAs = certPool
 		}
 
+		if options.ClientCertificateFile != "" {
+			clientCertificate, err := tls.LoadX509KeyPair(options.ClientCertificateFile, options.ClientKeyFile)
+			if err != nil {
+				return nil, fmt.Errorf("failed to load client key pair: %w", err)
+			}
+
+			tlsConfig.Certificates = []tls.Certificate{clientCertificate}
+		}
+
 		opt.SetTLSConfig(tlsConfig)
 	}
 
+	var pool *poolMetrics
+
+	if options.PoolMetrics {
+		pool = &poolMetrics{}
+		opt.SetPoolMonitor(pool.monitor(ctx))
+	}
+
 	mongoClient, err := mongo.Connect(opt)
 	if err != nil {
 		return nil, err
 	}
 
-	err = mongoClient.Ping(ctx, nil)
-	if err != nil {
-		return nil, err
+	if !options.SkipPing {
+		err = ping(ctx, mongoClient, options.ConnectRetries, options.ConnectRetryInterval)
+		if err != nil {
+			_ = mongoClient.Disconnect(ctx)
+
+			return nil, err
+		}
+	}
+
+	if options.RetryableCommands == nil {
+		options.RetryableCommands = DefaultRetryableCommands
 	}
 
 	client := &Client{
 		mongo:         mongoClient,
+		pool:          pool,
 		ClientOptions: *options,
 	}
 
+	err = client.checkComment(ctx)
+	if err != nil {
+		_ = mongoClient.Disconnect(ctx)
+
+		return nil, err
+	}
+
 	return client, nil
 }
+
+// commentMinVersion is the first server version accepting a comment on every command.
+var commentMinVersion = []int{4, 4}
+
+// checkComment drops the comment when the server would reject the commands carrying it.
+func (c *Client) checkComment(ctx context.Context) error {
+	if c.Comment == "" {
+		return nil
+	}
+
+	supported := false
+
+	if !c.IsDocumentDB() {
+		// The version check is a round trip, the configured comment is trusted
+		if c.SkipPing {
+			return nil
+		}
+
+		var err error
+
+		supported, err = c.serverVersionAtLeast(ctx, commentMinVersion...)
+		if err != nil {
+			return err
+		}
+	}
+
+	if !supported {
+		tflog.Warn(ctx, "the server doesn't accept a comment on every command, operation_comment is ignored")
+
+		c.Comment = ""
+	}
+
+	return nil
+}
+
+// serverVersionAtLeast compares the version reported by buildInfo with the minimum version, e.g. 6, 3.
+func (c *Client) serverVersionAtLeast(ctx context.Context, minimum ...int) (bool, error) {
+	var result struct {
+		VersionArray []int `bson:"versionArray"`
+	}
+
+	err := c.mongo.Database(adminDatabase).RunCommand(ctx, bson.D{{Key: "buildInfo", Value: 1}}).Decode(&result)
+	if err != nil {
+		return false, fmt.Errorf("failed to read the server version: %w", err)
+	}
+
+	for i, part := range minimum {
+		if i >= len(result.VersionArray) || result.VersionArray[i] < part {
+			return false, nil
+		}
+
+		if result.VersionArray[i] > part {
+			return true, nil
+		}
+	}
+
+	return true, nil
+}
+
+// startOperation limits the operation to OperationTimeout. The returned function releases the context
+// and converts the error of an operation which ran out of time into an OperationTimeoutError.
+func (c *Client) startOperation(ctx context.Context, op string) (context.Context, func(*error)) {
+	if c.OperationTimeout <= 0 {
+		return ctx, func(*error) {}
+	}
+
+	operationCtx, cancel := context.WithTimeout(ctx, c.OperationTimeout)
+
+	return operationCtx, func(err *error) {
+		defer cancel()
+
+		// The deadline of the parent context is reported by the parent operation
+		if *err == nil || ctx.Err() != nil || !errors.Is(operationCtx.Err(), context.DeadlineExceeded) {
+			return
+		}
+
+		*err = OperationTimeoutError{Op: op, Timeout: c.OperationTimeout, Err: *err}
+	}
+}
+
+// withWriteOptions appends the configured write concern and operation comment to a write command.
+func (c *Client) withWriteOptions(command bson.D) bson.D {
+	if c.WriteConcern != nil {
+		command = append(command, bson.E{Key: "writeConcern", Value: c.WriteConcern.toBson()})
+	}
+
+	if c.Comment != "" {
+		command = append(command, bson.E{Key: "comment", Value: c.Comment})
+	}
+
+	return command
+}
+
+// Disconnect closes the connection pool. It's safe to call on a client that was never connected.
+func (c *Client) Disconnect(ctx context.Context) error {
+	if c == nil || c.mongo == nil {
+		return nil
+	}
+
+	return c.mongo.Disconnect(ctx)
+}
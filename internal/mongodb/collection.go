@@ -0,0 +1,409 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+const (
+	collectionTypeView = "view"
+
+	createCollectionCmd = "create"
+	listCollectionsCmd  = "listCollections"
+	updateCollectionCmd = "collMod"
+	deleteCollectionCmd = "drop"
+)
+
+type GetCollectionOptions struct {
+	Name     string
+	Database string
+	// Primary reads from the primary regardless of the read preference, to see the preceding writes.
+	Primary bool
+}
+
+// CollectionOptions are the collection options returned by listCollections.
+type CollectionOptions struct {
+	Capped *bool `bson:"capped,omitempty"`
+	// Size is the maximum size of a capped collection in bytes
+	Size *int64 `bson:"size,omitempty"`
+	// Max is the maximum number of documents in a capped collection
+	Max       *int64     `bson:"max,omitempty"`
+	Collation *Collation `bson:"collation,omitempty"`
+	// Validator is the document validation query, e.g. {"$jsonSchema": {...}}.
+	// An empty document removes the validation on update.
+	Validator        bson.D  `bson:"validator,omitempty"`
+	ValidationLevel  *string `bson:"validationLevel,omitempty"`
+	ValidationAction *string `bson:"validationAction,omitempty"`
+	// TimeSeries can only be set on create
+	TimeSeries *TimeSeriesOptions `bson:"timeseries,omitempty"`
+	// ExpireAfterSeconds removes the documents of a time series collection older than the given age
+	ExpireAfterSeconds *int64 `bson:"expireAfterSeconds,omitempty"`
+	// RemoveExpiration turns the automatic removal of documents off on update
+	RemoveExpiration bool `bson:"-"`
+}
+
+// cappedSizeUnit is the multiple of bytes the server rounds the size of a capped collection up to.
+const cappedSizeUnit = 256
+
+// CappedSize returns the size of a capped collection stored by the server for the requested size.
+func CappedSize(size int64) int64 {
+	if remainder := size % cappedSizeUnit; remainder != 0 {
+		return size + cappedSizeUnit - remainder
+	}
+
+	return size
+}
+
+// cappedResizeMinVersion is the first server version changing the size and the maximum of a capped collection.
+var cappedResizeMinVersion = []int{6, 0}
+
+// Collation adds the version of the collation rules reported by the server to the driver collation options.
+type Collation struct {
+	options.Collation `bson:",inline"`
+	// Version is set by the server, it's not sent on create
+	Version string `bson:"version,omitempty"`
+}
+
+type TimeSeriesOptions struct {
+	TimeField string `bson:"timeField"`
+	MetaField string `bson:"metaField,omitempty"`
+	// Granularity is "seconds", "minutes" or "hours"
+	Granularity string `bson:"granularity,omitempty"`
+}
+
+type Collection struct {
+	Name     string
+	Database string
+	// Type is "collection", "view" or "timeseries"
+	Type     string
+	ReadOnly bool
+	Options  CollectionOptions
+	// RawOptions is the options document as returned by listCollections, including the options
+	// not decoded in Options, e.g. the pipeline of a view
+	RawOptions bson.Raw
+}
+
+func (c *Collection) IsView() bool {
+	return c.Type == collectionTypeView
+}
+
+// OptionsJSON returns the options document returned by listCollections as relaxed extended JSON.
+func (c *Collection) OptionsJSON() (string, error) {
+	options := bson.D{}
+
+	if len(c.RawOptions) > 0 {
+		err := bson.Unmarshal(c.RawOptions, &options)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return DocumentJSON(options)
+}
+
+func (c *Client) GetCollection(ctx context.Context, options *GetCollectionOptions) (_ *Collection, err error) {
+	ctx, end := c.startOperation(ctx, "GetCollection")
+	defer end(&err)
+
+	tflog.Debug(ctx, "GetCollection", map[string]interface{}{
+		"name":     options.Name,
+		"database": options.Database,
+	})
+
+	specifications, err := c.database(options.Database, options.Primary).
+		ListCollectionSpecifications(ctx, bson.D{{Key: "name", Value: options.Name}})
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case len(specifications) == 0:
+		return nil, NotFoundError{options.Name, "collection"}
+	case len(specifications) > 1:
+		return nil, TooManyError{"collection"}
+	}
+
+	return newCollection(options.Database, &specifications[0])
+}
+
+type ListCollectionsOptions struct {
+	Database string
+	// NameRegex filters the collections on the server with a $regex on the name
+	NameRegex string
+}
+
+// ListCollections lists the collections, views and time series collections of the database sorted by name.
+func (c *Client) ListCollections(ctx context.Context, options *ListCollectionsOptions) (_ []Collection, err error) {
+	ctx, end := c.startOperation(ctx, "ListCollections")
+	defer end(&err)
+
+	tflog.Debug(ctx, "ListCollections", map[string]interface{}{
+		"database":   options.Database,
+		"name_regex": options.NameRegex,
+	})
+
+	filter := bson.D{}
+	if options.NameRegex != "" {
+		filter = bson.D{{Key: "name", Value: bson.D{{Key: "$regex", Value: options.NameRegex}}}}
+	}
+
+	specifications, err := c.mongo.Database(options.Database).ListCollectionSpecifications(ctx, filter)
+	if err != nil {
+		return nil, wrapCommandError(listCollectionsCmd, 0, err)
+	}
+
+	collections := make([]Collection, 0, len(specifications))
+
+	for i := range specifications {
+		collection, err := newCollection(options.Database, &specifications[i])
+		if err != nil {
+			return nil, err
+		}
+
+		collections = append(collections, *collection)
+	}
+
+	slices.SortFunc(collections, func(a, b Collection) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return collections, nil
+}
+
+func newCollection(database string, specification *mongo.CollectionSpecification) (*Collection, error) {
+	collection := &Collection{
+		Name:       specification.Name,
+		Database:   database,
+		Type:       specification.Type,
+		ReadOnly:   specification.ReadOnly,
+		RawOptions: specification.Options,
+	}
+
+	if len(specification.Options) > 0 {
+		err := bson.Unmarshal(specification.Options, &collection.Options)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return collection, nil
+}
+
+func (c *Client) CreateCollection(ctx context.Context, collection *Collection) (_ *Collection, err error) {
+	ctx, end := c.startOperation(ctx, "CreateCollection")
+	defer end(&err)
+
+	tflog.Debug(ctx, "CreateCollection", map[string]interface{}{
+		"name":     collection.Name,
+		"database": collection.Database,
+	})
+
+	command := bson.D{
+		{Key: createCollectionCmd, Value: collection.Name},
+	}
+
+	if collection.Options.Capped != nil {
+		command = append(command, bson.E{Key: "capped", Value: *collection.Options.Capped})
+	}
+
+	if collection.Options.Size != nil {
+		command = append(command, bson.E{Key: "size", Value: *collection.Options.Size})
+	}
+
+	if collection.Options.Max != nil {
+		command = append(command, bson.E{Key: "max", Value: *collection.Options.Max})
+	}
+
+	if collection.Options.Collation != nil {
+		command = append(command, bson.E{Key: "collation", Value: collationToBson(collection.Options.Collation)})
+	}
+
+	command = append(command, collection.Options.validationToBson()...)
+
+	if collection.Options.TimeSeries != nil {
+		command = append(command, bson.E{Key: "timeseries", Value: collection.Options.TimeSeries})
+	}
+
+	if collection.Options.ExpireAfterSeconds != nil {
+		command = append(command, bson.E{Key: "expireAfterSeconds", Value: *collection.Options.ExpireAfterSeconds})
+	}
+
+	err = c.runCollectionCommand(ctx, collection.Database, createCollectionCmd, command)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.GetCollection(ctx, &GetCollectionOptions{
+		Name:     collection.Name,
+		Database: collection.Database,
+		Primary:  true,
+	})
+}
+
+// UpdateCollection applies the options which can be changed in place with collMod:
+// the capped collection limits, the document validation and the time series granularity and expiration.
+// The capped collection limits are only set when they change, it requires MongoDB 6.0.
+func (c *Client) UpdateCollection(ctx context.Context, collection *Collection) (_ *Collection, err error) {
+	ctx, end := c.startOperation(ctx, "UpdateCollection")
+	defer end(&err)
+
+	tflog.Debug(ctx, "UpdateCollection", map[string]interface{}{
+		"name":     collection.Name,
+		"database": collection.Database,
+	})
+
+	if collection.Options.Size != nil || collection.Options.Max != nil {
+		supported, err := c.serverVersionAtLeast(ctx, cappedResizeMinVersion...)
+		if err != nil {
+			return nil, err
+		}
+
+		if !supported {
+			return nil, fmt.Errorf("changing the size or the maximum of a capped collection requires MongoDB %d.%d "+
+				"or later", cappedResizeMinVersion[0], cappedResizeMinVersion[1])
+		}
+	}
+
+	command := bson.D{
+		{Key: updateCollectionCmd, Value: collection.Name},
+	}
+
+	if collection.Options.Size != nil {
+		command = append(command, bson.E{Key: "cappedSize", Value: *collection.Options.Size})
+	}
+
+	if collection.Options.Max != nil {
+		command = append(command, bson.E{Key: "cappedMax", Value: *collection.Options.Max})
+	}
+
+	command = append(command, collection.Options.validationToBson()...)
+
+	// Only the granularity of a time series collection can be changed, to a coarser one
+	if collection.Options.TimeSeries != nil && collection.Options.TimeSeries.Granularity != "" {
+		command = append(command, bson.E{Key: "timeseries", Value: bson.D{
+			{Key: "granularity", Value: collection.Options.TimeSeries.Granularity},
+		}})
+	}
+
+	switch {
+	case collection.Options.RemoveExpiration:
+		command = append(command, bson.E{Key: "expireAfterSeconds", Value: "off"})
+	case collection.Options.ExpireAfterSeconds != nil:
+		command = append(command, bson.E{Key: "expireAfterSeconds", Value: *collection.Options.ExpireAfterSeconds})
+	}
+
+	if len(command) > 1 {
+		err := c.runCollectionCommand(ctx, collection.Database, updateCollectionCmd, command)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return c.GetCollection(ctx, &GetCollectionOptions{
+		Name:     collection.Name,
+		Database: collection.Database,
+		Primary:  true,
+	})
+}
+
+func (c *Client) DeleteCollection(ctx context.Context, options *GetCollectionOptions) (err error) {
+	ctx, end := c.startOperation(ctx, "DeleteCollection")
+	defer end(&err)
+
+	tflog.Debug(ctx, "DeleteCollection", map[string]interface{}{
+		"name":     options.Name,
+		"database": options.Database,
+	})
+
+	command := bson.D{
+		{Key: deleteCollectionCmd, Value: options.Name},
+	}
+
+	return c.runCollectionCommand(ctx, options.Database, deleteCollectionCmd, command)
+}
+
+func (c *Client) runCollectionCommand(ctx context.Context, database, cmd string, command bson.D) error {
+	response := c.runCommand(ctx, database, c.withWriteOptions(command))
+	if err := response.Err(); err != nil {
+		return wrapCommandError(cmd, 0, err)
+	}
+
+	result := Result{}
+
+	err := response.Decode(&result)
+	if err != nil {
+		return err
+	}
+
+	if result.Ok != 1 {
+		return FailedCommandError{cmd}
+	}
+
+	return nil
+}
+
+func (o *CollectionOptions) validationToBson() bson.D {
+	out := bson.D{}
+
+	if o.Validator != nil {
+		out = append(out, bson.E{Key: "validator", Value: o.Validator})
+	}
+
+	if o.ValidationLevel != nil {
+		out = append(out, bson.E{Key: "validationLevel", Value: *o.ValidationLevel})
+	}
+
+	if o.ValidationAction != nil {
+		out = append(out, bson.E{Key: "validationAction", Value: *o.ValidationAction})
+	}
+
+	return out
+}
+
+// collationToBson builds the collation document with the field names expected by the server.
+func collationToBson(collation *Collation) bson.D {
+	out := bson.D{
+		{Key: "locale", Value: collation.Locale},
+	}
+
+	if collation.CaseLevel {
+		out = append(out, bson.E{Key: "caseLevel", Value: true})
+	}
+
+	if collation.CaseFirst != "" {
+		out = append(out, bson.E{Key: "caseFirst", Value: collation.CaseFirst})
+	}
+
+	if collation.Strength != 0 {
+		out = append(out, bson.E{Key: "strength", Value: collation.Strength})
+	}
+
+	if collation.NumericOrdering {
+		out = append(out, bson.E{Key: "numericOrdering", Value: true})
+	}
+
+	if collation.Alternate != "" {
+		out = append(out, bson.E{Key: "alternate", Value: collation.Alternate})
+	}
+
+	if collation.MaxVariable != "" {
+		out = append(out, bson.E{Key: "maxVariable", Value: collation.MaxVariable})
+	}
+
+	if collation.Normalization {
+		out = append(out, bson.E{Key: "normalization", Value: true})
+	}
+
+	if collation.Backwards {
+		out = append(out, bson.E{Key: "backwards", Value: true})
+	}
+
+	return out
+}
@@ -0,0 +1,21 @@
+package mongodb
+
+import "testing"
+
+func TestCappedSize(t *testing.T) {
+	t.Parallel()
+
+	tests := map[int64]int64{
+		1:    256,
+		256:  256,
+		1000: 1024,
+		1024: 1024,
+		4097: 4352,
+	}
+
+	for size, expected := range tests {
+		if actual := CappedSize(size); actual != expected {
+			t.Errorf("expected the size %d to be stored as %d, got %d", size, expected, actual)
+		}
+	}
+}
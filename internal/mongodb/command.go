@@ -0,0 +1,135 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// volatileResultFields change on every command and are removed from the results to avoid false drift.
+var volatileResultFields = []string{"$clusterTime", "operationTime", "electionId", "lastCommittedOpTime"}
+
+// DefaultMutatingCommands are the commands rejected by the read only command data source by default.
+var DefaultMutatingCommands = []string{
+	insertDocumentCmd, updateDocumentCmd, deleteDocumentCmd, "findAndModify", "bulkWrite",
+	createCollectionCmd, deleteCollectionCmd, updateCollectionCmd, "dropDatabase", "renameCollection",
+	"convertToCapped", "cloneCollectionAsCapped",
+	"createIndexes", "dropIndexes", "compact", "reIndex",
+	createUserCmd, updateUserCmr, deleteUserCmd, "dropAllUsersFromDatabase",
+	"grantRolesToUser", "revokeRolesFromUser",
+	createRoleCmd, updateRoleCmd, deleteRoleCmd, "dropAllRolesFromDatabase",
+	"grantRolesToRole", "revokeRolesFromRole", "grantPrivilegesToRole", "revokePrivilegesFromRole",
+	setParameterCmd, "setFeatureCompatibilityVersion", setDefaultRWConcernCmd, "shutdown", "fsync",
+	"replSetReconfig", "replSetStepDown", "replSetFreeze", "replSetInitiate",
+	shardCollectionCmd, reshardCollectionCmd, "addShard", "removeShard", "movePrimary", "moveChunk",
+	enableShardingCmd, "killOp", "killCursors", "killSessions", "killAllSessions",
+	"applyOps", "mapReduce", "setClusterParameter", "configureFailPoint", "refineCollectionShardKey",
+	"split", "mergeChunks", "setIndexCommitQuorum",
+	"createSearchIndexes", "updateSearchIndex", "dropSearchIndex",
+}
+
+// writeStages are the aggregation stages writing the results to a collection.
+var writeStages = []string{"$out", "$merge"}
+
+// WriteStage returns the stage of an aggregate command writing to a collection, or an empty string.
+func WriteStage(command bson.D) string {
+	if len(command) == 0 || !strings.EqualFold(command[0].Key, "aggregate") {
+		return ""
+	}
+
+	for _, e := range command {
+		if e.Key != "pipeline" {
+			continue
+		}
+
+		pipeline, ok := e.Value.(bson.A)
+		if !ok {
+			return ""
+		}
+
+		for _, stage := range pipeline {
+			stage, ok := stage.(bson.D)
+			if ok && len(stage) > 0 && slices.Contains(writeStages, stage[0].Key) {
+				return stage[0].Key
+			}
+		}
+	}
+
+	return ""
+}
+
+type RunCommandOptions struct {
+	Database string
+	// Command is an extended JSON document, the first field is the command name
+	Command string
+	// Write adds the write concern and the comment of the client, unless the command sets them
+	Write bool
+}
+
+// withMissingWriteOptions adds the write concern and the comment of the client the command doesn't set.
+func (c *Client) withMissingWriteOptions(command bson.D) bson.D {
+	for _, option := range c.withWriteOptions(bson.D{}) {
+		set := slices.ContainsFunc(command, func(e bson.E) bool {
+			return e.Key == option.Key
+		})
+
+		if !set {
+			command = append(command, option)
+		}
+	}
+
+	return command
+}
+
+// RunCommand runs an arbitrary command and returns the response as relaxed extended JSON.
+func (c *Client) RunCommand(ctx context.Context, options *RunCommandOptions) (_ string, err error) {
+	ctx, end := c.startOperation(ctx, "RunCommand")
+	defer end(&err)
+
+	command, err := ParseDocumentJSON(options.Command)
+	if err != nil {
+		return "", fmt.Errorf("invalid command: %w", err)
+	}
+
+	if len(command) == 0 {
+		return "", errors.New("invalid command: the command document is empty")
+	}
+
+	if options.Write {
+		command = c.withMissingWriteOptions(command)
+	}
+
+	cmd := command[0].Key
+
+	tflog.Debug(ctx, "RunCommand", map[string]interface{}{
+		"database": options.Database,
+		"command":  cmd,
+	})
+
+	var result bson.D
+
+	response := c.runCommand(ctx, options.Database, command)
+	if err = response.Err(); err != nil {
+		return "", wrapCommandError(cmd, 0, err)
+	}
+
+	err = response.Decode(&result)
+	if err != nil {
+		return "", err
+	}
+
+	out := make(bson.D, 0, len(result))
+
+	for _, e := range result {
+		if !slices.Contains(volatileResultFields, e.Key) {
+			out = append(out, e)
+		}
+	}
+
+	return DocumentJSON(out)
+}
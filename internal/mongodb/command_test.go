@@ -0,0 +1,58 @@
+package mongodb
+
+import (
+	"testing"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+func TestWithMissingWriteOptions(t *testing.T) {
+	t.Parallel()
+
+	c := &Client{ClientOptions: ClientOptions{
+		WriteConcern: &WriteConcern{W: "majority"},
+		Comment:      "terraform",
+	}}
+
+	tests := map[string]struct {
+		command  string
+		expected string
+	}{
+		"both added": {
+			command:  `{"insert":"users","documents":[]}`,
+			expected: `{"insert":"users","documents":[],"writeConcern":{"w":"majority"},"comment":"terraform"}`,
+		},
+		"write concern set": {
+			command:  `{"insert":"users","documents":[],"writeConcern":{"w":1}}`,
+			expected: `{"insert":"users","documents":[],"writeConcern":{"w":1},"comment":"terraform"}`,
+		},
+		"comment set": {
+			command:  `{"insert":"users","documents":[],"comment":"seed"}`,
+			expected: `{"insert":"users","documents":[],"comment":"seed","writeConcern":{"w":"majority"}}`,
+		},
+		"both set": {
+			command:  `{"insert":"users","documents":[],"comment":"seed","writeConcern":{"w":1}}`,
+			expected: `{"insert":"users","documents":[],"comment":"seed","writeConcern":{"w":1}}`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			command, err := ParseDocumentJSON(test.command)
+			if err != nil {
+				t.Fatalf("invalid command: %v", err)
+			}
+
+			actual, err := bson.MarshalExtJSON(c.withMissingWriteOptions(command), false, false)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if string(actual) != test.expected {
+				t.Errorf("expected %s, got %s", test.expected, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,237 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+const (
+	findDocumentCmd   = "find"
+	insertDocumentCmd = "insert"
+	updateDocumentCmd = "update"
+	deleteDocumentCmd = "delete"
+)
+
+// documentIDField is the primary key of every document.
+const documentIDField = "_id"
+
+type GetDocumentOptions struct {
+	Database   string
+	Collection string
+	// ID is the _id of the document. An ObjectID is given as its hex string
+	ID string
+	// Primary reads from the primary regardless of the read preference, to see the preceding writes.
+	Primary bool
+}
+
+type Document struct {
+	Database   string
+	Collection string
+	// ID is the _id of the document, ObjectIDs are returned as hex strings.
+	// An empty ID lets the server generate an ObjectID on insert
+	ID string
+	// Body is the document without its _id
+	Body bson.D
+}
+
+// documentIDFilter matches the _id stored as a string, or as an ObjectID when the id is an ObjectID hex string.
+func documentIDFilter(id string) bson.D {
+	values := bson.A{id}
+
+	if oid, err := bson.ObjectIDFromHex(id); err == nil {
+		values = append(values, oid)
+	}
+
+	return bson.D{{Key: documentIDField, Value: bson.D{{Key: "$in", Value: values}}}}
+}
+
+// documentID converts the _id read from the server into the string used by Document.
+// The other _id types can't be told apart from a string, so they are rejected.
+func documentID(value interface{}) (string, error) {
+	switch id := value.(type) {
+	case bson.ObjectID:
+		return id.Hex(), nil
+	case string:
+		return id, nil
+	default:
+		return "", UnsupportedDocumentIDError{ID: fmt.Sprint(id), Type: fmt.Sprintf("%T", id)}
+	}
+}
+
+// unsupportedDocumentID returns an UnsupportedDocumentIDError when the id of a missing document matches
+// a numeric _id, e.g. on import, so it's not reported as not found.
+func unsupportedDocumentID(ctx context.Context, collection *mongo.Collection, id string) error {
+	number, err := strconv.ParseFloat(id, 64)
+	if err != nil {
+		return nil
+	}
+
+	var result struct {
+		ID interface{} `bson:"_id"`
+	}
+
+	// The numbers of every BSON type are compared by value
+	err = collection.FindOne(ctx, bson.D{{Key: documentIDField, Value: number}}).Decode(&result)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil
+		}
+
+		return wrapCommandError(findDocumentCmd, 0, err)
+	}
+
+	_, err = documentID(result.ID)
+
+	return err
+}
+
+func (c *Client) GetDocument(ctx context.Context, options *GetDocumentOptions) (_ *Document, err error) {
+	ctx, end := c.startOperation(ctx, "GetDocument")
+	defer end(&err)
+
+	tflog.Debug(ctx, "GetDocument", map[string]interface{}{
+		"database":   options.Database,
+		"collection": options.Collection,
+		"id":         options.ID,
+	})
+
+	var result bson.D
+
+	collection := c.database(options.Database, options.Primary).Collection(options.Collection)
+
+	err = collection.FindOne(ctx, documentIDFilter(options.ID)).Decode(&result)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			if err = unsupportedDocumentID(ctx, collection, options.ID); err != nil {
+				return nil, err
+			}
+
+			return nil, NotFoundError{options.ID, "document"}
+		}
+
+		return nil, wrapCommandError(findDocumentCmd, 0, err)
+	}
+
+	document := &Document{
+		Database:   options.Database,
+		Collection: options.Collection,
+		Body:       bson.D{},
+	}
+
+	for _, e := range result {
+		if e.Key == documentIDField {
+			document.ID, err = documentID(e.Value)
+			if err != nil {
+				return nil, err
+			}
+
+			continue
+		}
+
+		document.Body = append(document.Body, e)
+	}
+
+	return document, nil
+}
+
+// SaveDocument inserts the document, or replaces the document with the same _id when the ID is set.
+func (c *Client) SaveDocument(ctx context.Context, document *Document) (_ *Document, err error) {
+	ctx, end := c.startOperation(ctx, "SaveDocument")
+	defer end(&err)
+
+	tflog.Debug(ctx, "SaveDocument", map[string]interface{}{
+		"database":   document.Database,
+		"collection": document.Collection,
+		"id":         document.ID,
+	})
+
+	id := document.ID
+
+	if id != "" {
+		// The replacement has no _id, so an existing document keeps the type of its _id
+		command := bson.D{
+			{Key: updateDocumentCmd, Value: document.Collection},
+			{Key: "updates", Value: bson.A{bson.D{
+				{Key: "q", Value: documentIDFilter(id)},
+				{Key: "u", Value: document.Body},
+			}}},
+		}
+
+		var result struct {
+			N int64 `bson:"n"`
+		}
+
+		err = c.runCommand(ctx, document.Database, c.withWriteOptions(command)).Decode(&result)
+		if err != nil {
+			return nil, wrapCommandError(updateDocumentCmd, 0, err)
+		}
+
+		if result.N > 0 {
+			return c.GetDocument(ctx, &GetDocumentOptions{
+				Database:   document.Database,
+				Collection: document.Collection,
+				ID:         id,
+				Primary:    true,
+			})
+		}
+	}
+
+	var insertedID interface{} = id
+	if id == "" {
+		insertedID = bson.NewObjectID()
+	}
+
+	command := bson.D{
+		{Key: insertDocumentCmd, Value: document.Collection},
+		{Key: "documents", Value: bson.A{append(bson.D{{Key: documentIDField, Value: insertedID}}, document.Body...)}},
+	}
+
+	err = c.runCommand(ctx, document.Database, c.withWriteOptions(command)).Err()
+	if err != nil {
+		return nil, wrapCommandError(insertDocumentCmd, 0, err)
+	}
+
+	id, err = documentID(insertedID)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.GetDocument(ctx, &GetDocumentOptions{
+		Database:   document.Database,
+		Collection: document.Collection,
+		ID:         id,
+		Primary:    true,
+	})
+}
+
+func (c *Client) DeleteDocument(ctx context.Context, options *GetDocumentOptions) (err error) {
+	ctx, end := c.startOperation(ctx, "DeleteDocument")
+	defer end(&err)
+
+	tflog.Debug(ctx, "DeleteDocument", map[string]interface{}{
+		"database":   options.Database,
+		"collection": options.Collection,
+		"id":         options.ID,
+	})
+
+	command := bson.D{
+		{Key: deleteDocumentCmd, Value: options.Collection},
+		{Key: "deletes", Value: bson.A{bson.D{
+			{Key: "q", Value: documentIDFilter(options.ID)},
+			{Key: "limit", Value: 1},
+		}}},
+	}
+
+	err = c.runCommand(ctx, options.Database, c.withWriteOptions(command)).Err()
+	if err != nil {
+		return wrapCommandError(deleteDocumentCmd, 0, err)
+	}
+
+	return nil
+}
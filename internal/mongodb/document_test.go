@@ -0,0 +1,48 @@
+package mongodb
+
+import (
+	"errors"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+func TestDocumentID(t *testing.T) {
+	t.Parallel()
+
+	oid := bson.NewObjectID()
+
+	tests := map[string]struct {
+		value       interface{}
+		expected    string
+		unsupported bool
+	}{
+		"string":   {value: "user-1", expected: "user-1"},
+		"ObjectID": {value: oid, expected: oid.Hex()},
+		"int32":    {value: int32(5), unsupported: true},
+		"int64":    {value: int64(5), unsupported: true},
+		"double":   {value: 5.0, unsupported: true},
+		"document": {value: bson.D{{Key: "a", Value: 1}}, unsupported: true},
+		"binary":   {value: bson.Binary{Data: []byte{1}}, unsupported: true},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			id, err := documentID(test.value)
+
+			if test.unsupported {
+				if !errors.As(err, &UnsupportedDocumentIDError{}) {
+					t.Errorf("expected an UnsupportedDocumentIDError, got %q, %v", id, err)
+				}
+
+				return
+			}
+
+			if err != nil || id != test.expected {
+				t.Errorf("expected %q, got %q, %v", test.expected, id, err)
+			}
+		})
+	}
+}
@@ -1,7 +1,38 @@
 package mongodb
 
 import (
+	"errors"
 	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+const (
+	// unauthorizedCode is returned when the user lacks the privileges required by a command.
+	unauthorizedCode = 13
+	// authenticationFailedCode is returned when the credentials are rejected.
+	authenticationFailedCode = 18
+	// duplicateKeyCode is returned when a unique index rejects a document.
+	duplicateKeyCode = 11000
+	// indexOptionsConflictCode is returned when an index with the same keys but other options exists.
+	indexOptionsConflictCode = 85
+	// indexKeySpecsConflictCode is returned when an index with the same name but other keys exists.
+	indexKeySpecsConflictCode = 86
+	// roleAlreadyExistsCode and userAlreadyExistsCode are returned by createRole and createUser.
+	roleAlreadyExistsCode = 51002
+	userAlreadyExistsCode = 51003
+	// The not found codes are returned by the commands changing a user, role, index or collection which is missing.
+	userNotFoundCode      = 11
+	roleNotFoundCode      = 31
+	indexNotFoundCode     = 27
+	namespaceNotFoundCode = 26
+	// maxTimeMSExpiredCode is returned by the server when a command exceeds its maxTimeMS limit.
+	maxTimeMSExpiredCode = 50
+	// writeConcernFailedCode is returned when the write concern is not satisfied before wtimeout.
+	writeConcernFailedCode = 64
+	// illegalOperationCode is returned e.g. when unsetting the default write concern on MongoDB 5.0 and later.
+	illegalOperationCode = 20
 )
 
 type NotFoundError struct {
@@ -28,3 +59,224 @@ type FailedCommandError struct {
 func (e FailedCommandError) Error() string {
 	return e.Cmd + " command failed"
 }
+
+// ParallelArraysError is returned when a compound index is built over more than one array field.
+type ParallelArraysError struct {
+	Name string
+	Err  error
+}
+
+func (e ParallelArraysError) Error() string {
+	return fmt.Sprintf("index %s cannot be built: at most one field of a compound multikey index "+
+		"can hold an array, %s", e.Name, e.Err)
+}
+
+func (e ParallelArraysError) Unwrap() error {
+	return e.Err
+}
+
+// MaxTimeExpiredError is returned when the server aborts a command which exceeded its maxTimeMS limit.
+type MaxTimeExpiredError struct {
+	Cmd       string
+	MaxTimeMS int64
+	Err       error
+}
+
+func (e MaxTimeExpiredError) Error() string {
+	return fmt.Sprintf("%s command exceeded the time limit of %dms", e.Cmd, e.MaxTimeMS)
+}
+
+func (e MaxTimeExpiredError) Unwrap() error {
+	return e.Err
+}
+
+// OperationTimeoutError is returned when a client operation does not complete within the operation timeout.
+// Unlike MaxTimeExpiredError, the operation is abandoned by the client and may still complete on the server.
+type OperationTimeoutError struct {
+	Op      string
+	Timeout time.Duration
+	Err     error
+}
+
+func (e OperationTimeoutError) Error() string {
+	return fmt.Sprintf("%s did not complete within the operation timeout of %s: %s", e.Op, e.Timeout, e.Err)
+}
+
+func (e OperationTimeoutError) Unwrap() error {
+	return e.Err
+}
+
+// UnsupportedDocumentIDError is returned for a document whose _id is neither a string nor an ObjectID.
+type UnsupportedDocumentIDError struct {
+	ID   string
+	Type string
+}
+
+func (e UnsupportedDocumentIDError) Error() string {
+	return fmt.Sprintf("document %s has an _id of type %s, only string and ObjectID _id values are supported",
+		e.ID, e.Type)
+}
+
+// IndexBuildTimeoutError is returned when an index is still being built after the wait timeout.
+// The build is not aborted, the index becomes usable once it completes.
+type IndexBuildTimeoutError struct {
+	Name    string
+	Timeout time.Duration
+}
+
+func (e IndexBuildTimeoutError) Error() string {
+	return fmt.Sprintf("index %s is still being built after %s, the build goes on in the background", e.Name, e.Timeout)
+}
+
+// WriteConcernTimeoutError is returned when a command was applied on the primary,
+// but not acknowledged by the requested number of members before the write concern timeout.
+type WriteConcernTimeoutError struct {
+	Cmd string
+	Err error
+}
+
+func (e WriteConcernTimeoutError) Error() string {
+	return fmt.Sprintf("%s command was applied on the primary, but was not acknowledged by enough members "+
+		"before the write concern timeout: %s", e.Cmd, e.Err)
+}
+
+func (e WriteConcernTimeoutError) Unwrap() error {
+	return e.Err
+}
+
+// UnauthorizedError is returned when the connected user lacks the privileges required by a command.
+type UnauthorizedError struct {
+	Cmd string
+	Err error
+}
+
+func (e UnauthorizedError) Error() string {
+	return fmt.Sprintf("not authorized to run %s command: %s", e.Cmd, e.Err)
+}
+
+func (e UnauthorizedError) Unwrap() error {
+	return e.Err
+}
+
+// notPrimaryErrorCodes are returned when a write is sent to a member which is not, or no longer, the primary.
+var notPrimaryErrorCodes = []int{
+	189,   // PrimarySteppedDown
+	10107, // NotWritablePrimary
+	11602, // InterruptedDueToReplStateChange
+	13435, // NotPrimaryNoSecondaryOk
+	13436, // NotPrimaryOrSecondary
+}
+
+// ServerCommandError is a command rejected by the server, with the error code and its name.
+type ServerCommandError struct {
+	Cmd      string
+	Code     int32
+	CodeName string
+	Err      error
+}
+
+func (e ServerCommandError) Error() string {
+	if e.CodeName == "" {
+		return fmt.Sprintf("%s command failed with code %d: %s", e.Cmd, e.Code, e.Err)
+	}
+
+	return fmt.Sprintf("%s command failed with code %d (%s): %s", e.Cmd, e.Code, e.CodeName, e.Err)
+}
+
+func (e ServerCommandError) Unwrap() error {
+	return e.Err
+}
+
+// ErrorCode returns the server error code of the error and its name, when the server returned one.
+func ErrorCode(err error) (int32, string, bool) {
+	var commandErr mongo.CommandError
+	if errors.As(err, &commandErr) {
+		return commandErr.Code, commandErr.Name, true
+	}
+
+	var writeErr mongo.WriteException
+	if errors.As(err, &writeErr) {
+		if writeErr.WriteConcernError != nil {
+			return int32(writeErr.WriteConcernError.Code), writeErr.WriteConcernError.Name, true //nolint:gosec
+		}
+
+		if len(writeErr.WriteErrors) > 0 {
+			return int32(writeErr.WriteErrors[0].Code), "", true //nolint:gosec
+		}
+	}
+
+	var serverErr mongo.ServerError
+	if errors.As(err, &serverErr) {
+		if codes := serverErr.ErrorCodes(); len(codes) > 0 {
+			return int32(codes[0]), "", true //nolint:gosec
+		}
+	}
+
+	return 0, "", false
+}
+
+// IsAuthError reports whether the command was rejected because of missing privileges or invalid credentials.
+func IsAuthError(err error) bool {
+	return hasErrorCode(err, unauthorizedCode, authenticationFailedCode)
+}
+
+// IsDuplicateError reports whether the command failed because the user, role, index or document already exists.
+func IsDuplicateError(err error) bool {
+	return mongo.IsDuplicateKeyError(err) || hasErrorCode(err,
+		duplicateKeyCode, indexOptionsConflictCode, indexKeySpecsConflictCode,
+		roleAlreadyExistsCode, userAlreadyExistsCode)
+}
+
+// IsNotFoundError reports whether the user, role, index or collection does not exist.
+func IsNotFoundError(err error) bool {
+	return errors.As(err, &NotFoundError{}) ||
+		hasErrorCode(err, userNotFoundCode, roleNotFoundCode, indexNotFoundCode, namespaceNotFoundCode)
+}
+
+// IsNotPrimaryError reports whether the command was sent to a member which is not the primary,
+// usually during an election.
+func IsNotPrimaryError(err error) bool {
+	return hasErrorCode(err, notPrimaryErrorCodes...)
+}
+
+func hasErrorCode(err error, codes ...int) bool {
+	var serverErr mongo.ServerError
+	if !errors.As(err, &serverErr) {
+		return false
+	}
+
+	for _, code := range codes {
+		if serverErr.HasErrorCode(code) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// wrapCommandError converts the well known server errors of a command into the typed errors.
+func wrapCommandError(cmd string, maxTimeMS int64, err error) error {
+	var serverErr mongo.ServerError
+	if !errors.As(err, &serverErr) {
+		return err
+	}
+
+	if serverErr.HasErrorCode(unauthorizedCode) {
+		return UnauthorizedError{Cmd: cmd, Err: err}
+	}
+
+	if serverErr.HasErrorCode(maxTimeMSExpiredCode) {
+		return MaxTimeExpiredError{Cmd: cmd, MaxTimeMS: maxTimeMS, Err: err}
+	}
+
+	if serverErr.HasErrorCode(writeConcernFailedCode) {
+		return WriteConcernTimeoutError{Cmd: cmd, Err: err}
+	}
+
+	code, codeName, ok := ErrorCode(err)
+	if !ok {
+		return err
+	}
+
+	return ServerCommandError{Cmd: cmd, Code: code, CodeName: codeName, Err: err}
+}
@@ -0,0 +1,34 @@
+package mongodb
+
+import (
+	"errors"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+func TestWrapCommandErrorMaxTimeExpired(t *testing.T) {
+	t.Parallel()
+
+	serverErr := mongo.CommandError{
+		Code:    maxTimeMSExpiredCode,
+		Name:    "MaxTimeMSExpired",
+		Message: "operation exceeded time limit",
+	}
+
+	err := wrapCommandError("createUser", 100, serverErr)
+
+	var maxTimeErr MaxTimeExpiredError
+	if !errors.As(err, &maxTimeErr) || maxTimeErr.MaxTimeMS != 100 {
+		t.Fatalf("expected a MaxTimeExpiredError of 100ms, got %v", err)
+	}
+
+	var commandErr mongo.CommandError
+	if !errors.As(err, &commandErr) || commandErr.Code != maxTimeMSExpiredCode {
+		t.Errorf("expected the server error to be unwrapped, got %v", err)
+	}
+
+	if !mongo.IsTimeout(err) {
+		t.Errorf("expected the driver to report a timeout for %v", err)
+	}
+}
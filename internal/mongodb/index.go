@@ -2,90 +2,296 @@ package mongodb
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strconv"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"go.mongodb.org/mongo-driver/v2/bson"
 	"go.mongodb.org/mongo-driver/v2/mongo"
-	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+const (
+	createIndexCmd = "createIndexes"
+	listIndexesCmd = "listIndexes"
+	deleteIndexCmd = "dropIndexes"
+
+	// currentOpStage lists the operations in progress, including the index builds.
+	currentOpStage = "$currentOp"
+
+	// cannotIndexParallelArraysCode is returned by the server when more than one key of a compound index is an array.
+	cannotIndexParallelArraysCode = 171
 )
 
 type GetIndexOptions struct {
 	Name       string
 	Database   string
 	Collection string
+	// Primary reads from the primary regardless of the read preference, to see the preceding writes.
+	Primary bool
 }
 
-// setIndexOptions is a workaround to use pointers. As an alternative, we can check each option for nil and then set it.
-func setIndexOptions(index *Index) func(*options.IndexOptions) error {
-	return func(opts *options.IndexOptions) error {
-		opts.Unique = index.Options.Unique
-		opts.Sparse = index.Options.Sparse
-		opts.Hidden = index.Options.Hidden
-		opts.Collation = index.Options.Collation
-		opts.ExpireAfterSeconds = index.Options.ExpireAfterSeconds
-		opts.SphereVersion = index.Options.SphereVersion
-		opts.Bits = index.Options.Bits
-		opts.Min = index.Options.Min
-		opts.Max = index.Options.Max
-		opts.DefaultLanguage = index.Options.DefaultLanguage
-		opts.LanguageOverride = index.Options.LanguageOverride
-		opts.TextVersion = index.Options.TextIndexVersion
-
-		if len(index.Options.PartialFilterExpression) > 0 {
-			opts.PartialFilterExpression = index.Options.PartialFilterExpression
+func (c *Client) CreateIndex(ctx context.Context, index *Index) (_ *Index, err error) {
+	ctx, end := c.startOperation(ctx, "CreateIndex")
+	defer end(&err)
+
+	tflog.Debug(ctx, "CreateIndex", map[string]interface{}{
+		"database":   index.Database,
+		"collection": index.Collection,
+		"name":       index.Name,
+	})
+
+	if index.Keys.IsCompoundWildcard() {
+		supported, err := c.serverVersionAtLeast(ctx, compoundWildcardMinVersion...)
+		if err != nil {
+			return nil, err
 		}
 
-		if len(index.Options.WildcardProjection) > 0 {
-			opts.WildcardProjection = index.Options.WildcardProjection
+		if !supported {
+			return nil, fmt.Errorf("compound wildcard indexes require MongoDB %d.%d or later",
+				compoundWildcardMinVersion[0], compoundWildcardMinVersion[1])
 		}
+	}
 
-		if len(index.Options.Weights) > 0 {
-			opts.Weights = index.Options.Weights
+	if index.Keys.HasType(GeoHaystackIndexType) {
+		removed, err := c.serverVersionAtLeast(ctx, geoHaystackRemovedVersion...)
+		if err != nil {
+			return nil, err
 		}
 
-		return nil
+		if removed {
+			return nil, fmt.Errorf("geoHaystack indexes were removed in MongoDB %d.%d, use a 2d index instead",
+				geoHaystackRemovedVersion[0], geoHaystackRemovedVersion[1])
+		}
 	}
-}
 
-func (c *Client) CreateIndex(ctx context.Context, index *Index) (*Index, error) {
-	tflog.Debug(ctx, "CreateIndex", map[string]interface{}{
-		"database":   index.Database,
-		"collection": index.Collection,
-		"name":       index.Name,
-	})
+	if index.Keys.HasType(ColumnstoreIndexType) {
+		supported, err := c.serverVersionAtLeast(ctx, columnstoreMinVersion...)
+		if err != nil {
+			return nil, err
+		}
 
-	opts := options.Index().
-		SetName(index.Name)
+		if !supported {
+			return nil, fmt.Errorf("column store indexes require MongoDB %d.%d or later",
+				columnstoreMinVersion[0], columnstoreMinVersion[1])
+		}
+	}
 
-	opts.Opts = append(opts.Opts, setIndexOptions(index))
+	spec, err := index.spec()
+	if err != nil {
+		return nil, err
+	}
 
-	indexModel := mongo.IndexModel{
-		Keys:    index.Keys.toBson(),
-		Options: opts,
+	// The command is run directly, as the driver applies neither the wtimeout of the write concern
+	// nor the comment to createIndexes, and its index options lack the columnstore projection
+	command := bson.D{
+		{Key: createIndexCmd, Value: index.Collection},
+		{Key: "indexes", Value: bson.A{spec}},
 	}
 
-	collection := c.mongo.Database(index.Database).Collection(index.Collection)
+	if quorum := index.commitQuorum(); quorum != nil {
+		command = append(command, bson.E{Key: "commitQuorum", Value: quorum})
+	}
 
-	_, err := collection.Indexes().CreateOne(ctx, indexModel)
+	// Creating an index with the same definition again is a no-op, so the command is safe to retry
+	err = c.runCommand(ctx, index.Database, c.withWriteOptions(command)).Err()
 	if err != nil {
-		return nil, fmt.Errorf("error creating index: %w", err)
+		var serverErr mongo.ServerError
+		if errors.As(err, &serverErr) && serverErr.HasErrorCode(cannotIndexParallelArraysCode) {
+			return nil, ParallelArraysError{Name: index.Name, Err: err}
+		}
+
+		return nil, fmt.Errorf("error creating index: %w", wrapCommandError(createIndexCmd, 0, err))
 	}
 
 	return c.GetIndex(ctx, &GetIndexOptions{
 		Name:       index.Name,
 		Database:   index.Database,
 		Collection: index.Collection,
+		Primary:    true,
 	})
 }
 
-func (c *Client) GetIndex(ctx context.Context, opt *GetIndexOptions) (*Index, error) {
-	collection := c.mongo.Database(opt.Database).Collection(opt.Collection)
+// commitQuorum converts CommitQuorum into the type expected by the server: number of members or a string.
+// It returns nil when the default quorum is used.
+func (i *Index) commitQuorum() interface{} {
+	if i.CommitQuorum == "" {
+		return nil
+	}
 
-	cursor, err := collection.Indexes().List(ctx)
+	if n, err := strconv.ParseInt(i.CommitQuorum, 10, 32); err == nil {
+		return int32(n)
+	}
+
+	return i.CommitQuorum
+}
+
+// compoundWildcardMinVersion is the first server version supporting wildcard keys in compound indexes.
+var compoundWildcardMinVersion = []int{7, 0}
+
+// geoHaystackRemovedVersion is the first server version without the geoHaystack indexes.
+var geoHaystackRemovedVersion = []int{5, 0}
+
+// columnstoreMinVersion is the first server version supporting column store indexes.
+var columnstoreMinVersion = []int{6, 3}
+
+// spec builds the index specification of the createIndexes command. The options are written with the field
+// names of listIndexes, except for the collation, and the index version set by the server is left out.
+func (i *Index) spec() (bson.D, error) {
+	indexOptions := i.Options
+	indexOptions.Collation = nil
+	indexOptions.IndexVersion = nil
+
+	data, err := bson.Marshal(indexOptions)
 	if err != nil {
 		return nil, err
 	}
 
+	var optionsSpec bson.D
+	if err = bson.Unmarshal(data, &optionsSpec); err != nil {
+		return nil, err
+	}
+
+	spec := append(bson.D{
+		{Key: "key", Value: i.Keys.toBson()},
+		{Key: "name", Value: i.Name},
+	}, optionsSpec...)
+
+	if i.Options.Collation != nil {
+		spec = append(spec, bson.E{Key: "collation", Value: collationToBson(i.Options.Collation)})
+	}
+
+	return spec, nil
+}
+
+// indexBuildPollInterval is the delay between the checks of an index build in progress.
+const indexBuildPollInterval = 2 * time.Second
+
+// WaitForIndexBuild waits until the index is listed and no build of it is reported by $currentOp.
+// The build is tracked on the primary, which waits for the commit quorum members before completing it.
+// An IndexBuildTimeoutError is returned when the build is still in progress after the timeout.
+// The wait is not limited by the operation timeout, which applies to every check instead.
+func (c *Client) WaitForIndexBuild(ctx context.Context, opt *GetIndexOptions, timeout time.Duration) error {
+	tflog.Debug(ctx, "WaitForIndexBuild", map[string]interface{}{
+		"database":   opt.Database,
+		"collection": opt.Collection,
+		"name":       opt.Name,
+		"timeout":    timeout.String(),
+	})
+
+	deadline := time.Now().Add(timeout)
+
+	for {
+		ready, err := c.indexReady(ctx, opt)
+		if err != nil {
+			return err
+		}
+
+		if ready {
+			return nil
+		}
+
+		if time.Now().Add(indexBuildPollInterval).After(deadline) {
+			return IndexBuildTimeoutError{Name: opt.Name, Timeout: timeout}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(indexBuildPollInterval):
+		}
+	}
+}
+
+// indexReady reports whether the index exists and is not being built. It's a single check of WaitForIndexBuild,
+// which is limited by the operation timeout.
+func (c *Client) indexReady(ctx context.Context, opt *GetIndexOptions) (ready bool, err error) {
+	ctx, end := c.startOperation(ctx, "WaitForIndexBuild")
+	defer end(&err)
+
+	primaryOpt := *opt
+	primaryOpt.Primary = true
+
+	_, err = c.GetIndex(ctx, &primaryOpt)
+	if err != nil {
+		if IsNotFoundError(err) {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	admin := c.database(adminDatabase, true)
+
+	cursor, err := admin.Aggregate(ctx, bson.A{
+		bson.D{{Key: currentOpStage, Value: bson.D{{Key: "allUsers", Value: true}}}},
+		bson.D{{Key: "$match", Value: bson.D{
+			{Key: "ns", Value: opt.Database + "." + opt.Collection},
+			{Key: "command.createIndexes", Value: opt.Collection},
+			{Key: "command.indexes.name", Value: opt.Name},
+		}}},
+		bson.D{{Key: "$limit", Value: 1}},
+	})
+	if err != nil {
+		return false, wrapCommandError(currentOpStage, 0, err)
+	}
+
+	defer func() {
+		_ = cursor.Close(ctx)
+	}()
+
+	building := cursor.Next(ctx)
+	if err = cursor.Err(); err != nil {
+		return false, wrapCommandError(currentOpStage, 0, err)
+	}
+
+	return !building, nil
+}
+
+type ListIndexesOptions struct {
+	Database   string
+	Collection string
+	// Primary reads from the primary regardless of the read preference.
+	Primary bool
+}
+
+func (c *Client) GetIndex(ctx context.Context, opt *GetIndexOptions) (_ *Index, err error) {
+	ctx, end := c.startOperation(ctx, "GetIndex")
+	defer end(&err)
+
+	indexes, err := c.ListIndexes(ctx, &ListIndexesOptions{
+		Database:   opt.Database,
+		Collection: opt.Collection,
+		Primary:    opt.Primary,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range indexes {
+		if indexes[i].Name == opt.Name {
+			return &indexes[i], nil
+		}
+	}
+
+	return nil, NotFoundError{
+		name: opt.Name,
+		t:    "index",
+	}
+}
+
+func (c *Client) ListIndexes(ctx context.Context, opt *ListIndexesOptions) (_ []Index, err error) {
+	ctx, end := c.startOperation(ctx, "ListIndexes")
+	defer end(&err)
+
+	collection := c.database(opt.Database, opt.Primary).Collection(opt.Collection)
+
+	cursor, err := collection.Indexes().List(ctx)
+	if err != nil {
+		return nil, wrapCommandError(listIndexesCmd, 0, err)
+	}
+
 	defer func(cursor *mongo.Cursor, ctx context.Context) {
 		err := cursor.Close(ctx)
 		if err != nil {
@@ -105,28 +311,81 @@ func (c *Client) GetIndex(ctx context.Context, opt *GetIndexOptions) (*Index, er
 	})
 
 	for i := range indexes {
-		if indexes[i].Name == opt.Name {
-			indexes[i].Database = opt.Database
-			indexes[i].Collection = opt.Collection
+		indexes[i].Database = opt.Database
+		indexes[i].Collection = opt.Collection
+	}
 
-			return &indexes[i], nil
-		}
+	return indexes, nil
+}
+
+// SetIndexHidden hides the index from the query planner or unhides it in place with collMod.
+func (c *Client) SetIndexHidden(ctx context.Context, options *GetIndexOptions, hidden bool) (_ *Index, err error) {
+	ctx, end := c.startOperation(ctx, "SetIndexHidden")
+	defer end(&err)
+
+	tflog.Debug(ctx, "SetIndexHidden", map[string]interface{}{
+		"database":   options.Database,
+		"collection": options.Collection,
+		"name":       options.Name,
+		"hidden":     hidden,
+	})
+
+	return c.modifyIndex(ctx, options, bson.E{Key: "hidden", Value: hidden})
+}
+
+// SetIndexTTL changes the expiration of a TTL index in place with collMod.
+func (c *Client) SetIndexTTL(
+	ctx context.Context,
+	options *GetIndexOptions,
+	expireAfterSeconds int32,
+) (_ *Index, err error) {
+	ctx, end := c.startOperation(ctx, "SetIndexTTL")
+	defer end(&err)
+
+	tflog.Debug(ctx, "SetIndexTTL", map[string]interface{}{
+		"database":           options.Database,
+		"collection":         options.Collection,
+		"name":               options.Name,
+		"expireAfterSeconds": expireAfterSeconds,
+	})
+
+	return c.modifyIndex(ctx, options, bson.E{Key: "expireAfterSeconds", Value: expireAfterSeconds})
+}
+
+func (c *Client) modifyIndex(ctx context.Context, options *GetIndexOptions, option bson.E) (*Index, error) {
+	command := bson.D{
+		{Key: updateCollectionCmd, Value: options.Collection},
+		{Key: "index", Value: bson.D{
+			{Key: "name", Value: options.Name},
+			option,
+		}},
 	}
 
-	return nil, NotFoundError{
-		name: opt.Name,
-		t:    "index",
+	err := c.runCollectionCommand(ctx, options.Database, updateCollectionCmd, command)
+	if err != nil {
+		return nil, err
 	}
+
+	primaryOptions := *options
+	primaryOptions.Primary = true
+
+	return c.GetIndex(ctx, &primaryOptions)
 }
 
-func (c *Client) DeleteIndex(ctx context.Context, options *GetIndexOptions) error {
+func (c *Client) DeleteIndex(ctx context.Context, options *GetIndexOptions) (err error) {
+	ctx, end := c.startOperation(ctx, "DeleteIndex")
+	defer end(&err)
+
 	tflog.Debug(ctx, "DeleteIndex", map[string]interface{}{
 		"database":   options.Database,
 		"collection": options.Collection,
 		"name":       options.Name,
 	})
 
-	collection := c.mongo.Database(options.Database).Collection(options.Collection)
+	command := bson.D{
+		{Key: deleteIndexCmd, Value: options.Collection},
+		{Key: "index", Value: options.Name},
+	}
 
-	return collection.Indexes().DropOne(ctx, options.Name)
+	return c.runCollectionCommand(ctx, options.Database, deleteIndexCmd, command)
 }
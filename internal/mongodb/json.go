@@ -0,0 +1,29 @@
+package mongodb
+
+import "go.mongodb.org/mongo-driver/v2/bson"
+
+// ParseDocumentJSON parses an extended JSON document preserving the order of the fields.
+func ParseDocumentJSON(data string) (bson.D, error) {
+	out := bson.D{}
+
+	if data == "" {
+		return out, nil
+	}
+
+	err := bson.UnmarshalExtJSON([]byte(data), false, &out)
+	if err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// DocumentJSON returns the document as relaxed extended JSON keeping the order of the fields.
+func DocumentJSON(doc bson.D) (string, error) {
+	out, err := bson.MarshalExtJSON(doc, false, false)
+	if err != nil {
+		return "", err
+	}
+
+	return string(out), nil
+}
@@ -0,0 +1,132 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+const (
+	getParameterCmd = "getParameter"
+	setParameterCmd = "setParameter"
+)
+
+// Parameter is a server parameter. The value keeps the BSON type reported by the server,
+// so it can be converted back from its string form with ParseValue.
+type Parameter struct {
+	Name  string
+	Value bson.RawValue
+}
+
+// String returns the value as a plain string: numbers and booleans as they are written, documents as JSON.
+func (p *Parameter) String() (string, error) {
+	switch p.Value.Type {
+	case bson.TypeString:
+		return p.Value.StringValue(), nil
+	case bson.TypeBoolean:
+		return strconv.FormatBool(p.Value.Boolean()), nil
+	case bson.TypeInt32:
+		return strconv.FormatInt(int64(p.Value.Int32()), 10), nil
+	case bson.TypeInt64:
+		return strconv.FormatInt(p.Value.Int64(), 10), nil
+	case bson.TypeDouble:
+		return strconv.FormatFloat(p.Value.Double(), 'g', -1, 64), nil
+	case bson.TypeEmbeddedDocument:
+		var doc bson.D
+
+		err := bson.Unmarshal(p.Value.Document(), &doc)
+		if err != nil {
+			return "", err
+		}
+
+		return DocumentJSON(doc)
+	default:
+		return "", fmt.Errorf("parameter %s has the unsupported type %s", p.Name, p.Value.Type)
+	}
+}
+
+// ParseValue converts the string form of a value into the BSON type of the current parameter value.
+func (p *Parameter) ParseValue(value string) (interface{}, error) {
+	var (
+		out interface{}
+		err error
+	)
+
+	switch p.Value.Type {
+	case bson.TypeString:
+		out = value
+	case bson.TypeBoolean:
+		out, err = strconv.ParseBool(value)
+	case bson.TypeInt32:
+		var n int64
+
+		n, err = strconv.ParseInt(value, 10, 32)
+		out = int32(n)
+	case bson.TypeInt64:
+		out, err = strconv.ParseInt(value, 10, 64)
+	case bson.TypeDouble:
+		out, err = strconv.ParseFloat(value, 64)
+	case bson.TypeEmbeddedDocument:
+		out, err = ParseDocumentJSON(value)
+	default:
+		return nil, fmt.Errorf("parameter %s has the unsupported type %s", p.Name, p.Value.Type)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("invalid value %q for parameter %s of type %s: %w", value, p.Name, p.Value.Type, err)
+	}
+
+	return out, nil
+}
+
+func (c *Client) GetParameter(ctx context.Context, name string) (_ *Parameter, err error) {
+	ctx, end := c.startOperation(ctx, "GetParameter")
+	defer end(&err)
+
+	tflog.Debug(ctx, "GetParameter", map[string]interface{}{
+		"name": name,
+	})
+
+	command := bson.D{
+		{Key: getParameterCmd, Value: 1},
+		{Key: name, Value: 1},
+	}
+
+	response, err := c.runCommand(ctx, adminDatabase, command).Raw()
+	if err != nil {
+		return nil, wrapCommandError(getParameterCmd, 0, err)
+	}
+
+	value, err := response.LookupErr(name)
+	if err != nil {
+		return nil, NotFoundError{name, "parameter"}
+	}
+
+	return &Parameter{Name: name, Value: value}, nil
+}
+
+// SetParameter sets the parameter to the value, which must have the BSON type expected by the server.
+func (c *Client) SetParameter(ctx context.Context, name string, value interface{}) (_ *Parameter, err error) {
+	ctx, end := c.startOperation(ctx, "SetParameter")
+	defer end(&err)
+
+	tflog.Debug(ctx, "SetParameter", map[string]interface{}{
+		"name": name,
+	})
+
+	command := bson.D{
+		{Key: setParameterCmd, Value: 1},
+		{Key: name, Value: value},
+	}
+
+	// setParameter does not support a write concern, it only changes the member it runs on
+	err = c.runCommand(ctx, adminDatabase, command).Err()
+	if err != nil {
+		return nil, wrapCommandError(setParameterCmd, 0, err)
+	}
+
+	return c.GetParameter(ctx, name)
+}
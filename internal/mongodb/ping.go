@@ -0,0 +1,73 @@
+package mongodb
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+const helloCmd = "hello"
+
+const (
+	TopologySingle     = "single"
+	TopologyReplicaSet = "replica_set"
+	TopologySharded    = "sharded"
+)
+
+// PingResult is the hello response of the primary with the time it took.
+type PingResult struct {
+	Ok        bool
+	RoundTrip time.Duration
+	// TopologyType is TopologySingle, TopologyReplicaSet or TopologySharded
+	TopologyType string
+	// Primary is the host:port of the replica set primary, empty for the other topologies
+	Primary string
+	SetName string
+}
+
+type helloResult struct {
+	Ok      float64 `bson:"ok"`
+	Msg     string  `bson:"msg"`
+	SetName string  `bson:"setName"`
+	Primary string  `bson:"primary"`
+}
+
+// Ping runs hello on the primary. It's not retried, so the round trip is the time of a single command
+// and an unreachable cluster is reported as soon as the server selection fails.
+func (c *Client) Ping(ctx context.Context) (_ *PingResult, err error) {
+	ctx, end := c.startOperation(ctx, "Ping")
+	defer end(&err)
+
+	tflog.Debug(ctx, "Ping")
+
+	start := time.Now()
+
+	var result helloResult
+
+	err = c.mongo.Database(adminDatabase).
+		RunCommand(ctx, bson.D{{Key: helloCmd, Value: 1}}, primaryRunCmdOptions).
+		Decode(&result)
+	if err != nil {
+		return nil, wrapCommandError(helloCmd, 0, err)
+	}
+
+	out := &PingResult{
+		Ok:           result.Ok == 1,
+		RoundTrip:    time.Since(start),
+		TopologyType: TopologySingle,
+		Primary:      result.Primary,
+		SetName:      result.SetName,
+	}
+
+	switch {
+	// mongos identifies itself with this message
+	case result.Msg == "isdbgrid":
+		out.TopologyType = TopologySharded
+	case result.SetName != "":
+		out.TopologyType = TopologyReplicaSet
+	}
+
+	return out, nil
+}
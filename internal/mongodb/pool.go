@@ -0,0 +1,84 @@
+package mongodb
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"go.mongodb.org/mongo-driver/v2/event"
+)
+
+// PoolStats are the connection pool event counts aggregated since the client was created.
+type PoolStats struct {
+	ConnectionsCreated int64
+	ConnectionsClosed  int64
+	CheckedOut         int64
+	CheckedIn          int64
+	CheckOutFailed     int64
+	CheckOutTimeouts   int64
+}
+
+type poolMetrics struct {
+	connectionsCreated atomic.Int64
+	connectionsClosed  atomic.Int64
+	checkedOut         atomic.Int64
+	checkedIn          atomic.Int64
+	checkOutFailed     atomic.Int64
+	checkOutTimeouts   atomic.Int64
+}
+
+// monitor returns the driver pool monitor counting the events and logging them with the logger of the given
+// context. The events outlive the Configure request, so the logger is kept without its cancellation.
+func (m *poolMetrics) monitor(ctx context.Context) *event.PoolMonitor {
+	ctx = context.WithoutCancel(ctx)
+
+	return &event.PoolMonitor{
+		Event: func(e *event.PoolEvent) {
+			switch e.Type {
+			case event.ConnectionCreated:
+				m.connectionsCreated.Add(1)
+			case event.ConnectionClosed:
+				m.connectionsClosed.Add(1)
+			case event.ConnectionCheckedOut:
+				m.checkedOut.Add(1)
+			case event.ConnectionCheckedIn:
+				m.checkedIn.Add(1)
+			case event.ConnectionCheckOutFailed:
+				m.checkOutFailed.Add(1)
+
+				if e.Reason == event.ReasonTimedOut {
+					m.checkOutTimeouts.Add(1)
+				}
+			default:
+				return
+			}
+
+			tflog.Trace(ctx, "Connection pool event", map[string]interface{}{
+				"type":     e.Type,
+				"address":  e.Address,
+				"reason":   e.Reason,
+				"duration": e.Duration.String(),
+			})
+		},
+	}
+}
+
+func (m *poolMetrics) stats() PoolStats {
+	return PoolStats{
+		ConnectionsCreated: m.connectionsCreated.Load(),
+		ConnectionsClosed:  m.connectionsClosed.Load(),
+		CheckedOut:         m.checkedOut.Load(),
+		CheckedIn:          m.checkedIn.Load(),
+		CheckOutFailed:     m.checkOutFailed.Load(),
+		CheckOutTimeouts:   m.checkOutTimeouts.Load(),
+	}
+}
+
+// PoolStats returns the connection pool event counts. The second value is false when PoolMetrics is disabled.
+func (c *Client) PoolStats() (PoolStats, bool) {
+	if c.pool == nil {
+		return PoolStats{}, false
+	}
+
+	return c.pool.stats(), true
+}
@@ -0,0 +1,33 @@
+package mongodb
+
+import (
+	"context"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/v2/event"
+)
+
+func TestPoolMonitorOutlivesContext(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	metrics := &poolMetrics{}
+	monitor := metrics.monitor(ctx)
+
+	// The Configure request ends before the client is used
+	cancel()
+
+	monitor.Event(&event.PoolEvent{Type: event.ConnectionCreated, Address: "localhost:27017"})
+	monitor.Event(&event.PoolEvent{Type: event.ConnectionCheckedOut, Address: "localhost:27017"})
+	monitor.Event(&event.PoolEvent{
+		Type:    event.ConnectionCheckOutFailed,
+		Address: "localhost:27017",
+		Reason:  event.ReasonTimedOut,
+	})
+
+	expected := PoolStats{ConnectionsCreated: 1, CheckedOut: 1, CheckOutFailed: 1, CheckOutTimeouts: 1}
+	if stats := metrics.stats(); stats != expected {
+		t.Errorf("expected %+v, got %+v", expected, stats)
+	}
+}
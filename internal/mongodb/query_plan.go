@@ -0,0 +1,137 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+const (
+	explainCmd = "explain"
+
+	collectionScanStage = "COLLSCAN"
+	indexScanStage      = "IXSCAN"
+)
+
+type ExplainQueryOptions struct {
+	Database   string
+	Collection string
+	// Filter and Sort are extended JSON documents
+	Filter string
+	Sort   string
+}
+
+// QueryPlan is the summary of the winning plan chosen by the query planner.
+type QueryPlan struct {
+	// IndexNames are the indexes scanned by the winning plan, in the order of appearance
+	IndexNames     []string
+	CollectionScan bool
+}
+
+func (c *Client) ExplainQuery(ctx context.Context, options *ExplainQueryOptions) (_ *QueryPlan, err error) {
+	ctx, end := c.startOperation(ctx, "ExplainQuery")
+	defer end(&err)
+
+	tflog.Debug(ctx, "ExplainQuery", map[string]interface{}{
+		"database":   options.Database,
+		"collection": options.Collection,
+		"filter":     options.Filter,
+		"sort":       options.Sort,
+	})
+
+	filter, err := ParseDocumentJSON(options.Filter)
+	if err != nil {
+		return nil, fmt.Errorf("invalid filter: %w", err)
+	}
+
+	find := bson.D{
+		{Key: "find", Value: options.Collection},
+		{Key: "filter", Value: filter},
+	}
+
+	if options.Sort != "" {
+		sort, err := ParseDocumentJSON(options.Sort)
+		if err != nil {
+			return nil, fmt.Errorf("invalid sort: %w", err)
+		}
+
+		find = append(find, bson.E{Key: "sort", Value: sort})
+	}
+
+	command := bson.D{
+		{Key: explainCmd, Value: find},
+		{Key: "verbosity", Value: "queryPlanner"},
+	}
+
+	var result struct {
+		QueryPlanner struct {
+			WinningPlan bson.Raw `bson:"winningPlan"`
+		} `bson:"queryPlanner"`
+	}
+
+	err = c.mongo.Database(options.Database).RunCommand(ctx, command).Decode(&result)
+	if err != nil {
+		return nil, err
+	}
+
+	if result.QueryPlanner.WinningPlan == nil {
+		return nil, FailedCommandError{explainCmd}
+	}
+
+	plan := &QueryPlan{IndexNames: []string{}}
+	plan.collectStages(result.QueryPlanner.WinningPlan)
+
+	return plan, nil
+}
+
+// collectStages walks the plan tree. The layout differs between the classic and the slot based engines
+// and sharded clusters, so every nested document is inspected for scan stages.
+func (p *QueryPlan) collectStages(doc bson.Raw) {
+	elements, err := doc.Elements()
+	if err != nil {
+		return
+	}
+
+	stage, _ := doc.Lookup("stage").StringValueOK()
+
+	switch stage {
+	case collectionScanStage:
+		p.CollectionScan = true
+	case indexScanStage:
+		if name, ok := doc.Lookup("indexName").StringValueOK(); ok {
+			p.addIndexName(name)
+		}
+	}
+
+	for _, element := range elements {
+		p.collectValue(element.Value())
+	}
+}
+
+func (p *QueryPlan) collectValue(value bson.RawValue) {
+	switch value.Type {
+	case bson.TypeEmbeddedDocument:
+		p.collectStages(value.Document())
+	case bson.TypeArray:
+		values, err := value.Array().Values()
+		if err != nil {
+			return
+		}
+
+		for _, v := range values {
+			p.collectValue(v)
+		}
+	}
+}
+
+func (p *QueryPlan) addIndexName(name string) {
+	for _, n := range p.IndexNames {
+		if n == name {
+			return
+		}
+	}
+
+	p.IndexNames = append(p.IndexNames, name)
+}
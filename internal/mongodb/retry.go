@@ -0,0 +1,193 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+const (
+	// DefaultCommandRetries is the number of additional attempts of a retryable command.
+	DefaultCommandRetries = 2
+
+	defaultCommandRetryInterval = time.Second
+	defaultConnectRetryInterval = time.Second
+)
+
+// DefaultRetryableCommands are the admin commands which are safe to repeat:
+// reads, updates applying the whole definition, grants and revokes, and creates and drops whose repeated attempt
+// reports the change applied by the attempt which lost its connection, see alreadyAppliedCodes.
+var DefaultRetryableCommands = []string{
+	getUserCmd,
+	createUserCmd,
+	updateUserCmr,
+	deleteUserCmd,
+	grantRolesToUserCmd,
+	revokeRolesFromUserCmd,
+	getRoleCmd,
+	createRoleCmd,
+	updateRoleCmd,
+	deleteRoleCmd,
+	grantPrivilegesToRoleCmd,
+	revokePrivilegesFromRoleCmd,
+	createIndexCmd,
+	deleteIndexCmd,
+	deleteCollectionCmd,
+}
+
+// alreadyAppliedCodes are the errors of a repeated command when the previous attempt was applied.
+var alreadyAppliedCodes = map[string]int{
+	createUserCmd:       userAlreadyExistsCode,
+	deleteUserCmd:       userNotFoundCode,
+	createRoleCmd:       roleAlreadyExistsCode,
+	deleteRoleCmd:       roleNotFoundCode,
+	deleteIndexCmd:      indexNotFoundCode,
+	deleteCollectionCmd: namespaceNotFoundCode,
+}
+
+// transientErrorCodes are the server error codes returned during elections and restarts.
+var transientErrorCodes = []int{
+	6,     // HostUnreachable
+	7,     // HostNotFound
+	89,    // NetworkTimeout
+	91,    // ShutdownInProgress
+	189,   // PrimarySteppedDown
+	10107, // NotWritablePrimary
+	11600, // InterruptedAtShutdown
+	11602, // InterruptedDueToReplStateChange
+	13435, // NotPrimaryNoSecondaryOk
+	13436, // NotPrimaryOrSecondary
+}
+
+func isTransientError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	if mongo.IsNetworkError(err) {
+		return true
+	}
+
+	var serverErr mongo.ServerError
+	if !errors.As(err, &serverErr) {
+		return false
+	}
+
+	if serverErr.HasErrorLabel("RetryableWriteError") || serverErr.HasErrorLabel("TransientTransactionError") {
+		return true
+	}
+
+	for _, code := range transientErrorCodes {
+		if serverErr.HasErrorCode(code) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// retry runs the command, repeating it with exponential backoff on transient errors
+// when the command is in the retryable list. Other errors are returned immediately.
+func (c *Client) retry(ctx context.Context, cmd string, run func() error) error {
+	err := run()
+	if !slices.Contains(c.RetryableCommands, cmd) {
+		return err
+	}
+
+	interval := c.CommandRetryInterval
+	if interval <= 0 {
+		interval = defaultCommandRetryInterval
+	}
+
+	for attempt := 1; attempt <= c.CommandRetries && isTransientError(err); attempt++ {
+		tflog.Warn(ctx, "Retrying command after transient error", map[string]interface{}{
+			"command":  cmd,
+			"attempt":  attempt,
+			"interval": interval.String(),
+			"err":      err.Error(),
+		})
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(interval):
+		}
+
+		interval *= 2
+
+		err = run()
+		if code, ok := alreadyAppliedCodes[cmd]; ok && hasErrorCode(err, code) {
+			tflog.Warn(ctx, "Command was applied by the previous attempt", map[string]interface{}{
+				"command": cmd,
+				"err":     err.Error(),
+			})
+
+			return nil
+		}
+	}
+
+	return err
+}
+
+// runCommand runs an admin command, retrying it on transient errors when the command is in the retryable list.
+func (c *Client) runCommand(
+	ctx context.Context,
+	database string,
+	command bson.D,
+	opts ...options.Lister[options.RunCmdOptions],
+) *mongo.SingleResult {
+	var response *mongo.SingleResult
+
+	err := c.retry(ctx, command[0].Key, func() error {
+		response = c.mongo.Database(database).RunCommand(ctx, command, opts...)
+
+		return response.Err()
+	})
+
+	// The error of the last attempt is ignored when the previous attempt was applied
+	if err == nil && response.Err() != nil {
+		return mongo.NewSingleResultFromDocument(bson.D{{Key: "ok", Value: 1}}, nil, nil)
+	}
+
+	return response
+}
+
+// ping checks the connection, retrying with exponential backoff while the cluster may be electing a primary.
+func ping(ctx context.Context, client *mongo.Client, retries int, interval time.Duration) error {
+	if interval <= 0 {
+		interval = defaultConnectRetryInterval
+	}
+
+	attempts := 1
+
+	err := client.Ping(ctx, nil)
+	for ; err != nil && attempts <= retries; attempts++ {
+		tflog.Warn(ctx, "Retrying ping", map[string]interface{}{
+			"attempt":  attempts,
+			"interval": interval.String(),
+			"err":      err.Error(),
+		})
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("ping failed after %d attempt(s): %w", attempts, err)
+		case <-time.After(interval):
+		}
+
+		interval *= 2
+		err = client.Ping(ctx, nil)
+	}
+
+	if err != nil {
+		return fmt.Errorf("ping failed after %d attempt(s): %w", attempts, err)
+	}
+
+	return nil
+}
@@ -13,9 +13,15 @@ const (
 	getRoleCmd    = "rolesInfo"
 	updateRoleCmd = "updateRole"
 	deleteRoleCmd = "dropRole"
+
+	grantPrivilegesToRoleCmd    = "grantPrivilegesToRole"
+	revokePrivilegesFromRoleCmd = "revokePrivilegesFromRole"
 )
 
-func (c *Client) UpsertRole(ctx context.Context, role *Role) (*Role, error) {
+func (c *Client) UpsertRole(ctx context.Context, role *Role) (_ *Role, err error) {
+	ctx, end := c.startOperation(ctx, "UpsertRole")
+	defer end(&err)
+
 	tflog.Debug(ctx, "UpsertRole", map[string]interface{}{
 		"name":     role.Name,
 		"database": role.Database,
@@ -23,7 +29,7 @@ func (c *Client) UpsertRole(ctx context.Context, role *Role) (*Role, error) {
 
 	var cmd string
 
-	_, err := c.GetRole(ctx, &GetRoleOptions{
+	_, err = c.GetRole(ctx, &GetRoleOptions{
 		Name:     role.Name,
 		Database: role.Database,
 	})
@@ -41,12 +47,25 @@ func (c *Client) UpsertRole(ctx context.Context, role *Role) (*Role, error) {
 		{Key: cmd, Value: role.Name},
 		{Key: "privileges", Value: role.Privileges.toBson()},
 		// Roles field is required, but empty array is fine
-		{Key: "roles", Value: role.Roles.toBson()},
+		{Key: "roles", Value: role.Roles.toBson(role.Database)},
 	}
 
-	response := c.mongo.Database(role.Database).RunCommand(ctx, command)
+	if role.AuthenticationRestrictions != nil {
+		command = append(command, bson.E{
+			Key:   "authenticationRestrictions",
+			Value: role.AuthenticationRestrictions.toBson(),
+		})
+	}
+
+	if role.MaxTimeMS > 0 {
+		command = append(command, bson.E{Key: "maxTimeMS", Value: role.MaxTimeMS})
+	}
+
+	command = c.withWriteOptions(command)
+
+	response := c.runCommand(ctx, role.Database, command)
 	if err = response.Err(); err != nil {
-		return nil, err
+		return nil, wrapCommandError(cmd, role.MaxTimeMS, err)
 	}
 
 	result := &Result{}
@@ -81,7 +100,10 @@ type getRoleResult struct {
 	Roles []Role `bson:"roles"`
 }
 
-func (c *Client) GetRole(ctx context.Context, options *GetRoleOptions) (*Role, error) {
+func (c *Client) GetRole(ctx context.Context, options *GetRoleOptions) (_ *Role, err error) {
+	ctx, end := c.startOperation(ctx, "GetRole")
+	defer end(&err)
+
 	tflog.Debug(ctx, "GetRole", map[string]interface{}{
 		"name":     options.Name,
 		"database": options.Database,
@@ -90,16 +112,17 @@ func (c *Client) GetRole(ctx context.Context, options *GetRoleOptions) (*Role, e
 	command := bson.D{
 		{Key: getRoleCmd, Value: options.Name},
 		{Key: "showPrivileges", Value: true},
+		{Key: "showAuthenticationRestrictions", Value: true},
 	}
 
-	response := c.mongo.Database(options.Database).RunCommand(ctx, command)
+	response := c.runCommand(ctx, options.Database, command, primaryRunCmdOptions)
 	if err := response.Err(); err != nil {
-		return nil, err
+		return nil, wrapCommandError(getRoleCmd, 0, err)
 	}
 
 	var result getRoleResult
 
-	err := response.Decode(&result)
+	err = response.Decode(&result)
 	if err != nil {
 		return nil, err
 	}
@@ -120,26 +143,127 @@ func (c *Client) GetRole(ctx context.Context, options *GetRoleOptions) (*Role, e
 	return &result.Roles[0], nil
 }
 
+type ListRolesOptions struct {
+	Database       string
+	IncludeBuiltin bool
+}
+
+// ListRoles returns the roles defined in the database with their privileges.
+func (c *Client) ListRoles(ctx context.Context, options *ListRolesOptions) (_ []Role, err error) {
+	ctx, end := c.startOperation(ctx, "ListRoles")
+	defer end(&err)
+
+	tflog.Debug(ctx, "ListRoles", map[string]interface{}{
+		"database":        options.Database,
+		"include_builtin": options.IncludeBuiltin,
+	})
+
+	command := bson.D{
+		{Key: getRoleCmd, Value: 1},
+		{Key: "showPrivileges", Value: true},
+		{Key: "showBuiltinRoles", Value: options.IncludeBuiltin},
+	}
+
+	response := c.runCommand(ctx, options.Database, command, primaryRunCmdOptions)
+	if err := response.Err(); err != nil {
+		return nil, wrapCommandError(getRoleCmd, 0, err)
+	}
+
+	var result getRoleResult
+
+	err = response.Decode(&result)
+	if err != nil {
+		return nil, err
+	}
+
+	if result.Ok != 1 {
+		return nil, FailedCommandError{getRoleCmd}
+	}
+
+	return result.Roles, nil
+}
+
 type DeleteRoleOptions struct {
 	Name     string
 	Database string
 }
 
-func (c *Client) DeleteRole(ctx context.Context, options *DeleteRoleOptions) error {
+func (c *Client) DeleteRole(ctx context.Context, options *DeleteRoleOptions) (err error) {
+	ctx, end := c.startOperation(ctx, "DeleteRole")
+	defer end(&err)
+
 	tflog.Debug(ctx, "DeleteRole", map[string]interface{}{
 		"name":     options.Name,
 		"database": options.Database,
 	})
 
-	command := bson.D{
+	command := c.withWriteOptions(bson.D{
 		{Key: deleteRoleCmd, Value: options.Name},
-	}
+	})
 
-	response := c.mongo.Database(options.Database).RunCommand(ctx, command)
+	response := c.runCommand(ctx, options.Database, command)
 	if err := response.Err(); err != nil {
+		return wrapCommandError(deleteRoleCmd, 0, err)
+	}
+
+	var result Result
+
+	err = response.Decode(&result)
+	if err != nil {
 		return err
 	}
 
+	if result.Ok != 1 {
+		return FailedCommandError{deleteRoleCmd}
+	}
+
+	return nil
+}
+
+type RolePrivilegesOptions struct {
+	Name       string
+	Database   string
+	Privileges Privileges
+}
+
+// GrantPrivilegesToRole adds the privileges to the role, keeping the privileges already granted.
+func (c *Client) GrantPrivilegesToRole(ctx context.Context, options *RolePrivilegesOptions) (err error) {
+	ctx, end := c.startOperation(ctx, "GrantPrivilegesToRole")
+	defer end(&err)
+
+	return c.changeRolePrivileges(ctx, grantPrivilegesToRoleCmd, options)
+}
+
+// RevokePrivilegesFromRole removes the actions of the privileges from the role.
+// Actions which are not granted are ignored.
+func (c *Client) RevokePrivilegesFromRole(ctx context.Context, options *RolePrivilegesOptions) (err error) {
+	ctx, end := c.startOperation(ctx, "RevokePrivilegesFromRole")
+	defer end(&err)
+
+	return c.changeRolePrivileges(ctx, revokePrivilegesFromRoleCmd, options)
+}
+
+func (c *Client) changeRolePrivileges(ctx context.Context, cmd string, options *RolePrivilegesOptions) error {
+	tflog.Debug(ctx, cmd, map[string]interface{}{
+		"name":       options.Name,
+		"database":   options.Database,
+		"privileges": len(options.Privileges),
+	})
+
+	if len(options.Privileges) == 0 {
+		return nil
+	}
+
+	command := c.withWriteOptions(bson.D{
+		{Key: cmd, Value: options.Name},
+		{Key: "privileges", Value: options.Privileges.toBson()},
+	})
+
+	response := c.runCommand(ctx, options.Database, command)
+	if err := response.Err(); err != nil {
+		return wrapCommandError(cmd, 0, err)
+	}
+
 	var result Result
 
 	err := response.Decode(&result)
@@ -148,7 +272,7 @@ func (c *Client) DeleteRole(ctx context.Context, options *DeleteRoleOptions) err
 	}
 
 	if result.Ok != 1 {
-		return FailedCommandError{deleteRoleCmd}
+		return FailedCommandError{cmd}
 	}
 
 	return nil
@@ -0,0 +1,138 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+const (
+	setDefaultRWConcernCmd = "setDefaultRWConcern"
+	getDefaultRWConcernCmd = "getDefaultRWConcern"
+
+	// rwConcernSourceGlobal marks the defaults set with setDefaultRWConcern, as opposed to the implicit ones.
+	rwConcernSourceGlobal = "global"
+)
+
+// DefaultRWConcern is the cluster wide default read and write concern. Nil fields are not set.
+type DefaultRWConcern struct {
+	// ReadConcernLevel is "local", "available" or "majority"
+	ReadConcernLevel string
+	WriteConcern     *WriteConcern
+}
+
+type defaultRWConcernResult struct {
+	DefaultReadConcern *struct {
+		Level string `bson:"level"`
+	} `bson:"defaultReadConcern"`
+	DefaultWriteConcern *struct {
+		W        interface{} `bson:"w"`
+		Journal  *bool       `bson:"j"`
+		WTimeout int64       `bson:"wtimeout"`
+	} `bson:"defaultWriteConcern"`
+	DefaultReadConcernSource  string `bson:"defaultReadConcernSource"`
+	DefaultWriteConcernSource string `bson:"defaultWriteConcernSource"`
+}
+
+// GetDefaultRWConcern returns the defaults set with setDefaultRWConcern, the implicit server defaults are omitted.
+func (c *Client) GetDefaultRWConcern(ctx context.Context) (_ *DefaultRWConcern, err error) {
+	ctx, end := c.startOperation(ctx, "GetDefaultRWConcern")
+	defer end(&err)
+
+	tflog.Debug(ctx, "GetDefaultRWConcern")
+
+	var result defaultRWConcernResult
+
+	response := c.runCommand(ctx, adminDatabase, bson.D{{Key: getDefaultRWConcernCmd, Value: 1}})
+	if err = response.Err(); err != nil {
+		return nil, wrapCommandError(getDefaultRWConcernCmd, 0, err)
+	}
+
+	err = response.Decode(&result)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &DefaultRWConcern{}
+
+	if result.DefaultReadConcern != nil && result.DefaultReadConcernSource == rwConcernSourceGlobal {
+		out.ReadConcernLevel = result.DefaultReadConcern.Level
+	}
+
+	if result.DefaultWriteConcern != nil && result.DefaultWriteConcernSource == rwConcernSourceGlobal {
+		out.WriteConcern = &WriteConcern{
+			Journal:  result.DefaultWriteConcern.Journal,
+			WTimeout: time.Duration(result.DefaultWriteConcern.WTimeout) * time.Millisecond,
+		}
+
+		if result.DefaultWriteConcern.W != nil {
+			out.WriteConcern.W = fmt.Sprint(result.DefaultWriteConcern.W)
+		}
+	}
+
+	return out, nil
+}
+
+// SetDefaultRWConcern sets the default read concern, or unsets it when the level is empty,
+// and the default write concern when it's not nil.
+func (c *Client) SetDefaultRWConcern(ctx context.Context, concern *DefaultRWConcern) (_ *DefaultRWConcern, err error) {
+	ctx, end := c.startOperation(ctx, "SetDefaultRWConcern")
+	defer end(&err)
+
+	tflog.Debug(ctx, "SetDefaultRWConcern", map[string]interface{}{
+		"readConcernLevel": concern.ReadConcernLevel,
+	})
+
+	readConcern := bson.D{}
+	if concern.ReadConcernLevel != "" {
+		readConcern = append(readConcern, bson.E{Key: "level", Value: concern.ReadConcernLevel})
+	}
+
+	command := bson.D{
+		{Key: setDefaultRWConcernCmd, Value: 1},
+		{Key: "defaultReadConcern", Value: readConcern},
+	}
+
+	if concern.WriteConcern != nil {
+		command = append(command, bson.E{Key: "defaultWriteConcern", Value: concern.WriteConcern.toBson()})
+	}
+
+	err = c.runCollectionCommand(ctx, adminDatabase, setDefaultRWConcernCmd, command)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.GetDefaultRWConcern(ctx)
+}
+
+// UnsetDefaultRWConcern restores the implicit default read concern and, when writeConcern is set,
+// the implicit default write concern. MongoDB 5.0 and later refuse to unset the default write concern,
+// in that case only the read concern is unset and writeConcernKept is true.
+func (c *Client) UnsetDefaultRWConcern(ctx context.Context, writeConcern bool) (writeConcernKept bool, err error) {
+	ctx, end := c.startOperation(ctx, "UnsetDefaultRWConcern")
+	defer end(&err)
+
+	tflog.Debug(ctx, "UnsetDefaultRWConcern", map[string]interface{}{
+		"writeConcern": writeConcern,
+	})
+
+	command := bson.D{
+		{Key: setDefaultRWConcernCmd, Value: 1},
+		{Key: "defaultReadConcern", Value: bson.D{}},
+	}
+
+	if writeConcern {
+		err = c.runCollectionCommand(ctx, adminDatabase, setDefaultRWConcernCmd,
+			append(command, bson.E{Key: "defaultWriteConcern", Value: bson.D{}}))
+		if err == nil || !hasErrorCode(err, illegalOperationCode) {
+			return false, err
+		}
+
+		writeConcernKept = true
+	}
+
+	return writeConcernKept, c.runCollectionCommand(ctx, adminDatabase, setDefaultRWConcernCmd, command)
+}
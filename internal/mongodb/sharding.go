@@ -0,0 +1,229 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+const (
+	enableShardingCmd    = "enableSharding"
+	shardCollectionCmd   = "shardCollection"
+	reshardCollectionCmd = "reshardCollection"
+
+	// configDatabase holds the sharding metadata of the cluster.
+	configDatabase = "config"
+)
+
+// ShardedDatabase is a database registered in the sharding metadata.
+type ShardedDatabase struct {
+	Name string `bson:"_id"`
+	// Primary is the shard holding the unsharded collections of the database
+	Primary string `bson:"primary"`
+}
+
+type ShardCollectionOptions struct {
+	Database   string
+	Collection string
+	Key        IndexKeys
+	Unique     bool
+	// NumInitialChunks is the number of chunks created for an empty collection with a hashed shard key,
+	// zero uses the server default
+	NumInitialChunks int32
+}
+
+// ShardedCollection is a collection registered in the sharding metadata.
+type ShardedCollection struct {
+	Database   string
+	Collection string
+	Key        IndexKeys
+	Unique     bool
+}
+
+type GetShardedCollectionOptions struct {
+	Database   string
+	Collection string
+	// Primary reads from the primary regardless of the read preference, to see the preceding writes.
+	Primary bool
+}
+
+// EnableSharding registers the database in the sharding metadata. It's a no-op for a database already registered.
+func (c *Client) EnableSharding(ctx context.Context, database string) (_ *ShardedDatabase, err error) {
+	ctx, end := c.startOperation(ctx, "EnableSharding")
+	defer end(&err)
+
+	tflog.Debug(ctx, "EnableSharding", map[string]interface{}{
+		"database": database,
+	})
+
+	command := bson.D{
+		{Key: enableShardingCmd, Value: database},
+	}
+
+	err = c.runCollectionCommand(ctx, adminDatabase, enableShardingCmd, command)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.getShardedDatabase(ctx, database, true)
+}
+
+// GetShardedDatabase reads the database from config.databases.
+func (c *Client) GetShardedDatabase(ctx context.Context, database string) (_ *ShardedDatabase, err error) {
+	ctx, end := c.startOperation(ctx, "GetShardedDatabase")
+	defer end(&err)
+
+	return c.getShardedDatabase(ctx, database, false)
+}
+
+// getShardedDatabase reads config.databases from the primary when set, otherwise with the read preference.
+func (c *Client) getShardedDatabase(ctx context.Context, database string, primary bool) (*ShardedDatabase, error) {
+	var out ShardedDatabase
+
+	err := c.database(configDatabase, primary).Collection("databases").
+		FindOne(ctx, bson.D{{Key: "_id", Value: database}}).Decode(&out)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, NotFoundError{database, "sharded database"}
+		}
+
+		return nil, wrapCommandError(findDocumentCmd, 0, err)
+	}
+
+	return &out, nil
+}
+
+// ShardCollection shards the collection on the key. The shard key index is created for an empty collection,
+// a collection holding documents needs an index starting with the shard key.
+func (c *Client) ShardCollection(
+	ctx context.Context,
+	options *ShardCollectionOptions,
+) (_ *ShardedCollection, err error) {
+	ctx, end := c.startOperation(ctx, "ShardCollection")
+	defer end(&err)
+
+	tflog.Debug(ctx, "ShardCollection", map[string]interface{}{
+		"database":   options.Database,
+		"collection": options.Collection,
+	})
+
+	command := bson.D{
+		{Key: shardCollectionCmd, Value: options.Database + "." + options.Collection},
+		{Key: "key", Value: options.Key.toBson()},
+	}
+
+	if options.Unique {
+		command = append(command, bson.E{Key: "unique", Value: true})
+	}
+
+	if options.NumInitialChunks > 0 {
+		command = append(command, bson.E{Key: "numInitialChunks", Value: options.NumInitialChunks})
+	}
+
+	err = c.runCollectionCommand(ctx, adminDatabase, shardCollectionCmd, command)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.GetShardedCollection(ctx, &GetShardedCollectionOptions{
+		Database:   options.Database,
+		Collection: options.Collection,
+		Primary:    true,
+	})
+}
+
+// reshardMinVersion is the first server version changing the shard key of a collection.
+var reshardMinVersion = []int{5, 0}
+
+// ReshardCollection changes the shard key of a sharded collection with reshardCollection. The command returns
+// once the documents were copied to the new chunks, which can take hours for a large collection.
+// The shard key of a resharded collection can't be unique.
+func (c *Client) ReshardCollection(
+	ctx context.Context,
+	options *ShardCollectionOptions,
+) (_ *ShardedCollection, err error) {
+	ctx, end := c.startOperation(ctx, "ReshardCollection")
+	defer end(&err)
+
+	tflog.Debug(ctx, "ReshardCollection", map[string]interface{}{
+		"database":   options.Database,
+		"collection": options.Collection,
+	})
+
+	if options.Unique {
+		return nil, errors.New("the shard key of a resharded collection can't be unique")
+	}
+
+	supported, err := c.serverVersionAtLeast(ctx, reshardMinVersion...)
+	if err != nil {
+		return nil, err
+	}
+
+	if !supported {
+		return nil, fmt.Errorf("changing the shard key requires MongoDB %d.%d or later",
+			reshardMinVersion[0], reshardMinVersion[1])
+	}
+
+	command := bson.D{
+		{Key: reshardCollectionCmd, Value: options.Database + "." + options.Collection},
+		{Key: "key", Value: options.Key.toBson()},
+	}
+
+	if options.NumInitialChunks > 0 {
+		command = append(command, bson.E{Key: "numInitialChunks", Value: options.NumInitialChunks})
+	}
+
+	err = c.runCollectionCommand(ctx, adminDatabase, reshardCollectionCmd, command)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.GetShardedCollection(ctx, &GetShardedCollectionOptions{
+		Database:   options.Database,
+		Collection: options.Collection,
+		Primary:    true,
+	})
+}
+
+// GetShardedCollection reads the collection from config.collections. The collections dropped
+// since they were sharded are reported as not found.
+func (c *Client) GetShardedCollection(
+	ctx context.Context,
+	options *GetShardedCollectionOptions,
+) (_ *ShardedCollection, err error) {
+	ctx, end := c.startOperation(ctx, "GetShardedCollection")
+	defer end(&err)
+
+	namespace := options.Database + "." + options.Collection
+
+	var result struct {
+		Key     bson.D `bson:"key"`
+		Unique  bool   `bson:"unique"`
+		Dropped bool   `bson:"dropped"`
+	}
+
+	err = c.database(configDatabase, options.Primary).Collection("collections").
+		FindOne(ctx, bson.D{{Key: "_id", Value: namespace}}).Decode(&result)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, NotFoundError{namespace, "sharded collection"}
+		}
+
+		return nil, wrapCommandError(findDocumentCmd, 0, err)
+	}
+
+	if result.Dropped {
+		return nil, NotFoundError{namespace, "sharded collection"}
+	}
+
+	return &ShardedCollection{
+		Database:   options.Database,
+		Collection: options.Collection,
+		Key:        IndexKeys(result.Key),
+		Unique:     result.Unique,
+	}, nil
+}
@@ -1,30 +1,86 @@
 package mongodb
 
 import (
+	"errors"
 	"fmt"
+	"strings"
 
 	"go.mongodb.org/mongo-driver/v2/bson"
-	"go.mongodb.org/mongo-driver/v2/mongo/options"
 )
 
-type IndexKeys map[string]interface{}
+// IndexKeys is the index key document. The order of the fields is significant for compound indexes.
+// The field names are kept verbatim, so dotted names like a.b.c address the embedded fields.
+type IndexKeys bson.D
 
 type IndexOptions struct {
-	Unique                  *bool                  `bson:"unique,omitempty"`
-	Sparse                  *bool                  `bson:"sparse,omitempty"`
-	Hidden                  *bool                  `bson:"hidden,omitempty"`
-	PartialFilterExpression map[string]interface{} `bson:"partialFilterExpression,omitempty"`
-	WildcardProjection      map[string]int32       `bson:"wildcardProjection,omitempty"`
-	Collation               *options.Collation     `bson:"collation,omitempty"`
-	ExpireAfterSeconds      *int32                 `bson:"expireAfterSeconds,omitempty"`
-	SphereVersion           *int32                 `bson:"2dSphereVersion,omitempty"`
-	Bits                    *int32                 `bson:"bits,omitempty"`
-	Min                     *float64               `bson:"min,omitempty"`
-	Max                     *float64               `bson:"max,omitempty"`
-	Weights                 map[string]int32       `bson:"weights,omitempty"`
-	DefaultLanguage         *string                `bson:"default_language,omitempty"`
-	LanguageOverride        *string                `bson:"language_override,omitempty"`
-	TextIndexVersion        *int32                 `bson:"textIndexVersion,omitempty"`
+	Unique                  *bool            `bson:"unique,omitempty"`
+	Sparse                  *bool            `bson:"sparse,omitempty"`
+	Hidden                  *bool            `bson:"hidden,omitempty"`
+	PartialFilterExpression bson.D           `bson:"partialFilterExpression,omitempty"`
+	WildcardProjection      IndexProjection  `bson:"wildcardProjection,omitempty"`
+	ColumnstoreProjection   IndexProjection  `bson:"columnstoreProjection,omitempty"`
+	StorageEngine           bson.D           `bson:"storageEngine,omitempty"`
+	Collation               *Collation       `bson:"collation,omitempty"`
+	ExpireAfterSeconds      *int32           `bson:"expireAfterSeconds,omitempty"`
+	SphereVersion           *int32           `bson:"2dSphereVersion,omitempty"`
+	Bits                    *int32           `bson:"bits,omitempty"`
+	Min                     *float64         `bson:"min,omitempty"`
+	Max                     *float64         `bson:"max,omitempty"`
+	BucketSize              *int32           `bson:"bucketSize,omitempty"`
+	Weights                 map[string]int32 `bson:"weights,omitempty"`
+	DefaultLanguage         *string          `bson:"default_language,omitempty"`
+	LanguageOverride        *string          `bson:"language_override,omitempty"`
+	TextIndexVersion        *int32           `bson:"textIndexVersion,omitempty"`
+	// IndexVersion is the index format version set by the server, it's not sent on create
+	IndexVersion *int32 `bson:"v,omitempty"`
+}
+
+// IndexProjection includes (1) or excludes (0) the fields of a wildcard or columnstore index.
+type IndexProjection map[string]int32
+
+// UnmarshalBSONValue reads the projections written with booleans or doubles, e.g. by the shell,
+// as the same 0 and 1 values used by the provider.
+func (p *IndexProjection) UnmarshalBSONValue(typ byte, data []byte) error {
+	*p = nil
+
+	if bson.Type(typ) != bson.TypeEmbeddedDocument {
+		return nil
+	}
+
+	elements, err := bson.Raw(data).Elements()
+	if err != nil {
+		return err
+	}
+
+	out := make(IndexProjection, len(elements))
+
+	for _, element := range elements {
+		value := element.Value()
+
+		switch value.Type {
+		case bson.TypeBoolean:
+			out[element.Key()] = boolToInt32(value.Boolean())
+		default:
+			n, ok := value.AsInt64OK()
+			if !ok {
+				return fmt.Errorf("projection field %q has the unsupported type %s", element.Key(), value.Type)
+			}
+
+			out[element.Key()] = boolToInt32(n != 0)
+		}
+	}
+
+	*p = out
+
+	return nil
+}
+
+func boolToInt32(b bool) int32 {
+	if b {
+		return 1
+	}
+
+	return 0
 }
 
 type Index struct {
@@ -33,49 +89,140 @@ type Index struct {
 	Collection string       `bson:"-"` // Not in MongoDB response
 	Keys       IndexKeys    `bson:"key"`
 	Options    IndexOptions `bson:"inline"` // Inline embedding
+	// CommitQuorum is the number of data bearing voting members, "majority" or "votingMembers",
+	// which must finish the index build before the index is ready. Only used on create
+	CommitQuorum string `bson:"-"`
+}
+
+// ColumnstoreIndexType is the key type of the column store indexes, available since MongoDB 6.3.
+const ColumnstoreIndexType = "columnstore"
+
+// GeoHaystackIndexType is the key type of the geoHaystack indexes, removed in MongoDB 5.0.
+const GeoHaystackIndexType = "geoHaystack"
+
+// WildcardIndexField is the key field of the wildcard indexes over all the fields of the documents.
+const WildcardIndexField = "$**"
+
+// defaultIndexName is the name of the index MongoDB creates on _id for every collection.
+const defaultIndexName = "_id_"
+
+// IsDefault reports whether the index is the _id index, which cannot be dropped.
+func (i *Index) IsDefault() bool {
+	return i.Name == defaultIndexName
+}
+
+// NewIndexKey builds an index key field. Ascending and descending keys are sent as numbers.
+func NewIndexKey(field, keyType string) bson.E {
+	switch keyType {
+	case "1":
+		return bson.E{Key: field, Value: 1}
+	case "-1":
+		return bson.E{Key: field, Value: -1}
+	default:
+		return bson.E{Key: field, Value: keyType}
+	}
+}
+
+// ParseIndexKeysJSON parses the index key document from JSON preserving the order and the numeric types of the fields.
+func ParseIndexKeysJSON(data string) (IndexKeys, error) {
+	var out bson.D
+
+	err := bson.UnmarshalExtJSON([]byte(data), false, &out)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(out) == 0 {
+		return nil, errors.New("index key document must have at least one field")
+	}
+
+	return IndexKeys(out), nil
 }
 
 func (k IndexKeys) toBson() bson.D {
-	out := bson.D{}
+	return bson.D(k)
+}
 
-	for field, value := range k {
-		out = append(out, bson.E{Key: field, Value: value})
+// JSON returns the index key document as relaxed extended JSON keeping the order of the fields.
+func (k IndexKeys) JSON() (string, error) {
+	out, err := bson.MarshalExtJSON(bson.D(k), false, false)
+	if err != nil {
+		return "", err
+	}
+
+	return string(out), nil
+}
+
+// Equal reports whether both key documents have the same fields in the same order.
+// Numeric values are compared regardless of their BSON type, so 1 equals 1.0.
+func (k IndexKeys) Equal(other IndexKeys) bool {
+	if len(k) != len(other) {
+		return false
+	}
+
+	for i := range k {
+		if k[i].Key != other[i].Key || keyValueString(k[i].Value) != keyValueString(other[i].Value) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Types returns the type of every key field in order, e.g. "1", "-1" or "hashed".
+func (k IndexKeys) Types() []string {
+	out := make([]string, 0, len(k))
+
+	for _, key := range k {
+		out = append(out, keyValueString(key.Value))
 	}
 
 	return out
 }
 
-func (k IndexKeys) ToStringMap() map[string]string {
-	out := map[string]string{}
+// HasType reports whether any key field has the given type.
+func (k IndexKeys) HasType(keyType string) bool {
+	for _, t := range k.Types() {
+		if t == keyType {
+			return true
+		}
+	}
+
+	return false
+}
 
-	for field, value := range k {
-		var ok bool
+// WildcardFields returns the wildcard key fields: $** for all the fields or a path ending with .$**.
+func (k IndexKeys) WildcardFields() []string {
+	var out []string
 
-		out[field], ok = value.(string)
-		if !ok {
-			out[field] = fmt.Sprintf("%v", value)
+	for _, key := range k {
+		if key.Key == WildcardIndexField || strings.HasSuffix(key.Key, "."+WildcardIndexField) {
+			out = append(out, key.Key)
 		}
 	}
 
 	return out
 }
 
-func ConvertMap(k map[string]string, indexKeys bool) map[string]interface{} {
-	out := map[string]interface{}{}
+// IsCompoundWildcard reports whether the wildcard key is combined with other keys, available since MongoDB 7.0.
+func (k IndexKeys) IsCompoundWildcard() bool {
+	return len(k) > 1 && len(k.WildcardFields()) > 0
+}
 
-	for field, value := range k {
-		if indexKeys {
-			switch value {
-			case "1":
-				out[field] = 1
-			case "-1":
-				out[field] = -1
-			default:
-				out[field] = value
-			}
-		} else {
-			out[field] = value
-		}
+func (k IndexKeys) ToStringMap() map[string]string {
+	out := map[string]string{}
+
+	for _, key := range k {
+		out[key.Key] = keyValueString(key.Value)
+	}
+
+	return out
+}
+
+func keyValueString(value interface{}) string {
+	out, ok := value.(string)
+	if !ok {
+		out = fmt.Sprintf("%v", value)
 	}
 
 	return out
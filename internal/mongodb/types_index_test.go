@@ -0,0 +1,284 @@
+package mongodb
+
+import (
+	"maps"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// roundTripIndex marshals the index specification like createIndexes and reads it back like listIndexes.
+func roundTripIndex(t *testing.T, keys IndexKeys) Index {
+	t.Helper()
+
+	spec, err := bson.Marshal(bson.D{{Key: "name", Value: "test_index"}, {Key: "key", Value: keys.toBson()}})
+	if err != nil {
+		t.Fatalf("failed to marshal the index: %v", err)
+	}
+
+	var index Index
+	if err := bson.Unmarshal(spec, &index); err != nil {
+		t.Fatalf("failed to unmarshal the index: %v", err)
+	}
+
+	return index
+}
+
+func TestIndexKeysDottedFieldsVerbatim(t *testing.T) {
+	t.Parallel()
+
+	keys := IndexKeys{
+		NewIndexKey("a.b.c", "1"),
+		NewIndexKey("address.zip", "-1"),
+		NewIndexKey("tags.0.name", "1"),
+	}
+
+	document := keys.toBson()
+	if len(document) != len(keys) {
+		t.Fatalf("expected %d fields, got %v", len(keys), document)
+	}
+
+	for i, key := range keys {
+		if document[i].Key != key.Key {
+			t.Errorf("field %d: expected %q, got %q", i, key.Key, document[i].Key)
+		}
+	}
+
+	index := roundTripIndex(t, keys)
+
+	if !index.Keys.Equal(keys) {
+		t.Errorf("expected the keys %v to round trip, got %v", keys, index.Keys)
+	}
+
+	if types := index.Keys.ToStringMap(); types["a.b.c"] != "1" || types["address.zip"] != "-1" {
+		t.Errorf("expected the dotted fields to keep their types, got %v", types)
+	}
+}
+
+func TestParseIndexKeysJSONDottedFields(t *testing.T) {
+	t.Parallel()
+
+	keys, err := ParseIndexKeysJSON(`{"a.b.c": 1, "a.b": -1, "a": 1}`)
+	if err != nil {
+		t.Fatalf("failed to parse the keys: %v", err)
+	}
+
+	expected := []string{"a.b.c", "a.b", "a"}
+	for i, field := range expected {
+		if keys[i].Key != field {
+			t.Errorf("field %d: expected %q, got %q", i, field, keys[i].Key)
+		}
+	}
+
+	out, err := keys.JSON()
+	if err != nil {
+		t.Fatalf("failed to encode the keys: %v", err)
+	}
+
+	if out != `{"a.b.c":1,"a.b":-1,"a":1}` {
+		t.Errorf("expected the dotted fields to be encoded verbatim, got %s", out)
+	}
+}
+
+func TestIndexProjectionUnmarshal(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		projection bson.D
+		expected   IndexProjection
+	}{
+		"inclusion": {
+			projection: bson.D{{Key: "a", Value: int32(1)}, {Key: "b.c", Value: true}, {Key: "_id", Value: 0.0}},
+			expected:   IndexProjection{"a": 1, "b.c": 1, "_id": 0},
+		},
+		"exclusion": {
+			projection: bson.D{{Key: "a", Value: int32(0)}, {Key: "b.c", Value: false}, {Key: "_id", Value: 1.0}},
+			expected:   IndexProjection{"a": 0, "b.c": 0, "_id": 1},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			spec, err := bson.Marshal(bson.D{
+				{Key: "name", Value: "$**_1"},
+				{Key: "key", Value: bson.D{{Key: WildcardIndexField, Value: int32(1)}}},
+				{Key: "wildcardProjection", Value: test.projection},
+			})
+			if err != nil {
+				t.Fatalf("failed to marshal the index: %v", err)
+			}
+
+			var index Index
+			if err := bson.Unmarshal(spec, &index); err != nil {
+				t.Fatalf("failed to unmarshal the index: %v", err)
+			}
+
+			if !maps.Equal(index.Options.WildcardProjection, test.expected) {
+				t.Errorf("expected %v, got %v", test.expected, index.Options.WildcardProjection)
+			}
+		})
+	}
+}
+
+func TestParseIndexKeysJSONFidelity(t *testing.T) {
+	t.Parallel()
+
+	data := `{"b":-1,"a":1,"score":1.5,"n":{"$numberLong":"1"},"loc":"2dsphere"}`
+
+	keys, err := ParseIndexKeysJSON(data)
+	if err != nil {
+		t.Fatalf("failed to parse the keys: %v", err)
+	}
+
+	expected := IndexKeys{
+		{Key: "b", Value: int32(-1)},
+		{Key: "a", Value: int32(1)},
+		{Key: "score", Value: 1.5},
+		{Key: "n", Value: int64(1)},
+		{Key: "loc", Value: "2dsphere"},
+	}
+
+	if len(keys) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, keys)
+	}
+
+	for i := range expected {
+		if keys[i] != expected[i] {
+			t.Errorf("field %d: expected %#v, got %#v", i, expected[i], keys[i])
+		}
+	}
+
+	out, err := keys.JSON()
+	if err != nil {
+		t.Fatalf("failed to encode the keys: %v", err)
+	}
+
+	// The relaxed extended JSON writes the int64 as a plain number, which is the same key
+	if expectedJSON := `{"b":-1,"a":1,"score":1.5,"n":1,"loc":"2dsphere"}`; out != expectedJSON {
+		t.Errorf("expected the JSON %s, got %s", expectedJSON, out)
+	}
+
+	reparsed, err := ParseIndexKeysJSON(out)
+	if err != nil || !reparsed.Equal(keys) {
+		t.Errorf("expected the JSON to parse back to %v, got %v (%v)", keys, reparsed, err)
+	}
+
+	index := roundTripIndex(t, keys)
+
+	for i := range expected {
+		if index.Keys[i] != expected[i] {
+			t.Errorf("field %d read back: expected %#v, got %#v", i, expected[i], index.Keys[i])
+		}
+	}
+}
+
+func TestIndexKeysEqual(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		keys, other IndexKeys
+		equal       bool
+	}{
+		"single field": {
+			keys:  IndexKeys{{Key: "a", Value: int32(1)}},
+			other: IndexKeys{{Key: "a", Value: int32(1)}},
+			equal: true,
+		},
+		"single field with another numeric type": {
+			keys:  IndexKeys{{Key: "a", Value: int32(-1)}},
+			other: IndexKeys{{Key: "a", Value: -1.0}},
+			equal: true,
+		},
+		"single field with int64": {
+			keys:  IndexKeys{{Key: "a", Value: int32(1)}},
+			other: IndexKeys{{Key: "a", Value: int64(1)}},
+			equal: true,
+		},
+		"single field with another direction": {
+			keys:  IndexKeys{{Key: "a", Value: int32(1)}},
+			other: IndexKeys{{Key: "a", Value: int32(-1)}},
+		},
+		"single field with another name": {
+			keys:  IndexKeys{{Key: "a", Value: int32(1)}},
+			other: IndexKeys{{Key: "b", Value: int32(1)}},
+		},
+		"compound": {
+			keys:  IndexKeys{{Key: "a", Value: int32(1)}, {Key: "b", Value: "hashed"}},
+			other: IndexKeys{{Key: "a", Value: 1.0}, {Key: "b", Value: "hashed"}},
+			equal: true,
+		},
+		"compound in another order": {
+			keys:  IndexKeys{{Key: "a", Value: int32(1)}, {Key: "b", Value: int32(-1)}},
+			other: IndexKeys{{Key: "b", Value: int32(-1)}, {Key: "a", Value: int32(1)}},
+		},
+		"compound prefix": {
+			keys:  IndexKeys{{Key: "a", Value: int32(1)}, {Key: "b", Value: int32(-1)}},
+			other: IndexKeys{{Key: "a", Value: int32(1)}},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if equal := test.keys.Equal(test.other); equal != test.equal {
+				t.Errorf("expected %v equal to %v: %t, got %t", test.keys, test.other, test.equal, equal)
+			}
+
+			if equal := test.other.Equal(test.keys); equal != test.equal {
+				t.Errorf("expected the comparison to be symmetric for %v and %v", test.keys, test.other)
+			}
+		})
+	}
+}
+
+func TestIndexSpec(t *testing.T) {
+	t.Parallel()
+
+	hidden := true
+	expireAfterSeconds := int32(3600)
+	version := int32(2)
+
+	index := Index{
+		Name: "created_at_1",
+		Keys: IndexKeys{NewIndexKey("created_at", "1")},
+		Options: IndexOptions{
+			Hidden:                  &hidden,
+			ExpireAfterSeconds:      &expireAfterSeconds,
+			PartialFilterExpression: bson.D{{Key: "status", Value: "active"}},
+			Collation:               &Collation{Collation: options.Collation{Locale: "fr", CaseLevel: true}},
+			IndexVersion:            &version,
+		},
+	}
+
+	spec, err := index.spec()
+	if err != nil {
+		t.Fatalf("failed to build the spec: %v", err)
+	}
+
+	expected := bson.D{
+		{Key: "key", Value: bson.D{{Key: "created_at", Value: int32(1)}}},
+		{Key: "name", Value: "created_at_1"},
+		{Key: "hidden", Value: true},
+		{Key: "partialFilterExpression", Value: bson.D{{Key: "status", Value: "active"}}},
+		{Key: "expireAfterSeconds", Value: int32(3600)},
+		{Key: "collation", Value: bson.D{{Key: "locale", Value: "fr"}, {Key: "caseLevel", Value: true}}},
+	}
+
+	expectedJSON, err := bson.MarshalExtJSON(expected, true, false)
+	if err != nil {
+		t.Fatalf("failed to encode the expected spec: %v", err)
+	}
+
+	actualJSON, err := bson.MarshalExtJSON(spec, true, false)
+	if err != nil {
+		t.Fatalf("failed to encode the spec: %v", err)
+	}
+
+	if string(actualJSON) != string(expectedJSON) {
+		t.Errorf("expected the spec %s, got %s", expectedJSON, actualJSON)
+	}
+}
@@ -0,0 +1,111 @@
+package mongodb
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// AuthenticationRestriction limits the addresses a user can authenticate from and to.
+// The addresses are IP addresses or CIDR ranges.
+type AuthenticationRestriction struct {
+	ClientSource  []string `bson:"clientSource,omitempty"  tfsdk:"client_source"`
+	ServerAddress []string `bson:"serverAddress,omitempty" tfsdk:"server_address"`
+}
+
+type AuthenticationRestrictions []AuthenticationRestriction
+
+// disabledClientSource is the broadcast address, which is never the address of a client.
+const disabledClientSource = "255.255.255.255/32"
+
+// disabledAuthenticationRestrictions disable a user, as MongoDB has no account lock: the user can only
+// authenticate from an address no client has. The roles and the sessions already authenticated are kept.
+func disabledAuthenticationRestrictions() AuthenticationRestrictions {
+	return AuthenticationRestrictions{{ClientSource: []string{disabledClientSource}}}
+}
+
+func (a AuthenticationRestrictions) isDisabled() bool {
+	return len(a) == 1 && len(a[0].ServerAddress) == 0 &&
+		len(a[0].ClientSource) == 1 && a[0].ClientSource[0] == disabledClientSource
+}
+
+// ToTerraformList returns an empty list rather than a null one when there are no restrictions.
+func (a *AuthenticationRestrictions) ToTerraformList(ctx context.Context) (types.List, diag.Diagnostics) {
+	restrictions := *a
+	if restrictions == nil {
+		restrictions = AuthenticationRestrictions{}
+	}
+
+	return types.ListValueFrom(ctx, types.ObjectType{AttrTypes: AuthenticationRestrictionAttributeTypes}, restrictions)
+}
+
+// UnmarshalBSONValue flattens the nested arrays, as rolesInfo groups the restrictions of a role in one more array.
+func (a *AuthenticationRestrictions) UnmarshalBSONValue(typ byte, data []byte) error {
+	*a = nil
+
+	if bson.Type(typ) != bson.TypeArray {
+		return nil
+	}
+
+	return a.appendValue(bson.RawValue{Type: bson.Type(typ), Value: data})
+}
+
+func (a *AuthenticationRestrictions) appendValue(value bson.RawValue) error {
+	switch value.Type {
+	case bson.TypeArray:
+		values, err := value.Array().Values()
+		if err != nil {
+			return err
+		}
+
+		for _, v := range values {
+			err = a.appendValue(v)
+			if err != nil {
+				return err
+			}
+		}
+	case bson.TypeEmbeddedDocument:
+		var restriction AuthenticationRestriction
+
+		err := bson.Unmarshal(value.Document(), &restriction)
+		if err != nil {
+			return err
+		}
+
+		*a = append(*a, restriction)
+	}
+
+	return nil
+}
+
+func (a *AuthenticationRestrictions) toBson() bson.A {
+	out := bson.A{}
+
+	for _, restriction := range *a {
+		document := bson.D{}
+
+		if len(restriction.ClientSource) > 0 {
+			document = append(document, bson.E{Key: "clientSource", Value: restriction.ClientSource})
+		}
+
+		if len(restriction.ServerAddress) > 0 {
+			document = append(document, bson.E{Key: "serverAddress", Value: restriction.ServerAddress})
+		}
+
+		out = append(out, document)
+	}
+
+	return out
+}
+
+var AuthenticationRestrictionAttributeTypes = map[string]attr.Type{
+	"client_source": types.ListType{
+		ElemType: types.StringType,
+	},
+	"server_address": types.ListType{
+		ElemType: types.StringType,
+	},
+}
@@ -1,7 +1,9 @@
 package mongodb
 
 import (
+	"cmp"
 	"context"
+	"slices"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
@@ -10,9 +12,56 @@ import (
 	"go.mongodb.org/mongo-driver/v2/bson"
 )
 
+// Resource is either a database and collection pair, the cluster or any resource.
+// An empty collection is every collection of the database, an empty database is every database.
 type Resource struct {
-	DB         string `bson:"db"         tfsdk:"db"`
-	Collection string `bson:"collection" tfsdk:"collection"`
+	DB          *string `bson:"db,omitempty"          tfsdk:"db"`
+	Collection  *string `bson:"collection,omitempty"  tfsdk:"collection"`
+	Cluster     *bool   `bson:"cluster,omitempty"     tfsdk:"cluster"`
+	AnyResource *bool   `bson:"anyResource,omitempty" tfsdk:"any_resource"`
+}
+
+func (r *Resource) IsCluster() bool {
+	return r.Cluster != nil && *r.Cluster
+}
+
+func (r *Resource) IsAnyResource() bool {
+	return r.AnyResource != nil && *r.AnyResource
+}
+
+// Equal reports whether both resources target the same database and collection, the cluster or any resource.
+func (r *Resource) Equal(other *Resource) bool {
+	return r.IsCluster() == other.IsCluster() &&
+		r.IsAnyResource() == other.IsAnyResource() &&
+		r.db() == other.db() &&
+		r.collection() == other.collection()
+}
+
+func (r *Resource) toBson() bson.M {
+	switch {
+	case r.IsCluster():
+		return bson.M{"cluster": true}
+	case r.IsAnyResource():
+		return bson.M{"anyResource": true}
+	default:
+		return bson.M{"db": r.db(), "collection": r.collection()}
+	}
+}
+
+func (r *Resource) db() string {
+	if r.DB == nil {
+		return ""
+	}
+
+	return *r.DB
+}
+
+func (r *Resource) collection() string {
+	if r.Collection == nil {
+		return ""
+	}
+
+	return *r.Collection
 }
 
 type Privilege struct {
@@ -47,16 +96,51 @@ func (p *Privileges) ToTerraformSet(ctx context.Context) (*types.Set, diag.Diagn
 	return &privilegesList, nil
 }
 
+// Intersect returns the actions of the privileges which the other privileges grant on the same resource as well.
+func (p *Privileges) Intersect(other Privileges) Privileges {
+	return p.filterActions(other, true)
+}
+
+// Subtract returns the actions of the privileges which the other privileges do not grant on the same resource.
+func (p *Privileges) Subtract(other Privileges) Privileges {
+	return p.filterActions(other, false)
+}
+
+func (p *Privileges) filterActions(other Privileges, granted bool) Privileges {
+	out := Privileges{}
+
+	for _, privilege := range *p {
+		var otherActions []string
+
+		for i := range other {
+			if privilege.Resource.Equal(&other[i].Resource) {
+				otherActions = append(otherActions, other[i].Actions...)
+			}
+		}
+
+		actions := []string{}
+
+		for _, action := range privilege.Actions {
+			if slices.Contains(otherActions, action) == granted {
+				actions = append(actions, action)
+			}
+		}
+
+		if len(actions) > 0 {
+			out = append(out, Privilege{Resource: privilege.Resource, Actions: actions})
+		}
+	}
+
+	return out
+}
+
 func (p *Privileges) toBson() bson.A {
 	out := bson.A{}
 
 	for _, privilege := range *p {
 		out = append(out, bson.M{
-			"resource": bson.M{
-				"db":         privilege.Resource.DB,
-				"collection": privilege.Resource.Collection,
-			},
-			"actions": privilege.Actions,
+			"resource": privilege.Resource.toBson(),
+			"actions":  privilege.Actions,
 		})
 	}
 
@@ -70,14 +154,34 @@ type ShortRole struct {
 
 type ShortRoles []ShortRole
 
+// Normalize returns the roles sorted by database and name without the duplicates,
+// which the server stores once. The roles without a database get defaultDB, the database of the command,
+// so a role given with and without its database is a duplicate.
+func (r *ShortRoles) Normalize(defaultDB string) ShortRoles {
+	out := slices.Clone(*r)
+
+	for i := range out {
+		if out[i].DB == "" {
+			out[i].DB = defaultDB
+		}
+	}
+
+	slices.SortFunc(out, func(a, b ShortRole) int {
+		return cmp.Or(cmp.Compare(a.DB, b.DB), cmp.Compare(a.Role, b.Role))
+	})
+
+	return slices.Compact(out)
+}
+
 func (r *ShortRoles) ToTerraformSet(ctx context.Context) (*types.Set, diag.Diagnostics) {
-	roles := make([]basetypes.ObjectValue, 0, len(*r))
+	normalized := r.Normalize("")
+	roles := make([]basetypes.ObjectValue, 0, len(normalized))
 
 	roleType := types.ObjectType{
 		AttrTypes: ShortRoleAttributeTypes,
 	}
 
-	for _, role := range *r {
+	for _, role := range normalized {
 		roleObject, d := types.ObjectValueFrom(ctx, ShortRoleAttributeTypes, role)
 
 		if d.HasError() {
@@ -95,10 +199,11 @@ func (r *ShortRoles) ToTerraformSet(ctx context.Context) (*types.Set, diag.Diagn
 	return &rolesList, nil
 }
 
-func (r *ShortRoles) toBson() bson.A {
+// toBson returns the roles of a command run on defaultDB, which is the database of the roles without one.
+func (r *ShortRoles) toBson(defaultDB string) bson.A {
 	out := bson.A{}
 
-	for _, role := range *r {
+	for _, role := range r.Normalize(defaultDB) {
 		out = append(out, bson.M{"role": role.Role, "db": role.DB})
 	}
 
@@ -110,6 +215,13 @@ type Role struct {
 	Database   string     `bson:"db"`
 	Privileges Privileges `bson:"privileges"`
 	Roles      ShortRoles `bson:"roles"`
+	IsBuiltin  bool       `bson:"isBuiltin"`
+
+	// AuthenticationRestrictions are not sent when nil, an empty slice removes them
+	AuthenticationRestrictions AuthenticationRestrictions `bson:"authenticationRestrictions"`
+
+	// MaxTimeMS limits the execution time of the create and update commands. Zero means no limit.
+	MaxTimeMS int64 `bson:"-"`
 }
 
 var ShortRoleAttributeTypes = map[string]attr.Type{
@@ -117,12 +229,16 @@ var ShortRoleAttributeTypes = map[string]attr.Type{
 	"db":   types.StringType,
 }
 
+var ResourceAttributeTypes = map[string]attr.Type{
+	"db":           types.StringType,
+	"collection":   types.StringType,
+	"cluster":      types.BoolType,
+	"any_resource": types.BoolType,
+}
+
 var PrivilegeAttributeTypes = map[string]attr.Type{
 	"resource": types.ObjectType{
-		AttrTypes: map[string]attr.Type{
-			"db":         types.StringType,
-			"collection": types.StringType,
-		},
+		AttrTypes: ResourceAttributeTypes,
 	},
 	"actions": types.SetType{
 		ElemType: types.StringType,
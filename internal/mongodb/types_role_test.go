@@ -0,0 +1,78 @@
+package mongodb
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestShortRolesNormalize(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		roles    ShortRoles
+		expected ShortRoles
+	}{
+		"exact duplicates": {
+			roles:    ShortRoles{{Role: "readWrite", DB: "app"}, {Role: "readWrite", DB: "app"}},
+			expected: ShortRoles{{Role: "readWrite", DB: "app"}},
+		},
+		"implicit and explicit database": {
+			roles:    ShortRoles{{Role: "readWrite"}, {Role: "readWrite", DB: "admin"}},
+			expected: ShortRoles{{Role: "readWrite", DB: "admin"}},
+		},
+		"same role in another database": {
+			roles:    ShortRoles{{Role: "readWrite"}, {Role: "readWrite", DB: "app"}},
+			expected: ShortRoles{{Role: "readWrite", DB: "admin"}, {Role: "readWrite", DB: "app"}},
+		},
+		"order": {
+			roles: ShortRoles{
+				{Role: "read", DB: "reporting"},
+				{Role: "readWrite", DB: "app"},
+				{Role: "clusterMonitor", DB: "admin"},
+				{Role: "dbAdmin", DB: "app"},
+			},
+			expected: ShortRoles{
+				{Role: "clusterMonitor", DB: "admin"},
+				{Role: "dbAdmin", DB: "app"},
+				{Role: "readWrite", DB: "app"},
+				{Role: "read", DB: "reporting"},
+			},
+		},
+		"duplicates out of order": {
+			roles: ShortRoles{
+				{Role: "readWrite", DB: "app"},
+				{Role: "clusterMonitor"},
+				{Role: "readWrite", DB: "app"},
+				{Role: "clusterMonitor", DB: "admin"},
+			},
+			expected: ShortRoles{{Role: "clusterMonitor", DB: "admin"}, {Role: "readWrite", DB: "app"}},
+		},
+		"empty": {
+			roles:    ShortRoles{},
+			expected: ShortRoles{},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			normalized := test.roles.Normalize("admin")
+			if !slices.Equal(normalized, test.expected) {
+				t.Errorf("expected %+v, got %+v", test.expected, normalized)
+			}
+		})
+	}
+}
+
+func TestShortRolesNormalizeKeepsRoles(t *testing.T) {
+	t.Parallel()
+
+	roles := ShortRoles{{Role: "readWrite"}, {Role: "read", DB: "app"}}
+
+	_ = roles.Normalize("admin")
+
+	if roles[0].DB != "" || roles[1].Role != "read" {
+		t.Errorf("expected the roles to be left unchanged, got %+v", roles)
+	}
+}
@@ -1,5 +1,7 @@
 package mongodb
 
+import "go.mongodb.org/mongo-driver/v2/bson"
+
 type User struct {
 	Username string `bson:"user"`
 	Password string
@@ -7,8 +9,37 @@ type User struct {
 	Database   string     `bson:"db"`
 	Roles      ShortRoles `bson:"roles"`
 	Mechanisms []string   `bson:"mechanisms"`
+
+	// AuthenticationRestrictions are not sent when nil, an empty slice removes them
+	AuthenticationRestrictions AuthenticationRestrictions `bson:"authenticationRestrictions"`
+	// CustomData is not sent when nil, an empty document removes it
+	CustomData bson.D `bson:"customData"`
+
+	// PasswordDigestor is PasswordDigestorServer or PasswordDigestorClient, the server digests the password when empty
+	PasswordDigestor string `bson:"-"`
+
+	// MaxTimeMS limits the execution time of the create and update commands. Zero means no limit.
+	MaxTimeMS int64 `bson:"-"`
+
+	// Disabled users can't authenticate, see disabledAuthenticationRestrictions.
+	// Their authentication restrictions are restored when they are enabled again
+	Disabled bool `bson:"-"`
+}
+
+// readDisabled reports the user with the deny-all restriction as disabled, without the restriction.
+func (u *User) readDisabled() {
+	if u.AuthenticationRestrictions.isDisabled() {
+		u.Disabled = true
+		u.AuthenticationRestrictions = nil
+	}
 }
 
+const (
+	PasswordDigestorServer = "server"
+	// PasswordDigestorClient sends the SCRAM-SHA-1 digest instead of the password, SCRAM-SHA-256 is not supported
+	PasswordDigestorClient = "client"
+)
+
 type Result struct {
 	Ok int `bson:"ok"`
 }
@@ -2,7 +2,12 @@ package mongodb
 
 import (
 	"context"
+	"crypto/md5"
+	"encoding/hex"
 	"errors"
+	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"go.mongodb.org/mongo-driver/v2/bson"
@@ -13,9 +18,17 @@ const (
 	getUserCmd    = "usersInfo"
 	updateUserCmr = "updateUser"
 	deleteUserCmd = "dropUser"
+
+	grantRolesToUserCmd    = "grantRolesToUser"
+	revokeRolesFromUserCmd = "revokeRolesFromUser"
+
+	authenticationMechanismsParameter = "authenticationMechanisms"
 )
 
-func (c *Client) UpsertUser(ctx context.Context, user *User) (*User, error) {
+func (c *Client) UpsertUser(ctx context.Context, user *User) (_ *User, err error) {
+	ctx, end := c.startOperation(ctx, "UpsertUser")
+	defer end(&err)
+
 	tflog.Debug(ctx, "UpsertUser", map[string]interface{}{
 		"username": user.Username,
 		"db":       user.Database,
@@ -27,7 +40,7 @@ func (c *Client) UpsertUser(ctx context.Context, user *User) (*User, error) {
 		Username: user.Username,
 		Database: user.Database,
 	}
-	_, err := c.GetUser(ctx, getUserOptions)
+	current, err := c.GetUser(ctx, getUserOptions)
 
 	switch {
 	case errors.As(err, &NotFoundError{}):
@@ -38,23 +51,18 @@ func (c *Client) UpsertUser(ctx context.Context, user *User) (*User, error) {
 		return nil, err
 	}
 
-	command := bson.D{
-		{Key: cmd, Value: user.Username},
-		// Roles field is required, but empty array is fine
-		{Key: "roles", Value: user.Roles.toBson()},
-	}
+	mechanisms := c.userMechanisms(ctx, user, current)
 
-	if user.Password != "" {
-		command = append(command, bson.E{Key: "pwd", Value: user.Password})
+	err = c.checkMechanisms(ctx, user, current, mechanisms)
+	if err != nil {
+		return nil, err
 	}
 
-	if len(user.Mechanisms) > 0 {
-		command = append(command, bson.E{Key: "mechanisms", Value: user.Mechanisms})
-	}
+	command := c.withWriteOptions(userCommand(cmd, user, current, mechanisms))
 
-	response := c.mongo.Database(user.Database).RunCommand(ctx, command)
+	response := c.runCommand(ctx, user.Database, command)
 	if err = response.Err(); err != nil {
-		return nil, err
+		return nil, wrapCommandError(cmd, user.MaxTimeMS, err)
 	}
 
 	result := &Result{}
@@ -76,6 +84,173 @@ func (c *Client) UpsertUser(ctx context.Context, user *User) (*User, error) {
 	return user, nil
 }
 
+// scramMechanisms are the mechanisms of the credentials created with the password.
+var scramMechanisms = []string{"SCRAM-SHA-1", "SCRAM-SHA-256"}
+
+// userMechanisms returns the mechanisms sent with the user: the configured ones, otherwise the current ones,
+// as a new password creates the credentials of every mechanism enabled on the server when they are not sent.
+// A new user gets the SCRAM mechanisms enabled on the server, the server default is used when they can't be read,
+// e.g. on DocumentDB. The users without a password, e.g. of $external, have no SCRAM mechanisms.
+func (c *Client) userMechanisms(ctx context.Context, user *User, current *User) []string {
+	switch {
+	case len(user.Mechanisms) > 0:
+		return user.Mechanisms
+	case current != nil:
+		return filterScramMechanisms(current.Mechanisms)
+	case user.Password == "" || c.IsDocumentDB():
+		return nil
+	}
+
+	enabled, err := c.serverMechanisms(ctx)
+	if err != nil {
+		tflog.Debug(ctx, "failed to read the authentication mechanisms", map[string]interface{}{
+			"err": err.Error(),
+		})
+
+		return nil
+	}
+
+	return filterScramMechanisms(enabled)
+}
+
+// filterScramMechanisms drops the mechanisms without credentials stored in the user, e.g. MONGODB-X509.
+func filterScramMechanisms(mechanisms []string) []string {
+	var out []string
+
+	for _, mechanism := range mechanisms {
+		if slices.Contains(scramMechanisms, mechanism) {
+			out = append(out, mechanism)
+		}
+	}
+
+	return out
+}
+
+// userCommand builds the createUser or updateUser command of the user, current is nil for a new user.
+func userCommand(cmd string, user *User, current *User, mechanisms []string) bson.D {
+	restrictions := user.AuthenticationRestrictions
+
+	switch {
+	case user.Disabled:
+		restrictions = disabledAuthenticationRestrictions()
+	case restrictions == nil && current != nil && current.Disabled:
+		// Enabling the user removes the deny-all restriction
+		restrictions = AuthenticationRestrictions{}
+	}
+
+	command := bson.D{
+		{Key: cmd, Value: user.Username},
+		// Roles field is required, but empty array is fine
+		{Key: "roles", Value: user.Roles.toBson(user.Database)},
+	}
+
+	if user.Password != "" {
+		switch user.PasswordDigestor {
+		case PasswordDigestorClient:
+			command = append(command,
+				bson.E{Key: "pwd", Value: digestPassword(user.Username, user.Password)},
+				bson.E{Key: "digestPassword", Value: false},
+			)
+		default:
+			command = append(command, bson.E{Key: "pwd", Value: user.Password})
+		}
+	}
+
+	if len(mechanisms) > 0 {
+		command = append(command, bson.E{Key: "mechanisms", Value: mechanisms})
+	}
+
+	if restrictions != nil {
+		command = append(command, bson.E{
+			Key:   "authenticationRestrictions",
+			Value: restrictions.toBson(),
+		})
+	}
+
+	if user.CustomData != nil {
+		command = append(command, bson.E{Key: "customData", Value: user.CustomData})
+	}
+
+	if user.MaxTimeMS > 0 {
+		command = append(command, bson.E{Key: "maxTimeMS", Value: user.MaxTimeMS})
+	}
+
+	return command
+}
+
+// checkMechanisms verifies that the mechanisms are enabled on the server, and that the mechanisms
+// added to an existing user come with the password, as the server can't create their credentials without it.
+func (c *Client) checkMechanisms(ctx context.Context, user *User, current *User, mechanisms []string) error {
+	if current != nil && user.Password == "" && len(current.Mechanisms) > 0 {
+		for _, mechanism := range mechanisms {
+			if !slices.Contains(current.Mechanisms, mechanism) {
+				return fmt.Errorf("adding the %s mechanism to the user %q requires setting the password again",
+					mechanism, user.Username)
+			}
+		}
+	}
+
+	// DocumentDB doesn't expose the enabled mechanisms
+	if len(user.Mechanisms) == 0 || c.IsDocumentDB() {
+		return nil
+	}
+
+	enabled, err := c.serverMechanisms(ctx)
+	if err != nil {
+		// The check is skipped for the users allowed to manage users, but not to read the server parameters
+		tflog.Debug(ctx, "failed to read the authentication mechanisms", map[string]interface{}{
+			"err": err.Error(),
+		})
+
+		return nil
+	}
+
+	for _, mechanism := range user.Mechanisms {
+		if !slices.Contains(enabled, mechanism) {
+			return fmt.Errorf("the %s mechanism is not enabled on the server, enabled mechanisms: %s",
+				mechanism, strings.Join(enabled, ", "))
+		}
+	}
+
+	return nil
+}
+
+// serverMechanisms returns the authenticationMechanisms server parameter.
+func (c *Client) serverMechanisms(ctx context.Context) ([]string, error) {
+	parameter, err := c.GetParameter(ctx, authenticationMechanismsParameter)
+	if err != nil {
+		return nil, err
+	}
+
+	values, ok := parameter.Value.ArrayOK()
+	if !ok {
+		return nil, fmt.Errorf("parameter %s has the unexpected type %s", parameter.Name, parameter.Value.Type)
+	}
+
+	elements, err := values.Values()
+	if err != nil {
+		return nil, err
+	}
+
+	mechanisms := make([]string, 0, len(elements))
+
+	for _, element := range elements {
+		if mechanism, ok := element.StringValueOK(); ok {
+			mechanisms = append(mechanisms, mechanism)
+		}
+	}
+
+	return mechanisms, nil
+}
+
+// digestPassword returns the SCRAM-SHA-1 password digest computed by the drivers and the server.
+func digestPassword(username, password string) string {
+	//nolint:gosec // MD5 is mandated by the SCRAM-SHA-1 credentials format
+	digest := md5.Sum([]byte(username + ":mongo:" + password))
+
+	return hex.EncodeToString(digest[:])
+}
+
 type GetUserOptions struct {
 	Username string
 	Database string
@@ -86,7 +261,10 @@ type getUsersResult struct {
 	Users []User `bson:"users"`
 }
 
-func (c *Client) GetUser(ctx context.Context, options *GetUserOptions) (*User, error) {
+func (c *Client) GetUser(ctx context.Context, options *GetUserOptions) (_ *User, err error) {
+	ctx, end := c.startOperation(ctx, "GetUser")
+	defer end(&err)
+
 	tflog.Debug(ctx, "GetUser", map[string]interface{}{
 		"username": options.Username,
 		"db":       options.Database,
@@ -94,16 +272,17 @@ func (c *Client) GetUser(ctx context.Context, options *GetUserOptions) (*User, e
 
 	command := bson.D{
 		{Key: getUserCmd, Value: options.Username},
+		{Key: "showAuthenticationRestrictions", Value: true},
 	}
 
-	response := c.mongo.Database(options.Database).RunCommand(ctx, command)
+	response := c.runCommand(ctx, options.Database, command, primaryRunCmdOptions)
 	if err := response.Err(); err != nil {
-		return nil, err
+		return nil, wrapCommandError(getUserCmd, 0, err)
 	}
 
 	var result getUsersResult
 
-	err := response.Decode(&result)
+	err = response.Decode(&result)
 	if err != nil {
 		return nil, err
 	}
@@ -116,34 +295,137 @@ func (c *Client) GetUser(ctx context.Context, options *GetUserOptions) (*User, e
 
 	switch {
 	case userCount == 0:
-		return nil, NotFoundError{}
+		return nil, NotFoundError{options.Username, "user"}
 	case userCount > 1:
 		return nil, TooManyError{t: "user"}
 	}
 
+	result.Users[0].readDisabled()
+
 	return &result.Users[0], nil
 }
 
+type ListUsersOptions struct {
+	Database string
+}
+
+// ListUsers returns all users defined in the database.
+func (c *Client) ListUsers(ctx context.Context, options *ListUsersOptions) (_ []User, err error) {
+	ctx, end := c.startOperation(ctx, "ListUsers")
+	defer end(&err)
+
+	tflog.Debug(ctx, "ListUsers", map[string]interface{}{
+		"db": options.Database,
+	})
+
+	command := bson.D{
+		{Key: getUserCmd, Value: 1},
+		{Key: "showAuthenticationRestrictions", Value: true},
+	}
+
+	response := c.runCommand(ctx, options.Database, command, primaryRunCmdOptions)
+	if err := response.Err(); err != nil {
+		return nil, wrapCommandError(getUserCmd, 0, err)
+	}
+
+	var result getUsersResult
+
+	err = response.Decode(&result)
+	if err != nil {
+		return nil, err
+	}
+
+	if result.Ok != 1 {
+		return nil, FailedCommandError{getUserCmd}
+	}
+
+	for i := range result.Users {
+		result.Users[i].readDisabled()
+	}
+
+	return result.Users, nil
+}
+
 type DeleteUserOptions struct {
 	Username string
 	Database string
 }
 
-func (c *Client) DeleteUser(ctx context.Context, options *DeleteUserOptions) error {
+func (c *Client) DeleteUser(ctx context.Context, options *DeleteUserOptions) (err error) {
+	ctx, end := c.startOperation(ctx, "DeleteUser")
+	defer end(&err)
+
 	tflog.Debug(ctx, "DeleteUser", map[string]interface{}{
 		"username": options.Username,
 		"db":       options.Database,
 	})
 
-	command := bson.D{
+	command := c.withWriteOptions(bson.D{
 		{Key: deleteUserCmd, Value: options.Username},
-	}
+	})
 
-	response := c.mongo.Database(options.Database).RunCommand(ctx, command)
+	response := c.runCommand(ctx, options.Database, command)
 	if err := response.Err(); err != nil {
+		return wrapCommandError(deleteUserCmd, 0, err)
+	}
+
+	result := Result{}
+
+	err = response.Decode(&result)
+	if err != nil {
 		return err
 	}
 
+	if result.Ok != 1 {
+		return FailedCommandError{deleteUserCmd}
+	}
+
+	return nil
+}
+
+type UserRolesOptions struct {
+	Username string
+	Database string
+	Roles    ShortRoles
+}
+
+// GrantRolesToUser adds the roles to the user, keeping the roles already granted.
+func (c *Client) GrantRolesToUser(ctx context.Context, options *UserRolesOptions) (err error) {
+	ctx, end := c.startOperation(ctx, "GrantRolesToUser")
+	defer end(&err)
+
+	return c.changeUserRoles(ctx, grantRolesToUserCmd, options)
+}
+
+// RevokeRolesFromUser removes the roles from the user. Roles which are not granted are ignored.
+func (c *Client) RevokeRolesFromUser(ctx context.Context, options *UserRolesOptions) (err error) {
+	ctx, end := c.startOperation(ctx, "RevokeRolesFromUser")
+	defer end(&err)
+
+	return c.changeUserRoles(ctx, revokeRolesFromUserCmd, options)
+}
+
+func (c *Client) changeUserRoles(ctx context.Context, cmd string, options *UserRolesOptions) error {
+	tflog.Debug(ctx, cmd, map[string]interface{}{
+		"username": options.Username,
+		"db":       options.Database,
+		"roles":    options.Roles,
+	})
+
+	if len(options.Roles) == 0 {
+		return nil
+	}
+
+	command := c.withWriteOptions(bson.D{
+		{Key: cmd, Value: options.Username},
+		{Key: "roles", Value: options.Roles.toBson(options.Database)},
+	})
+
+	response := c.runCommand(ctx, options.Database, command)
+	if err := response.Err(); err != nil {
+		return wrapCommandError(cmd, 0, err)
+	}
+
 	result := Result{}
 
 	err := response.Decode(&result)
@@ -152,7 +434,7 @@ func (c *Client) DeleteUser(ctx context.Context, options *DeleteUserOptions) err
 	}
 
 	if result.Ok != 1 {
-		return FailedCommandError{deleteUserCmd}
+		return FailedCommandError{cmd}
 	}
 
 	return nil
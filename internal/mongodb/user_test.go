@@ -0,0 +1,154 @@
+package mongodb
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+func TestUserMechanisms(t *testing.T) {
+	t.Parallel()
+
+	scramSHA256 := []string{"SCRAM-SHA-256"}
+	both := []string{"SCRAM-SHA-1", "SCRAM-SHA-256"}
+
+	tests := map[string]struct {
+		serverType string
+		user       User
+		current    *User
+		expected   []string
+	}{
+		"configured on create": {
+			user:     User{Password: "secret", Mechanisms: scramSHA256},
+			expected: scramSHA256,
+		},
+		"configured on update": {
+			user:     User{Mechanisms: scramSHA256},
+			current:  &User{Mechanisms: both},
+			expected: scramSHA256,
+		},
+		"current kept on update": {
+			user:     User{},
+			current:  &User{Mechanisms: scramSHA256},
+			expected: scramSHA256,
+		},
+		"current kept with a new password": {
+			user:     User{Password: "secret"},
+			current:  &User{Mechanisms: both},
+			expected: both,
+		},
+		"external user": {
+			user:    User{Database: "$external"},
+			current: &User{Database: "$external", Mechanisms: []string{"external"}},
+		},
+		"created without password": {
+			user: User{Database: "$external"},
+		},
+		"server default on DocumentDB": {
+			serverType: ServerTypeDocumentDB,
+			user:       User{Password: "secret"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			c := &Client{ClientOptions: ClientOptions{ServerType: test.serverType}}
+
+			mechanisms := c.userMechanisms(context.Background(), &test.user, test.current)
+			if !slices.Equal(mechanisms, test.expected) {
+				t.Errorf("expected mechanisms %q, got %q", test.expected, mechanisms)
+			}
+		})
+	}
+}
+
+func TestUserCommandMechanisms(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		cmd        string
+		user       User
+		mechanisms []string
+		expected   bson.D
+	}{
+		"create": {
+			cmd:        createUserCmd,
+			user:       User{Username: "app", Password: "secret"},
+			mechanisms: []string{"SCRAM-SHA-256"},
+			expected: bson.D{
+				{Key: createUserCmd, Value: "app"},
+				{Key: "roles", Value: bson.A{}},
+				{Key: "pwd", Value: "secret"},
+				{Key: "mechanisms", Value: []string{"SCRAM-SHA-256"}},
+			},
+		},
+		"mechanisms change": {
+			cmd:        updateUserCmr,
+			user:       User{Username: "app", Password: "secret"},
+			mechanisms: []string{"SCRAM-SHA-1", "SCRAM-SHA-256"},
+			expected: bson.D{
+				{Key: updateUserCmr, Value: "app"},
+				{Key: "roles", Value: bson.A{}},
+				{Key: "pwd", Value: "secret"},
+				{Key: "mechanisms", Value: []string{"SCRAM-SHA-1", "SCRAM-SHA-256"}},
+			},
+		},
+		"mechanism removed without password": {
+			cmd:        updateUserCmr,
+			user:       User{Username: "app"},
+			mechanisms: []string{"SCRAM-SHA-256"},
+			expected: bson.D{
+				{Key: updateUserCmr, Value: "app"},
+				{Key: "roles", Value: bson.A{}},
+				{Key: "mechanisms", Value: []string{"SCRAM-SHA-256"}},
+			},
+		},
+		"client digest": {
+			cmd:        updateUserCmr,
+			user:       User{Username: "app", Password: "secret", PasswordDigestor: PasswordDigestorClient},
+			mechanisms: []string{"SCRAM-SHA-1"},
+			expected: bson.D{
+				{Key: updateUserCmr, Value: "app"},
+				{Key: "roles", Value: bson.A{}},
+				{Key: "pwd", Value: digestPassword("app", "secret")},
+				{Key: "digestPassword", Value: false},
+				{Key: "mechanisms", Value: []string{"SCRAM-SHA-1"}},
+			},
+		},
+		"server default": {
+			cmd:  createUserCmd,
+			user: User{Username: "app", Password: "secret"},
+			expected: bson.D{
+				{Key: createUserCmd, Value: "app"},
+				{Key: "roles", Value: bson.A{}},
+				{Key: "pwd", Value: "secret"},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			command := userCommand(test.cmd, &test.user, nil, test.mechanisms)
+
+			expected, err := bson.MarshalExtJSON(test.expected, true, false)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			actual, err := bson.MarshalExtJSON(command, true, false)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if string(actual) != string(expected) {
+				t.Errorf("expected command %s, got %s", expected, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/megum1n/terraform-provider-mongodb/internal/mongodb"
+)
+
+var _ validator.String = knownActionValidator{}
+
+// knownActionValidator checks the privilege action against the documented MongoDB actions,
+// unless the allow_unknown_actions attribute of the resource is set.
+type knownActionValidator struct{}
+
+func knownAction() validator.String {
+	return knownActionValidator{}
+}
+
+func (v knownActionValidator) Description(_ context.Context) string {
+	return "value must be a known MongoDB privilege action"
+}
+
+func (v knownActionValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v knownActionValidator) ValidateString(
+	ctx context.Context,
+	req validator.StringRequest,
+	resp *validator.StringResponse,
+) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, ok := mongodb.GetActionScope(req.ConfigValue.ValueString()); ok {
+		return
+	}
+
+	var allowUnknown types.Bool
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("allow_unknown_actions"), &allowUnknown)...)
+	if resp.Diagnostics.HasError() || allowUnknown.IsUnknown() || allowUnknown.ValueBool() {
+		return
+	}
+
+	resp.Diagnostics.AddAttributeError(
+		req.Path,
+		"Unknown privilege action",
+		fmt.Sprintf("%q is not a known MongoDB privilege action. "+
+			"Set allow_unknown_actions = true for the actions added in newer MongoDB versions.",
+			req.ConfigValue.ValueString()),
+	)
+}
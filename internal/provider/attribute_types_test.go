@@ -0,0 +1,172 @@
+package provider
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/megum1n/terraform-provider-mongodb/internal/mongodb"
+)
+
+// typedAttribute is implemented by the attributes of the resource and the data source schemas.
+type typedAttribute interface {
+	GetType() attr.Type
+}
+
+// objectAttributeTypes returns the attribute types of the object held by a single, list or set nested attribute.
+func objectAttributeTypes(t *testing.T, attribute typedAttribute) map[string]attr.Type {
+	t.Helper()
+
+	attributeType := attribute.GetType()
+	if withElements, ok := attributeType.(attr.TypeWithElementType); ok {
+		attributeType = withElements.ElementType()
+	}
+
+	object, ok := attributeType.(types.ObjectType)
+	if !ok {
+		t.Fatalf("expected an object attribute, got %s", attributeType)
+	}
+
+	return object.AttrTypes
+}
+
+func resourceAttribute(t *testing.T, r resource.Resource, name string) typedAttribute {
+	t.Helper()
+
+	resp := &resource.SchemaResponse{}
+	r.Schema(context.Background(), resource.SchemaRequest{}, resp)
+
+	attribute, ok := resp.Schema.Attributes[name]
+	if !ok {
+		t.Fatalf("attribute %s not found in the schema", name)
+	}
+
+	return attribute
+}
+
+func dataSourceAttribute(t *testing.T, d datasource.DataSource, name string) typedAttribute {
+	t.Helper()
+
+	resp := &datasource.SchemaResponse{}
+	d.Schema(context.Background(), datasource.SchemaRequest{}, resp)
+
+	attribute, ok := resp.Schema.Attributes[name]
+	if !ok {
+		t.Fatalf("attribute %s not found in the schema", name)
+	}
+
+	return attribute
+}
+
+// TestAttributeTypesMatchSchema checks that the attribute maps used to build the state values
+// describe the same objects as the schema, a mismatch fails the state conversion at runtime.
+func TestAttributeTypesMatchSchema(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		attribute func(t *testing.T) typedAttribute
+		expected  map[string]attr.Type
+	}{
+		"index keys": {
+			attribute: func(t *testing.T) typedAttribute { return resourceAttribute(t, NewIndexResource(), "keys") },
+			expected:  IndexKeyModel{}.AttributeTypes(),
+		},
+		"index collation": {
+			attribute: func(t *testing.T) typedAttribute { return resourceAttribute(t, NewIndexResource(), "collation") },
+			expected:  CollationModel{}.AttributeTypes(),
+		},
+		"index data source collation": {
+			attribute: func(t *testing.T) typedAttribute {
+				return dataSourceAttribute(t, NewIndexDataSource(), "collation")
+			},
+			expected: CollationModel{}.AttributeTypes(),
+		},
+		"collection collation": {
+			attribute: func(t *testing.T) typedAttribute {
+				return resourceAttribute(t, NewCollectionResource(), "collation")
+			},
+			expected: CollationModel{}.AttributeTypes(),
+		},
+		"collection timeseries": {
+			attribute: func(t *testing.T) typedAttribute {
+				return resourceAttribute(t, NewCollectionResource(), "timeseries")
+			},
+			expected: TimeSeriesModel{}.AttributeTypes(),
+		},
+		"default read concern": {
+			attribute: func(t *testing.T) typedAttribute {
+				return resourceAttribute(t, NewDefaultRWConcernResource(), "default_read_concern")
+			},
+			expected: ReadConcernModel{}.AttributeTypes(),
+		},
+		"default write concern": {
+			attribute: func(t *testing.T) typedAttribute {
+				return resourceAttribute(t, NewDefaultRWConcernResource(), "default_write_concern")
+			},
+			expected: WriteConcernModel{}.AttributeTypes(),
+		},
+		"user roles": {
+			attribute: func(t *testing.T) typedAttribute { return resourceAttribute(t, NewUserResource(), "roles") },
+			expected:  mongodb.ShortRoleAttributeTypes,
+		},
+		"user authentication restrictions": {
+			attribute: func(t *testing.T) typedAttribute {
+				return resourceAttribute(t, NewUserResource(), "authentication_restrictions")
+			},
+			expected: mongodb.AuthenticationRestrictionAttributeTypes,
+		},
+		"user roles resource": {
+			attribute: func(t *testing.T) typedAttribute { return resourceAttribute(t, NewUserRolesResource(), "roles") },
+			expected:  mongodb.ShortRoleAttributeTypes,
+		},
+		"user data source roles": {
+			attribute: func(t *testing.T) typedAttribute { return dataSourceAttribute(t, NewUserDataSource(), "roles") },
+			expected:  mongodb.ShortRoleAttributeTypes,
+		},
+		"role roles": {
+			attribute: func(t *testing.T) typedAttribute { return resourceAttribute(t, NewRoleResource(), "roles") },
+			expected:  mongodb.ShortRoleAttributeTypes,
+		},
+		"role privileges": {
+			attribute: func(t *testing.T) typedAttribute { return resourceAttribute(t, NewRoleResource(), "privileges") },
+			expected:  mongodb.PrivilegeAttributeTypes,
+		},
+		"role authentication restrictions": {
+			attribute: func(t *testing.T) typedAttribute {
+				return resourceAttribute(t, NewRoleResource(), "authentication_restrictions")
+			},
+			expected: mongodb.AuthenticationRestrictionAttributeTypes,
+		},
+		"role privileges resource": {
+			attribute: func(t *testing.T) typedAttribute {
+				return resourceAttribute(t, NewRolePrivilegesResource(), "privileges")
+			},
+			expected: mongodb.PrivilegeAttributeTypes,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			actual := objectAttributeTypes(t, test.attribute(t))
+
+			if !slices.Equal(slices.Sorted(maps.Keys(actual)), slices.Sorted(maps.Keys(test.expected))) {
+				t.Fatalf("expected the attributes %v, got %v",
+					slices.Sorted(maps.Keys(test.expected)), slices.Sorted(maps.Keys(actual)))
+			}
+
+			for attribute, attributeType := range test.expected {
+				if !attributeType.Equal(actual[attribute]) {
+					t.Errorf("attribute %s: expected type %s, got %s", attribute, attributeType, actual[attribute])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,361 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+
+	"github.com/megum1n/terraform-provider-mongodb/internal/mongodb"
+)
+
+// CollationModel is the collation of the index and collection resources. It's created with the collection
+// or the index and read back from listCollections and listIndexes.
+type CollationModel struct {
+	Locale          types.String `tfsdk:"locale"`
+	CaseLevel       types.Bool   `tfsdk:"case_level"`
+	CaseFirst       types.String `tfsdk:"case_first"`
+	Strength        types.Int64  `tfsdk:"strength"`
+	NumericOrdering types.Bool   `tfsdk:"numeric_ordering"`
+	Alternate       types.String `tfsdk:"alternate"`
+	MaxVariable     types.String `tfsdk:"max_variable"`
+	Backwards       types.Bool   `tfsdk:"backwards"`
+	Version         types.String `tfsdk:"version"`
+}
+
+func (c CollationModel) AttributeTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"locale":           types.StringType,
+		"case_level":       types.BoolType,
+		"case_first":       types.StringType,
+		"strength":         types.Int64Type,
+		"numeric_ordering": types.BoolType,
+		"alternate":        types.StringType,
+		"max_variable":     types.StringType,
+		"backwards":        types.BoolType,
+		"version":          types.StringType,
+	}
+}
+
+// collationAttribute returns the schema of the collation block. The collation can't be changed in place:
+// adding or removing it and changing any configurable field requires a replacement.
+// The fields left unset get the defaults of the locale from the server, e.g. backwards for fr_CA,
+// and keep them from the state, so they don't cause a diff.
+func collationAttribute() schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		Description: "Collation settings for string comparison. The fields left unset use the defaults of the locale",
+		Optional:    true,
+		Computed:    true,
+		Default:     objectdefault.StaticValue(types.ObjectNull(CollationModel{}.AttributeTypes())),
+		PlanModifiers: []planmodifier.Object{
+			// The whole object is not compared, as the computed version is unknown until it is copied from the state
+			objectplanmodifier.RequiresReplaceIf(
+				func(_ context.Context, req planmodifier.ObjectRequest, resp *objectplanmodifier.RequiresReplaceIfFuncResponse) {
+					resp.RequiresReplace = req.StateValue.IsNull() != req.PlanValue.IsNull()
+				},
+				"Adding or removing the collation requires replacement",
+				"Adding or removing the collation requires replacement",
+			),
+		},
+		Attributes: map[string]schema.Attribute{
+			"locale": schema.StringAttribute{
+				Description: "The locale for string comparison. The simple locale compares the strings " +
+					"as binary data and can't be combined with the other fields",
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"case_level": schema.BoolAttribute{
+				Description: "Whether to consider case in the 'Level=1' comparison",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.Bool{
+					collationDefaultFromState{},
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"case_first": schema.StringAttribute{
+				Description: "Whether uppercase or lowercase should sort first",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					collationDefaultFromState{},
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf("upper", "lower", "off"),
+				},
+			},
+			"strength": schema.Int64Attribute{
+				Description: "Comparison level (1-5)",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.Int64{
+					collationDefaultFromState{},
+					int64planmodifier.RequiresReplace(),
+				},
+				Validators: []validator.Int64{
+					int64validator.Between(1, 5),
+				},
+			},
+			"numeric_ordering": schema.BoolAttribute{
+				Description: "Whether to compare numeric strings as numbers",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.Bool{
+					collationDefaultFromState{},
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"alternate": schema.StringAttribute{
+				Description: "Whether spaces and punctuation are considered base characters",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					collationDefaultFromState{},
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf("non-ignorable", "shifted"),
+				},
+			},
+			"max_variable": schema.StringAttribute{
+				Description: "Which characters are affected by 'alternate'",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					collationDefaultFromState{},
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf("punct", "space"),
+				},
+			},
+			"backwards": schema.BoolAttribute{
+				Description: "Whether to reverse secondary differences",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.Bool{
+					collationDefaultFromState{},
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"version": schema.StringAttribute{
+				Description: "Version of the collation rules, set by the server",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					collationDefaultFromState{},
+				},
+			},
+		},
+	}
+}
+
+var (
+	_ planmodifier.Bool   = collationDefaultFromState{}
+	_ planmodifier.String = collationDefaultFromState{}
+	_ planmodifier.Int64  = collationDefaultFromState{}
+)
+
+// collationDefaultFromState plans the unset collation fields with the locale defaults read back from the
+// server, as UseStateForUnknown does, unless the locale changes, which brings other defaults.
+type collationDefaultFromState struct{}
+
+func (m collationDefaultFromState) Description(_ context.Context) string {
+	return "Uses the server default of the collation locale in the state while the locale is unchanged"
+}
+
+func (m collationDefaultFromState) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+// useState reports whether the planned unknown value is replaced by the value in the state.
+func (m collationDefaultFromState) useState(
+	ctx context.Context,
+	attributePath path.Path,
+	plan tfsdk.Plan,
+	state tfsdk.State,
+	stateValue, planValue, configValue attr.Value,
+) (bool, diag.Diagnostics) {
+	if stateValue.IsNull() || !planValue.IsUnknown() || configValue.IsUnknown() {
+		return false, nil
+	}
+
+	var planLocale, stateLocale types.String
+
+	localePath := attributePath.ParentPath().AtName("locale")
+
+	diags := plan.GetAttribute(ctx, localePath, &planLocale)
+	diags.Append(state.GetAttribute(ctx, localePath, &stateLocale)...)
+
+	return !diags.HasError() && planLocale.Equal(stateLocale), diags
+}
+
+func (m collationDefaultFromState) PlanModifyBool(
+	ctx context.Context,
+	req planmodifier.BoolRequest,
+	resp *planmodifier.BoolResponse,
+) {
+	useState, diags := m.useState(ctx, req.Path, req.Plan, req.State, req.StateValue, req.PlanValue, req.ConfigValue)
+	resp.Diagnostics.Append(diags...)
+
+	if useState {
+		resp.PlanValue = req.StateValue
+	}
+}
+
+func (m collationDefaultFromState) PlanModifyString(
+	ctx context.Context,
+	req planmodifier.StringRequest,
+	resp *planmodifier.StringResponse,
+) {
+	useState, diags := m.useState(ctx, req.Path, req.Plan, req.State, req.StateValue, req.PlanValue, req.ConfigValue)
+	resp.Diagnostics.Append(diags...)
+
+	if useState {
+		resp.PlanValue = req.StateValue
+	}
+}
+
+func (m collationDefaultFromState) PlanModifyInt64(
+	ctx context.Context,
+	req planmodifier.Int64Request,
+	resp *planmodifier.Int64Response,
+) {
+	useState, diags := m.useState(ctx, req.Path, req.Plan, req.State, req.StateValue, req.PlanValue, req.ConfigValue)
+	resp.Diagnostics.Append(diags...)
+
+	if useState {
+		resp.PlanValue = req.StateValue
+	}
+}
+
+// simpleCollationLocale is the binary comparison of the strings. The server doesn't store it,
+// so a collection or an index with the simple locale is read back without a collation.
+const simpleCollationLocale = "simple"
+
+func isSimpleCollation(object types.Object) bool {
+	if object.IsNull() || object.IsUnknown() {
+		return false
+	}
+
+	locale, ok := object.Attributes()["locale"].(types.String)
+
+	return ok && locale.ValueString() == simpleCollationLocale
+}
+
+// validateCollation checks that no other field is set with the simple locale, which MongoDB rejects.
+func validateCollation(config types.Object, attribute path.Path, diags *diag.Diagnostics) {
+	if !isSimpleCollation(config) {
+		return
+	}
+
+	attributes := config.Attributes()
+
+	for _, name := range slices.Sorted(maps.Keys(attributes)) {
+		if name == "locale" || attributes[name].IsNull() {
+			continue
+		}
+
+		diags.AddAttributeError(
+			attribute.AtName(name),
+			"Invalid collation configuration",
+			fmt.Sprintf("%s can't be set with the %q locale, which compares the strings as binary data",
+				name, simpleCollationLocale),
+		)
+	}
+}
+
+// collationObjectValue converts the collation returned by the server to the Terraform object.
+// The current simple collation is kept when the server returns none.
+func collationObjectValue(
+	ctx context.Context,
+	current types.Object,
+	collation *mongodb.Collation,
+) (types.Object, diag.Diagnostics) {
+	if collation == nil {
+		if !isSimpleCollation(current) {
+			return types.ObjectNull(CollationModel{}.AttributeTypes()), nil
+		}
+
+		// The other fields are read back with the defaults of the other locales
+		collation = &mongodb.Collation{
+			Collation: options.Collation{
+				Locale:      simpleCollationLocale,
+				CaseFirst:   "off",
+				Strength:    3,
+				Alternate:   "non-ignorable",
+				MaxVariable: "punct",
+			},
+		}
+	}
+
+	model := CollationModel{
+		Locale:          types.StringValue(collation.Locale),
+		CaseLevel:       types.BoolValue(collation.CaseLevel),
+		CaseFirst:       types.StringValue(collation.CaseFirst),
+		Strength:        types.Int64Value(int64(collation.Strength)),
+		NumericOrdering: types.BoolValue(collation.NumericOrdering),
+		Alternate:       types.StringValue(collation.Alternate),
+		MaxVariable:     types.StringValue(collation.MaxVariable),
+		Backwards:       types.BoolValue(collation.Backwards),
+		Version:         types.StringValue(collation.Version),
+	}
+
+	if collation.Version == "" {
+		model.Version = types.StringNull()
+	}
+
+	return types.ObjectValueFrom(ctx, model.AttributeTypes(), model)
+}
+
+// collationOptions converts the configured collation object, returning nil when it is not set.
+func collationOptions(ctx context.Context, object types.Object) (*mongodb.Collation, diag.Diagnostics) {
+	if object.IsNull() || object.IsUnknown() {
+		return nil, nil
+	}
+
+	collation := &CollationModel{}
+
+	diags := object.As(ctx, collation, basetypes.ObjectAsOptions{})
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	// The defaults of the other fields are not sent with the simple locale
+	if collation.Locale.ValueString() == simpleCollationLocale {
+		return &mongodb.Collation{Collation: options.Collation{Locale: simpleCollationLocale}}, diags
+	}
+
+	return &mongodb.Collation{
+		Collation: options.Collation{
+			Locale:          collation.Locale.ValueString(),
+			CaseLevel:       collation.CaseLevel.ValueBool(),
+			CaseFirst:       collation.CaseFirst.ValueString(),
+			Strength:        int(collation.Strength.ValueInt64()),
+			NumericOrdering: collation.NumericOrdering.ValueBool(),
+			Alternate:       collation.Alternate.ValueString(),
+			MaxVariable:     collation.MaxVariable.ValueString(),
+			Backwards:       collation.Backwards.ValueBool(),
+		},
+	}, diags
+}
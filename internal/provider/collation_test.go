@@ -0,0 +1,202 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type collationTestModel struct {
+	Collation types.Object `tfsdk:"collation"`
+}
+
+// collationTestObject returns a collation with the given values, the other fields are null.
+func collationTestObject(t *testing.T, values map[string]attr.Value) types.Object {
+	t.Helper()
+
+	attributeTypes := CollationModel{}.AttributeTypes()
+	attributes := map[string]attr.Value{}
+
+	for name, attributeType := range attributeTypes {
+		attributes[name] = types.StringNull()
+
+		switch attributeType {
+		case types.BoolType:
+			attributes[name] = types.BoolNull()
+		case types.Int64Type:
+			attributes[name] = types.Int64Null()
+		}
+	}
+
+	for name, value := range values {
+		attributes[name] = value
+	}
+
+	object, diags := types.ObjectValue(attributeTypes, attributes)
+	if diags.HasError() {
+		t.Fatalf("invalid collation object: %v", diags)
+	}
+
+	return object
+}
+
+// planBackwards runs the plan modifiers of collation.backwards like the framework does on plan.
+func planBackwards(t *testing.T, config, plan, state types.Object) (types.Bool, bool) {
+	t.Helper()
+
+	ctx := context.Background()
+	collation := collationAttribute()
+	testSchema := schema.Schema{Attributes: map[string]schema.Attribute{"collation": collation}}
+
+	req := planmodifier.BoolRequest{
+		Path:        path.Root("collation").AtName("backwards"),
+		Config:      tfsdk.Config{Schema: testSchema},
+		Plan:        tfsdk.Plan{Schema: testSchema},
+		State:       tfsdk.State{Schema: testSchema},
+		ConfigValue: config.Attributes()["backwards"].(types.Bool),
+		PlanValue:   plan.Attributes()["backwards"].(types.Bool),
+		StateValue:  state.Attributes()["backwards"].(types.Bool),
+	}
+
+	// tfsdk.Config can't be set, it's built from a state holding the configuration
+	configState := tfsdk.State{Schema: testSchema}
+
+	diags := configState.Set(ctx, &collationTestModel{Collation: config})
+	req.Config.Raw = configState.Raw
+
+	diags.Append(req.Plan.Set(ctx, &collationTestModel{Collation: plan})...)
+	diags.Append(req.State.Set(ctx, &collationTestModel{Collation: state})...)
+
+	if diags.HasError() {
+		t.Fatalf("failed to build the request: %v", diags)
+	}
+
+	resp := &planmodifier.BoolResponse{PlanValue: req.PlanValue}
+
+	for _, modifier := range collation.Attributes["backwards"].(schema.BoolAttribute).PlanModifiers {
+		modifier.PlanModifyBool(ctx, req, resp)
+
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("plan modifier failed: %v", resp.Diagnostics)
+		}
+
+		req.PlanValue = resp.PlanValue
+	}
+
+	return resp.PlanValue, resp.RequiresReplace
+}
+
+func TestCollationLocaleDefaultsNoDiff(t *testing.T) {
+	t.Parallel()
+
+	// fr_CA reverses the secondary differences by default, which the server returns as backwards = true
+	state := collationTestObject(t, map[string]attr.Value{
+		"locale":    types.StringValue("fr_CA"),
+		"strength":  types.Int64Value(2),
+		"backwards": types.BoolValue(true),
+	})
+
+	tests := map[string]struct {
+		config, plan    types.Object
+		expected        types.Bool
+		requiresReplace bool
+	}{
+		"unset field keeps the locale default": {
+			config: collationTestObject(t, map[string]attr.Value{
+				"locale":   types.StringValue("fr_CA"),
+				"strength": types.Int64Value(2),
+			}),
+			plan: collationTestObject(t, map[string]attr.Value{
+				"locale":    types.StringValue("fr_CA"),
+				"strength":  types.Int64Value(2),
+				"backwards": types.BoolUnknown(),
+			}),
+			expected: types.BoolValue(true),
+		},
+		"configured field equal to the locale default": {
+			config: collationTestObject(t, map[string]attr.Value{
+				"locale":    types.StringValue("fr_CA"),
+				"backwards": types.BoolValue(true),
+			}),
+			plan: collationTestObject(t, map[string]attr.Value{
+				"locale":    types.StringValue("fr_CA"),
+				"backwards": types.BoolValue(true),
+			}),
+			expected: types.BoolValue(true),
+		},
+		"configured field different from the state": {
+			config: collationTestObject(t, map[string]attr.Value{
+				"locale":    types.StringValue("fr_CA"),
+				"backwards": types.BoolValue(false),
+			}),
+			plan: collationTestObject(t, map[string]attr.Value{
+				"locale":    types.StringValue("fr_CA"),
+				"backwards": types.BoolValue(false),
+			}),
+			expected:        types.BoolValue(false),
+			requiresReplace: true,
+		},
+		"changed locale brings its own defaults": {
+			config: collationTestObject(t, map[string]attr.Value{
+				"locale": types.StringValue("en"),
+			}),
+			plan: collationTestObject(t, map[string]attr.Value{
+				"locale":    types.StringValue("en"),
+				"backwards": types.BoolUnknown(),
+			}),
+			expected:        types.BoolUnknown(),
+			requiresReplace: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			planned, requiresReplace := planBackwards(t, test.config, test.plan, state)
+
+			if !planned.Equal(test.expected) {
+				t.Errorf("expected planned backwards %s, got %s", test.expected, planned)
+			}
+
+			if requiresReplace != test.requiresReplace {
+				t.Errorf("expected requires replace %t, got %t", test.requiresReplace, requiresReplace)
+			}
+		})
+	}
+}
+
+func TestCollationAttributeHasNoStaticDefaults(t *testing.T) {
+	t.Parallel()
+
+	for name, attribute := range collationAttribute().Attributes {
+		if name == "locale" || name == "version" {
+			continue
+		}
+
+		if !attribute.IsOptional() || !attribute.IsComputed() {
+			t.Errorf("%s must be optional and computed to keep the locale default of the server", name)
+		}
+
+		var hasDefault bool
+
+		switch a := attribute.(type) {
+		case schema.BoolAttribute:
+			hasDefault = a.Default != nil
+		case schema.StringAttribute:
+			hasDefault = a.Default != nil
+		case schema.Int64Attribute:
+			hasDefault = a.Default != nil
+		}
+
+		if hasDefault {
+			t.Errorf("%s must not have a static default, which differs from the defaults of some locales", name)
+		}
+	}
+}
@@ -0,0 +1,192 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/megum1n/terraform-provider-mongodb/internal/mongodb"
+)
+
+var _ datasource.DataSource = &CollectionDataSource{}
+var _ datasource.DataSourceWithConfigure = &CollectionDataSource{}
+
+func NewCollectionDataSource() datasource.DataSource {
+	return &CollectionDataSource{}
+}
+
+type CollectionDataSource struct {
+	client *mongodb.Client
+}
+
+type CollectionDataSourceModel struct {
+	ID        types.String `tfsdk:"id"`
+	Database  types.String `tfsdk:"database"`
+	Name      types.String `tfsdk:"name"`
+	Type      types.String `tfsdk:"type"`
+	ReadOnly  types.Bool   `tfsdk:"read_only"`
+	Options   types.String `tfsdk:"options"`
+	Capped    types.Bool   `tfsdk:"capped"`
+	Validator types.String `tfsdk:"validator"`
+	Collation types.Object `tfsdk:"collation"`
+}
+
+func (m *CollectionDataSourceModel) updateState(ctx context.Context, collection *mongodb.Collection) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	m.ID = types.StringValue(collectionID(collection.Database, collection.Name))
+	m.Type = types.StringValue(collection.Type)
+	m.ReadOnly = types.BoolValue(collection.ReadOnly)
+	m.Capped = types.BoolValue(collection.Options.Capped != nil && *collection.Options.Capped)
+
+	options, err := collection.OptionsJSON()
+	if err != nil {
+		diags.AddError("Failed to parse collection options", err.Error())
+
+		return diags
+	}
+
+	m.Options = types.StringValue(options)
+	m.Validator = types.StringNull()
+
+	if len(collection.Options.Validator) > 0 {
+		validator, err := mongodb.DocumentJSON(collection.Options.Validator)
+		if err != nil {
+			diags.AddError("Failed to parse collection validator", err.Error())
+
+			return diags
+		}
+
+		m.Validator = types.StringValue(validator)
+	}
+
+	m.Collation, diags = collationObjectValue(ctx, types.ObjectNull(CollationModel{}.AttributeTypes()),
+		collection.Options.Collation)
+
+	return diags
+}
+
+func (d *CollectionDataSource) Metadata(
+	_ context.Context,
+	req datasource.MetadataRequest,
+	resp *datasource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_collection"
+}
+
+func (d *CollectionDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Reads an existing MongoDB collection or view without managing it",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Collection identifier in the format database.name",
+				Computed:    true,
+			},
+			"database": schema.StringAttribute{
+				Description: "Database name",
+				Required:    true,
+			},
+			"name": schema.StringAttribute{
+				Description: "Collection name",
+				Required:    true,
+			},
+			"type": schema.StringAttribute{
+				Description: "Type of the collection: collection, view or timeseries",
+				Computed:    true,
+			},
+			"read_only": schema.BoolAttribute{
+				Description: "Whether the collection is read only, e.g. a view",
+				Computed:    true,
+			},
+			"options": schema.StringAttribute{
+				Description: "JSON encoded options of the collection as returned by listCollections, " +
+					"e.g. the pipeline of a view",
+				Computed: true,
+			},
+			"capped": schema.BoolAttribute{
+				Description: "Whether the collection is capped",
+				Computed:    true,
+			},
+			"validator": schema.StringAttribute{
+				Description: "JSON encoded document validation query, null when the collection has no validator",
+				Computed:    true,
+			},
+			"collation": collationDataSourceAttribute(),
+		},
+	}
+}
+
+func (d *CollectionDataSource) Configure(
+	_ context.Context,
+	req datasource.ConfigureRequest,
+	resp *datasource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	p, ok := req.ProviderData.(*MongodbProvider)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *MongodbProvider, got: %T. "+
+				"Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = p.client
+}
+
+func (d *CollectionDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if d.client == nil {
+		resp.Diagnostics.AddError(
+			"MongoDB client is not configured",
+			"Expected configured MongoDB client. Please report this issue to the provider developers.",
+		)
+
+		return
+	}
+
+	var config CollectionDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	collection, err := d.client.GetCollection(ctx, &mongodb.GetCollectionOptions{
+		Name:     config.Name.ValueString(),
+		Database: config.Database.ValueString(),
+	})
+	if err != nil {
+		if errors.As(err, &mongodb.NotFoundError{}) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("name"),
+				"Collection not found",
+				fmt.Sprintf("Collection %q does not exist in the %s database", config.Name.ValueString(),
+					config.Database.ValueString()),
+			)
+
+			return
+		}
+
+		resp.Diagnostics.AddError("Failed to read collection", err.Error())
+
+		return
+	}
+
+	resp.Diagnostics.Append(config.updateState(ctx, collection)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
+}
@@ -0,0 +1,747 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"go.mongodb.org/mongo-driver/v2/bson"
+
+	"github.com/megum1n/terraform-provider-mongodb/internal/mongodb"
+)
+
+var (
+	_ resource.Resource                   = &CollectionResource{}
+	_ resource.ResourceWithConfigure      = &CollectionResource{}
+	_ resource.ResourceWithImportState    = &CollectionResource{}
+	_ resource.ResourceWithValidateConfig = &CollectionResource{}
+	_ resource.ResourceWithModifyPlan     = &CollectionResource{}
+)
+
+func NewCollectionResource() resource.Resource {
+	return &CollectionResource{}
+}
+
+type CollectionResource struct {
+	client *mongodb.Client
+}
+
+type CollectionResourceModel struct {
+	ID        types.String `tfsdk:"id"`
+	Database  types.String `tfsdk:"database"`
+	Name      types.String `tfsdk:"name"`
+	Capped    types.Bool   `tfsdk:"capped"`
+	Size      types.Int64  `tfsdk:"size"`
+	Max       types.Int64  `tfsdk:"max"`
+	Collation types.Object `tfsdk:"collation"`
+
+	Validator        types.String `tfsdk:"validator"`
+	ValidationLevel  types.String `tfsdk:"validation_level"`
+	ValidationAction types.String `tfsdk:"validation_action"`
+
+	TimeSeries         types.Object `tfsdk:"timeseries"`
+	ExpireAfterSeconds types.Int64  `tfsdk:"expire_after_seconds"`
+
+	AllowDataLoss types.Bool `tfsdk:"allow_data_loss"`
+}
+
+type TimeSeriesModel struct {
+	TimeField   types.String `tfsdk:"time_field"`
+	MetaField   types.String `tfsdk:"meta_field"`
+	Granularity types.String `tfsdk:"granularity"`
+}
+
+func (t TimeSeriesModel) AttributeTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"time_field":  types.StringType,
+		"meta_field":  types.StringType,
+		"granularity": types.StringType,
+	}
+}
+
+// timeSeriesGranularities are ordered from the finest to the coarsest.
+var timeSeriesGranularities = []string{"seconds", "minutes", "hours"}
+
+// coarserGranularity reports whether the granularity can be changed from one to the other in place.
+func coarserGranularity(from, to string) bool {
+	return slices.Index(timeSeriesGranularities, to) >= slices.Index(timeSeriesGranularities, from)
+}
+
+func timeSeriesObjectValue(ctx context.Context, timeSeries *mongodb.TimeSeriesOptions) (types.Object, diag.Diagnostics) {
+	if timeSeries == nil {
+		return types.ObjectNull(TimeSeriesModel{}.AttributeTypes()), nil
+	}
+
+	model := TimeSeriesModel{
+		TimeField:   types.StringValue(timeSeries.TimeField),
+		MetaField:   types.StringNull(),
+		Granularity: types.StringValue(timeSeries.Granularity),
+	}
+
+	if timeSeries.MetaField != "" {
+		model.MetaField = types.StringValue(timeSeries.MetaField)
+	}
+
+	return types.ObjectValueFrom(ctx, model.AttributeTypes(), model)
+}
+
+func timeSeriesOptions(ctx context.Context, object types.Object) (*mongodb.TimeSeriesOptions, diag.Diagnostics) {
+	if object.IsNull() || object.IsUnknown() {
+		return nil, nil
+	}
+
+	timeSeries := &TimeSeriesModel{}
+
+	diags := object.As(ctx, timeSeries, basetypes.ObjectAsOptions{})
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	options := &mongodb.TimeSeriesOptions{
+		TimeField: timeSeries.TimeField.ValueString(),
+		MetaField: timeSeries.MetaField.ValueString(),
+	}
+
+	// Unknown until the server picks its default
+	if !timeSeries.Granularity.IsUnknown() {
+		options.Granularity = timeSeries.Granularity.ValueString()
+	}
+
+	return options, diags
+}
+
+func (c *CollectionResourceModel) updateState(ctx context.Context, collection *mongodb.Collection) diag.Diagnostics {
+	c.ID = types.StringValue(collectionID(collection.Database, collection.Name))
+	c.Database = types.StringValue(collection.Database)
+	c.Name = types.StringValue(collection.Name)
+
+	// Keep the unset options null instead of false
+	if collection.Options.Capped != nil && *collection.Options.Capped {
+		c.Capped = types.BoolValue(true)
+	} else if !c.Capped.IsNull() {
+		c.Capped = types.BoolValue(false)
+	}
+
+	// The server rounds the size up to a multiple of 256 bytes, the configured size is kept
+	size := collection.Options.Size
+	if size == nil || c.Size.IsNull() || c.Size.IsUnknown() || mongodb.CappedSize(c.Size.ValueInt64()) != *size {
+		c.Size = types.Int64PointerValue(size)
+	}
+
+	c.Max = types.Int64PointerValue(collection.Options.Max)
+
+	collation, diags := collationObjectValue(ctx, c.Collation, collection.Options.Collation)
+	c.Collation = collation
+
+	// Keep the configured JSON when it describes the same validator
+	if len(collection.Options.Validator) == 0 {
+		c.Validator = types.StringNull()
+	} else {
+		validator, err := mongodb.DocumentJSON(collection.Options.Validator)
+		if err != nil {
+			diags.AddError("Failed to parse collection validator", err.Error())
+
+			return diags
+		}
+
+		if !isSameJSON(c.Validator, []byte(validator)) {
+			c.Validator = types.StringValue(validator)
+		}
+	}
+
+	c.ValidationLevel = types.StringPointerValue(collection.Options.ValidationLevel)
+	c.ValidationAction = types.StringPointerValue(collection.Options.ValidationAction)
+
+	timeSeries, d := timeSeriesObjectValue(ctx, collection.Options.TimeSeries)
+	diags.Append(d...)
+
+	c.TimeSeries = timeSeries
+	c.ExpireAfterSeconds = types.Int64PointerValue(collection.Options.ExpireAfterSeconds)
+
+	return diags
+}
+
+// collection builds the client collection from the plan.
+func (c *CollectionResourceModel) collection(ctx context.Context) (*mongodb.Collection, diag.Diagnostics) {
+	collation, diags := collationOptions(ctx, c.Collation)
+
+	collection := &mongodb.Collection{
+		Name:     c.Name.ValueString(),
+		Database: c.Database.ValueString(),
+		Options: mongodb.CollectionOptions{
+			Capped:    c.Capped.ValueBoolPointer(),
+			Size:      c.Size.ValueInt64Pointer(),
+			Max:       c.Max.ValueInt64Pointer(),
+			Collation: collation,
+		},
+	}
+
+	timeSeries, d := timeSeriesOptions(ctx, c.TimeSeries)
+
+	diags.Append(d...)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	collection.Options.TimeSeries = timeSeries
+	collection.Options.ExpireAfterSeconds = c.ExpireAfterSeconds.ValueInt64Pointer()
+
+	if !c.Validator.IsNull() && !c.Validator.IsUnknown() {
+		validator, err := mongodb.ParseDocumentJSON(c.Validator.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("validator"), "Failed to parse collection validator", err.Error())
+
+			return nil, diags
+		}
+
+		collection.Options.Validator = validator
+	}
+
+	if !c.ValidationLevel.IsNull() && !c.ValidationLevel.IsUnknown() {
+		collection.Options.ValidationLevel = c.ValidationLevel.ValueStringPointer()
+	}
+
+	if !c.ValidationAction.IsNull() && !c.ValidationAction.IsUnknown() {
+		collection.Options.ValidationAction = c.ValidationAction.ValueStringPointer()
+	}
+
+	return collection, diags
+}
+
+// collectionID builds the import identifier. Database names can't contain dots, so the collection goes last.
+func collectionID(database, name string) string {
+	return database + "." + name
+}
+
+func (r *CollectionResource) Metadata(
+	_ context.Context,
+	req resource.MetadataRequest,
+	resp *resource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_collection"
+}
+
+func (r *CollectionResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages MongoDB collections",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Import identifier in the format database.collection",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"database": schema.StringAttribute{
+				Description: "Database name",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "Collection name",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"capped": schema.BoolAttribute{
+				Description: "Whether the collection is capped. Requires size. Can't be changed in place",
+				Optional:    true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"size": schema.Int64Attribute{
+				Description: "Maximum size of a capped collection in bytes. The server rounds it up " +
+					"to a multiple of 256. Changed in place with collMod from MongoDB 6.0",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"max": schema.Int64Attribute{
+				Description: "Maximum number of documents in a capped collection. " +
+					"Changed in place with collMod from MongoDB 6.0",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"collation": collationAttribute(),
+			"validator": schema.StringAttribute{
+				Description: "JSON encoded document validation query, " +
+					"e.g. {\"$jsonSchema\": {\"required\": [\"name\"]}}. Changed in place with collMod",
+				Optional: true,
+			},
+			"validation_level": schema.StringAttribute{
+				Description: "How strictly the validator is applied to the existing documents: " +
+					"off, strict or moderate. The server default is strict",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf("off", "strict", "moderate"),
+				},
+			},
+			"validation_action": schema.StringAttribute{
+				Description: "Whether invalid documents are rejected or only logged: error or warn. " +
+					"The server default is error",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf("error", "warn"),
+				},
+			},
+			"timeseries": schema.SingleNestedAttribute{
+				Description: "Creates a time series collection. A collection can't be converted to or from " +
+					"a time series collection in place",
+				Optional: true,
+				PlanModifiers: []planmodifier.Object{
+					objectplanmodifier.RequiresReplaceIf(
+						func(_ context.Context, req planmodifier.ObjectRequest, resp *objectplanmodifier.RequiresReplaceIfFuncResponse) {
+							resp.RequiresReplace = req.StateValue.IsNull() != req.PlanValue.IsNull()
+						},
+						"Adding or removing the time series options requires replacing the collection",
+						"Adding or removing the time series options requires replacing the collection",
+					),
+				},
+				Attributes: map[string]schema.Attribute{
+					"time_field": schema.StringAttribute{
+						Description: "Name of the field holding the date of each document",
+						Required:    true,
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.RequiresReplace(),
+						},
+					},
+					"meta_field": schema.StringAttribute{
+						Description: "Name of the field holding the metadata identifying a series",
+						Optional:    true,
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.RequiresReplace(),
+						},
+					},
+					"granularity": schema.StringAttribute{
+						Description: "Interval between the measurements of a series: seconds, minutes or hours. " +
+							"The server default is seconds. Changed in place with collMod to a coarser granularity " +
+							"only",
+						Optional: true,
+						Computed: true,
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.UseStateForUnknown(),
+							stringplanmodifier.RequiresReplaceIf(
+								func(_ context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+									if req.StateValue.IsNull() || req.PlanValue.IsUnknown() {
+										return
+									}
+
+									resp.RequiresReplace = !coarserGranularity(req.StateValue.ValueString(), req.PlanValue.ValueString())
+								},
+								"Changing to a finer granularity requires replacing the collection",
+								"Changing to a finer granularity requires replacing the collection",
+							),
+						},
+						Validators: []validator.String{
+							stringvalidator.OneOf(timeSeriesGranularities...),
+						},
+					},
+				},
+			},
+			"expire_after_seconds": schema.Int64Attribute{
+				Description: "Removes the documents of a time series collection older than the given number of seconds. " +
+					"Changed in place with collMod",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"allow_data_loss": schema.BoolAttribute{
+				Description: "Allow the changes which replace the collection, e.g. capped or timeseries. " +
+					"The collection is dropped with all its documents and created empty",
+				Optional: true,
+			},
+		},
+	}
+}
+
+// ModifyPlan rejects the replacement of an existing collection, which drops its documents,
+// unless allow_data_loss is set.
+func (r *CollectionResource) ModifyPlan(
+	ctx context.Context,
+	req resource.ModifyPlanRequest,
+	resp *resource.ModifyPlanResponse,
+) {
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() || len(resp.RequiresReplace) == 0 {
+		return
+	}
+
+	var allowDataLoss types.Bool
+
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("allow_data_loss"), &allowDataLoss)...)
+	if resp.Diagnostics.HasError() || allowDataLoss.IsUnknown() {
+		return
+	}
+
+	var attributes []string
+
+	for _, attributePath := range resp.RequiresReplace {
+		attributes = append(attributes, attributePath.String())
+	}
+
+	detail := fmt.Sprintf("Changing %s replaces the collection: it's dropped with all its documents and indexes, "+
+		"and created empty.", strings.Join(attributes, ", "))
+
+	if !allowDataLoss.ValueBool() {
+		resp.Diagnostics.AddError(
+			"Collection replacement drops its data",
+			detail+" Set allow_data_loss = true to confirm the replacement.",
+		)
+
+		return
+	}
+
+	resp.Diagnostics.AddWarning("Collection replacement drops its data", detail)
+}
+
+func (r *CollectionResource) ValidateConfig(
+	ctx context.Context,
+	req resource.ValidateConfigRequest,
+	resp *resource.ValidateConfigResponse,
+) {
+	var config CollectionResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !config.Validator.IsNull() && !config.Validator.IsUnknown() {
+		_, err := mongodb.ParseDocumentJSON(config.Validator.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("validator"), "Failed to parse collection validator", err.Error())
+		}
+	}
+
+	validateCollation(config.Collation, path.Root("collation"), &resp.Diagnostics)
+
+	if !config.ExpireAfterSeconds.IsNull() && config.TimeSeries.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("expire_after_seconds"),
+			"Invalid collection configuration",
+			"expire_after_seconds is only supported for time series collections",
+		)
+	}
+
+	if config.Capped.IsUnknown() {
+		return
+	}
+
+	if config.Capped.ValueBool() && !config.TimeSeries.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("timeseries"),
+			"Invalid collection configuration",
+			"time series collections can't be capped",
+		)
+	}
+
+	if config.Capped.ValueBool() {
+		if config.Size.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("size"),
+				"Missing capped collection size",
+				"size is required for capped collections",
+			)
+		}
+
+		return
+	}
+
+	for attribute, value := range map[string]types.Int64{"size": config.Size, "max": config.Max} {
+		if !value.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root(attribute),
+				"Invalid collection configuration",
+				fmt.Sprintf("%s is only supported for capped collections", attribute),
+			)
+		}
+	}
+}
+
+func (r *CollectionResource) Configure(
+	_ context.Context,
+	req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	p, ok := req.ProviderData.(*MongodbProvider)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *MongodbProvider, got: %T. "+
+				"Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = p.client
+}
+
+func (r *CollectionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if !r.checkClient(&resp.Diagnostics) {
+		return
+	}
+
+	var plan CollectionResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	collection, d := plan.collection(ctx)
+
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	dbCollection, err := r.client.CreateCollection(ctx, collection)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			commandErrorSummary(err, "Error creating MongoDB collection"),
+			err.Error(),
+		)
+
+		return
+	}
+
+	resp.Diagnostics.Append(plan.updateState(ctx, dbCollection)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "Collection created")
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *CollectionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if !r.checkClient(&resp.Diagnostics) {
+		return
+	}
+
+	var state CollectionResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	collection, err := r.client.GetCollection(ctx, &mongodb.GetCollectionOptions{
+		Name:     state.Name.ValueString(),
+		Database: state.Database.ValueString(),
+	})
+	if err != nil {
+		if errors.As(err, &mongodb.NotFoundError{}) {
+			tflog.Debug(ctx, "Collection not found, removing from state")
+			resp.State.RemoveResource(ctx)
+
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			"Error reading MongoDB collection",
+			err.Error(),
+		)
+
+		return
+	}
+
+	resp.Diagnostics.Append(state.updateState(ctx, collection)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *CollectionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if !r.checkClient(&resp.Diagnostics) {
+		return
+	}
+
+	var plan, state CollectionResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	collection, d := plan.collection(ctx)
+
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// An empty validator removes the document validation
+	if plan.Validator.IsNull() && !state.Validator.IsNull() {
+		collection.Options.Validator = bson.D{}
+	}
+
+	if plan.ExpireAfterSeconds.IsNull() && !state.ExpireAfterSeconds.IsNull() {
+		collection.Options.RemoveExpiration = true
+	}
+
+	// The capped collection limits are only sent when they change, collMod accepts them from MongoDB 6.0
+	if plan.Size.Equal(state.Size) {
+		collection.Options.Size = nil
+	}
+
+	if plan.Max.Equal(state.Max) {
+		collection.Options.Max = nil
+	}
+
+	// The time series granularity is only sent when it changes
+	if collection.Options.TimeSeries != nil {
+		current, d := timeSeriesOptions(ctx, state.TimeSeries)
+
+		resp.Diagnostics.Append(d...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		if current != nil && current.Granularity == collection.Options.TimeSeries.Granularity {
+			collection.Options.TimeSeries = nil
+		}
+	}
+
+	dbCollection, err := r.client.UpdateCollection(ctx, collection)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			commandErrorSummary(err, "Error updating MongoDB collection"),
+			err.Error(),
+		)
+
+		return
+	}
+
+	resp.Diagnostics.Append(plan.updateState(ctx, dbCollection)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "Collection updated")
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *CollectionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if !r.checkClient(&resp.Diagnostics) {
+		return
+	}
+
+	var state CollectionResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteCollection(ctx, &mongodb.GetCollectionOptions{
+		Name:     state.Name.ValueString(),
+		Database: state.Database.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			commandErrorSummary(err, "Error deleting MongoDB collection"),
+			err.Error(),
+		)
+
+		return
+	}
+
+	tflog.Trace(ctx, "Collection deleted")
+}
+
+func (r *CollectionResource) ImportState(
+	ctx context.Context,
+	req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse,
+) {
+	if !r.checkClient(&resp.Diagnostics) {
+		return
+	}
+
+	database, name, ok := strings.Cut(req.ID, ".")
+	if !ok || database == "" || name == "" {
+		resp.Diagnostics.AddError(
+			"Invalid import ID",
+			"Import ID should be in the format: database.collection",
+		)
+
+		return
+	}
+
+	collection, err := r.client.GetCollection(ctx, &mongodb.GetCollectionOptions{
+		Name:     name,
+		Database: database,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error importing collection",
+			fmt.Sprintf("Failed to read collection %s: %s", req.ID, err),
+		)
+
+		return
+	}
+
+	var state CollectionResourceModel
+
+	resp.Diagnostics.Append(state.updateState(ctx, collection)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *CollectionResource) checkClient(diags *diag.Diagnostics) bool {
+	if r.client == nil {
+		diags.AddError(
+			"MongoDB client is not configured",
+			"Expected configured MongoDB client. Please report this issue to the provider developers.",
+		)
+
+		return false
+	}
+
+	return true
+}
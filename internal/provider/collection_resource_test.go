@@ -0,0 +1,54 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/megum1n/terraform-provider-mongodb/internal/mongodb"
+)
+
+func TestCollectionUpdateStateKeepsCappedSize(t *testing.T) {
+	t.Parallel()
+
+	size := func(value int64) *int64 {
+		return &value
+	}
+
+	tests := map[string]struct {
+		planned  types.Int64
+		server   *int64
+		expected types.Int64
+	}{
+		"rounded up":           {types.Int64Value(1000), size(1024), types.Int64Value(1000)},
+		"multiple of 256":      {types.Int64Value(1024), size(1024), types.Int64Value(1024)},
+		"changed on server":    {types.Int64Value(1000), size(2048), types.Int64Value(2048)},
+		"not configured":       {types.Int64Null(), size(1024), types.Int64Value(1024)},
+		"not capped":           {types.Int64Null(), nil, types.Int64Null()},
+		"removed on server":    {types.Int64Value(1000), nil, types.Int64Null()},
+		"unknown on import":    {types.Int64Unknown(), size(1024), types.Int64Value(1024)},
+		"not the rounded size": {types.Int64Value(1025), size(1024), types.Int64Value(1024)},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			model := CollectionResourceModel{Size: test.planned}
+
+			diags := model.updateState(context.Background(), &mongodb.Collection{
+				Name:     "events",
+				Database: "app",
+				Options:  mongodb.CollectionOptions{Capped: new(bool), Size: test.server},
+			})
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+
+			if !model.Size.Equal(test.expected) {
+				t.Errorf("expected size %s, got %s", test.expected, model.Size)
+			}
+		})
+	}
+}
@@ -0,0 +1,140 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/megum1n/terraform-provider-mongodb/internal/mongodb"
+)
+
+var _ datasource.DataSource = &CollectionsDataSource{}
+var _ datasource.DataSourceWithConfigure = &CollectionsDataSource{}
+
+func NewCollectionsDataSource() datasource.DataSource {
+	return &CollectionsDataSource{}
+}
+
+type CollectionsDataSource struct {
+	client *mongodb.Client
+}
+
+type CollectionsDataSourceModel struct {
+	Database    types.String              `tfsdk:"database"`
+	NameRegex   types.String              `tfsdk:"name_regex"`
+	Collections []CollectionsElementModel `tfsdk:"collections"`
+}
+
+type CollectionsElementModel struct {
+	Name types.String `tfsdk:"name"`
+	Type types.String `tfsdk:"type"`
+}
+
+func (d *CollectionsDataSource) Metadata(
+	_ context.Context,
+	req datasource.MetadataRequest,
+	resp *datasource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_collections"
+}
+
+func (d *CollectionsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the collections of a MongoDB database, including the views and the time series collections",
+		Attributes: map[string]schema.Attribute{
+			"database": schema.StringAttribute{
+				Description: "Database name",
+				Required:    true,
+			},
+			"name_regex": schema.StringAttribute{
+				Description: "Regular expression the collection names must match, evaluated by the server " +
+					"with $regex, e.g. ^orders_",
+				Optional: true,
+			},
+			"collections": schema.ListNestedAttribute{
+				Description: "Collections of the database sorted by name",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Description: "Collection name",
+							Computed:    true,
+						},
+						"type": schema.StringAttribute{
+							Description: "Type of the collection: collection, view or timeseries",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *CollectionsDataSource) Configure(
+	_ context.Context,
+	req datasource.ConfigureRequest,
+	resp *datasource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	p, ok := req.ProviderData.(*MongodbProvider)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *MongodbProvider, got: %T. "+
+				"Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = p.client
+}
+
+func (d *CollectionsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if d.client == nil {
+		resp.Diagnostics.AddError(
+			"MongoDB client is not configured",
+			"Expected configured MongoDB client. Please report this issue to the provider developers.",
+		)
+
+		return
+	}
+
+	var config CollectionsDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	collections, err := d.client.ListCollections(ctx, &mongodb.ListCollectionsOptions{
+		Database:  config.Database.ValueString(),
+		NameRegex: config.NameRegex.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			commandErrorSummary(err, "failed to list collections"),
+			err.Error(),
+		)
+
+		return
+	}
+
+	config.Collections = make([]CollectionsElementModel, 0, len(collections))
+
+	for _, collection := range collections {
+		config.Collections = append(config.Collections, CollectionsElementModel{
+			Name: types.StringValue(collection.Name),
+			Type: types.StringValue(collection.Type),
+		})
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
+}
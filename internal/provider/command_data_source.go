@@ -0,0 +1,181 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"go.mongodb.org/mongo-driver/v2/bson"
+
+	"github.com/megum1n/terraform-provider-mongodb/internal/mongodb"
+)
+
+var _ datasource.DataSource = &CommandDataSource{}
+var _ datasource.DataSourceWithConfigure = &CommandDataSource{}
+
+func NewCommandDataSource() datasource.DataSource {
+	return &CommandDataSource{}
+}
+
+type CommandDataSource struct {
+	client *mongodb.Client
+}
+
+type CommandDataSourceModel struct {
+	Database       types.String `tfsdk:"database"`
+	Command        types.String `tfsdk:"command"`
+	DeniedCommands types.Set    `tfsdk:"denied_commands"`
+	Result         types.String `tfsdk:"result"`
+}
+
+func (d *CommandDataSource) Metadata(
+	_ context.Context,
+	req datasource.MetadataRequest,
+	resp *datasource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_command"
+}
+
+func (d *CommandDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Runs a read only command, e.g. `buildInfo`, `serverStatus` or `connectionStatus`, " +
+			"and returns its response. Commands which change data or the server configuration are rejected.",
+
+		Attributes: map[string]schema.Attribute{
+			"database": schema.StringAttribute{
+				MarkdownDescription: "Database to run the command on, e.g. `admin`",
+				Required:            true,
+			},
+			"command": schema.StringAttribute{
+				MarkdownDescription: "Command as a JSON document in the MongoDB extended JSON format, " +
+					"the first field is the command name",
+				Required: true,
+			},
+			"denied_commands": schema.SetAttribute{
+				MarkdownDescription: "Names of the commands to reject, compared case insensitively. " +
+					"Defaults to the commands writing data, managing users, roles, indexes, collections, " +
+					"replication and sharding, and changing the server parameters. " +
+					"The aggregations with a `$out` or `$merge` stage are always rejected",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"result": schema.StringAttribute{
+				MarkdownDescription: "Response of the command as relaxed extended JSON. " +
+					"The cluster time fields are removed",
+				Computed: true,
+			},
+		},
+	}
+}
+
+func (d *CommandDataSource) Configure(
+	_ context.Context,
+	req datasource.ConfigureRequest,
+	resp *datasource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	p, ok := req.ProviderData.(*MongodbProvider)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *MongodbProvider, got: %T. "+
+				"Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = p.client
+}
+
+// checkReadOnlyCommand rejects the denied commands, and the aggregations writing to a collection.
+func checkReadOnlyCommand(command bson.D, denied []string) error {
+	for _, name := range denied {
+		if strings.EqualFold(name, command[0].Key) {
+			return fmt.Errorf("the %s command is rejected by the read only data source, "+
+				"use the mongodb_command resource or change denied_commands", command[0].Key)
+		}
+	}
+
+	if stage := mongodb.WriteStage(command); stage != "" {
+		return fmt.Errorf("the %s stage writes to a collection and is rejected by the read only data source, "+
+			"use the mongodb_command resource", stage)
+	}
+
+	return nil
+}
+
+func (d *CommandDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if d.client == nil {
+		resp.Diagnostics.AddError(
+			"MongoDB client is not configured",
+			"Expected configured MongoDB client. Please report this issue to the provider developers.",
+		)
+
+		return
+	}
+
+	var config CommandDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	command, err := mongodb.ParseDocumentJSON(config.Command.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("command"), "Failed to parse command", err.Error())
+
+		return
+	}
+
+	if len(command) == 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("command"),
+			"Invalid command",
+			"The command document must not be empty, its first field is the command name",
+		)
+
+		return
+	}
+
+	denied := mongodb.DefaultMutatingCommands
+	if !config.DeniedCommands.IsNull() {
+		denied = nil
+
+		resp.Diagnostics.Append(config.DeniedCommands.ElementsAs(ctx, &denied, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	if err = checkReadOnlyCommand(command, denied); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("command"), "Command not allowed", err.Error())
+
+		return
+	}
+
+	result, err := d.client.RunCommand(ctx, &mongodb.RunCommandOptions{
+		Database: config.Database.ValueString(),
+		Command:  config.Command.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			commandErrorSummary(err, "failed to run command"),
+			err.Error(),
+		)
+
+		return
+	}
+
+	config.Result = types.StringValue(result)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
+}
@@ -0,0 +1,60 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/megum1n/terraform-provider-mongodb/internal/mongodb"
+)
+
+func TestCheckReadOnlyCommand(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		command string
+		denied  []string
+		allowed bool
+	}{
+		"buildInfo":              {command: `{"buildInfo":1}`, allowed: true},
+		"find":                   {command: `{"find":"users","filter":{}}`, allowed: true},
+		"insert":                 {command: `{"insert":"users","documents":[{}]}`},
+		"case insensitive":       {command: `{"ApplyOps":[]}`},
+		"applyOps":               {command: `{"applyOps":[]}`},
+		"mapReduce":              {command: `{"mapReduce":"users","map":"","reduce":"","out":"totals"}`},
+		"setClusterParameter":    {command: `{"setClusterParameter":{"changeStreamOptions":{}}}`},
+		"configureFailPoint":     {command: `{"configureFailPoint":"failCommand","mode":"alwaysOn"}`},
+		"refineShardKey":         {command: `{"refineCollectionShardKey":"app.users","key":{"a":1,"b":1}}`},
+		"split":                  {command: `{"split":"app.users","middle":{"a":1}}`},
+		"mergeChunks":            {command: `{"mergeChunks":"app.users","bounds":[{"a":1},{"a":2}]}`},
+		"createSearchIndexes":    {command: `{"createSearchIndexes":"users","indexes":[]}`},
+		"updateSearchIndex":      {command: `{"updateSearchIndex":"users","name":"default","definition":{}}`},
+		"dropSearchIndex":        {command: `{"dropSearchIndex":"users","name":"default"}`},
+		"setIndexCommitQuorum":   {command: `{"setIndexCommitQuorum":"users","indexNames":["a_1"],"commitQuorum":1}`},
+		"aggregate":              {command: `{"aggregate":"users","pipeline":[{"$match":{}}],"cursor":{}}`, allowed: true},
+		"aggregate with $out":    {command: `{"aggregate":"users","pipeline":[{"$match":{}},{"$out":"copy"}],"cursor":{}}`},
+		"aggregate with $merge":  {command: `{"aggregate":"users","pipeline":[{"$merge":{"into":"copy"}}],"cursor":{}}`},
+		"$out with custom list":  {command: `{"aggregate":"users","pipeline":[{"$out":"copy"}]}`, denied: []string{}},
+		"$out in another field":  {command: `{"find":"users","filter":{"$out":1}}`, allowed: true},
+		"custom denied commands": {command: `{"insert":"users","documents":[{}]}`, denied: []string{}, allowed: true},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			command, err := mongodb.ParseDocumentJSON(test.command)
+			if err != nil {
+				t.Fatalf("invalid command: %v", err)
+			}
+
+			denied := test.denied
+			if denied == nil {
+				denied = mongodb.DefaultMutatingCommands
+			}
+
+			err = checkReadOnlyCommand(command, denied)
+			if allowed := err == nil; allowed != test.allowed {
+				t.Errorf("expected the command to be allowed: %t, got %v", test.allowed, err)
+			}
+		})
+	}
+}
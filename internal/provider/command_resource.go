@@ -0,0 +1,352 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/megum1n/terraform-provider-mongodb/internal/mongodb"
+)
+
+var (
+	_ resource.Resource                   = &CommandResource{}
+	_ resource.ResourceWithConfigure      = &CommandResource{}
+	_ resource.ResourceWithValidateConfig = &CommandResource{}
+)
+
+func NewCommandResource() resource.Resource {
+	return &CommandResource{}
+}
+
+type CommandResource struct {
+	client *mongodb.Client
+}
+
+type CommandResourceModel struct {
+	ID              types.String `tfsdk:"id"`
+	Database        types.String `tfsdk:"database"`
+	Create          types.String `tfsdk:"create"`
+	Read            types.String `tfsdk:"read"`
+	Delete          types.String `tfsdk:"delete"`
+	ForgetOnDestroy types.Bool   `tfsdk:"forget_on_destroy"`
+	Result          types.String `tfsdk:"result"`
+}
+
+func (r *CommandResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_command"
+}
+
+func (r *CommandResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Runs arbitrary database commands, for the features without a dedicated resource. " +
+			"The `create` command runs once when the resource is created, the `read` command on every refresh " +
+			"and the `delete` command when the resource is destroyed. The commands are JSON documents " +
+			"in the MongoDB extended JSON format, the first field is the command name.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Database and name of the create command",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"database": schema.StringAttribute{
+				MarkdownDescription: "Database to run the commands on, e.g. `admin`",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"create": schema.StringAttribute{
+				MarkdownDescription: "Command run when the resource is created. Changing it recreates the resource. " +
+					"The `write_concern` and `operation_comment` of the provider are added to the create " +
+					"and delete commands, unless they set `writeConcern` or `comment`",
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"read": schema.StringAttribute{
+				MarkdownDescription: "Command run on every refresh, its response is stored in `result` " +
+					"so that changes made outside of Terraform are reported",
+				Optional: true,
+			},
+			"delete": schema.StringAttribute{
+				MarkdownDescription: "Command run when the resource is destroyed. " +
+					"Required unless `forget_on_destroy` is set",
+				Optional: true,
+			},
+			"forget_on_destroy": schema.BoolAttribute{
+				MarkdownDescription: "Only remove the resource from the state when it is destroyed, " +
+					"for the commands which can't be undone. Conflicts with `delete`",
+				Optional: true,
+			},
+			"result": schema.StringAttribute{
+				MarkdownDescription: "Response of the read command, or of the create command when there is " +
+					"no read command, as relaxed extended JSON. The cluster time fields are removed",
+				Computed: true,
+			},
+		},
+	}
+}
+
+func (r *CommandResource) ValidateConfig(
+	ctx context.Context,
+	req resource.ValidateConfigRequest,
+	resp *resource.ValidateConfigResponse,
+) {
+	var config CommandResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for attribute, value := range map[string]types.String{
+		"create": config.Create,
+		"read":   config.Read,
+		"delete": config.Delete,
+	} {
+		if value.IsNull() || value.IsUnknown() {
+			continue
+		}
+
+		command, err := mongodb.ParseDocumentJSON(value.ValueString())
+
+		switch {
+		case err != nil:
+			resp.Diagnostics.AddAttributeError(path.Root(attribute), "Failed to parse command", err.Error())
+		case len(command) == 0:
+			resp.Diagnostics.AddAttributeError(
+				path.Root(attribute),
+				"Invalid command",
+				"The command document must not be empty, its first field is the command name",
+			)
+		}
+	}
+
+	if config.Delete.IsUnknown() || config.ForgetOnDestroy.IsUnknown() {
+		return
+	}
+
+	switch {
+	case !config.Delete.IsNull() && config.ForgetOnDestroy.ValueBool():
+		resp.Diagnostics.AddAttributeError(
+			path.Root("forget_on_destroy"),
+			"Invalid command configuration",
+			"forget_on_destroy conflicts with delete",
+		)
+	case config.Delete.IsNull() && !config.ForgetOnDestroy.ValueBool():
+		resp.Diagnostics.AddAttributeError(
+			path.Root("delete"),
+			"Missing delete command",
+			"Set the delete command which undoes the create command, "+
+				"or set forget_on_destroy = true to leave the changes in place when the resource is destroyed",
+		)
+	}
+}
+
+func (r *CommandResource) Configure(
+	_ context.Context,
+	req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	p, ok := req.ProviderData.(*MongodbProvider)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *MongodbProvider, got: %T. "+
+				"Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = p.client
+}
+
+func (r *CommandResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if !r.checkClient(&resp.Diagnostics) {
+		return
+	}
+
+	var plan CommandResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result, err := r.client.RunCommand(ctx, &mongodb.RunCommandOptions{
+		Database: plan.Database.ValueString(),
+		Command:  plan.Create.ValueString(),
+		Write:    true,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			commandErrorSummary(err, "Error running create command"),
+			err.Error(),
+		)
+
+		return
+	}
+
+	command, err := mongodb.ParseDocumentJSON(plan.Create.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to parse command", err.Error())
+
+		return
+	}
+
+	plan.ID = types.StringValue(fmt.Sprintf("%s.%s", plan.Database.ValueString(), command[0].Key))
+	plan.Result = types.StringValue(result)
+
+	if !plan.Read.IsNull() {
+		resp.Diagnostics.Append(r.read(ctx, &plan)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	tflog.Trace(ctx, "Command run")
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *CommandResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if !r.checkClient(&resp.Diagnostics) {
+		return
+	}
+
+	var state CommandResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if state.Read.IsNull() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.read(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *CommandResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if !r.checkClient(&resp.Diagnostics) {
+		return
+	}
+
+	var plan, state CommandResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Only the read and delete commands change in place, the create command is not run again
+	plan.Result = state.Result
+
+	if !plan.Read.IsNull() {
+		resp.Diagnostics.Append(r.read(ctx, &plan)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	tflog.Trace(ctx, "Command updated")
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *CommandResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if !r.checkClient(&resp.Diagnostics) {
+		return
+	}
+
+	var state CommandResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if state.Delete.IsNull() {
+		resp.Diagnostics.AddWarning(
+			"Command not undone",
+			fmt.Sprintf("The %s resource has no delete command, it was only removed from the Terraform state",
+				state.ID.ValueString()),
+		)
+
+		return
+	}
+
+	_, err := r.client.RunCommand(ctx, &mongodb.RunCommandOptions{
+		Database: state.Database.ValueString(),
+		Command:  state.Delete.ValueString(),
+		Write:    true,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			commandErrorSummary(err, "Error running delete command"),
+			err.Error(),
+		)
+
+		return
+	}
+
+	tflog.Trace(ctx, "Command deleted")
+}
+
+// read runs the read command and stores its response in the result.
+func (r *CommandResource) read(ctx context.Context, model *CommandResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	result, err := r.client.RunCommand(ctx, &mongodb.RunCommandOptions{
+		Database: model.Database.ValueString(),
+		Command:  model.Read.ValueString(),
+	})
+	if err != nil {
+		diags.AddError(
+			commandErrorSummary(err, "Error running read command"),
+			err.Error(),
+		)
+
+		return diags
+	}
+
+	model.Result = types.StringValue(result)
+
+	return diags
+}
+
+func (r *CommandResource) checkClient(diags *diag.Diagnostics) bool {
+	if r.client == nil {
+		diags.AddError(
+			"MongoDB client is not configured",
+			"Expected configured MongoDB client. Please report this issue to the provider developers.",
+		)
+
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,92 @@
+package provider
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"gopkg.in/yaml.v3"
+)
+
+// providerConfigFile is the content of the file referenced by the "config_file" attribute.
+// YAML is a superset of JSON, so both formats are accepted.
+type providerConfigFile struct {
+	Hosts              []string `yaml:"hosts"`
+	Username           *string  `yaml:"username"`
+	Password           *string  `yaml:"password"`
+	AuthSource         *string  `yaml:"auth_source"`
+	ReplicaSet         *string  `yaml:"replica_set"`
+	TLS                *bool    `yaml:"tls"`
+	Certificate        *string  `yaml:"certificate"`
+	CertificateFile    *string  `yaml:"certificate_file"`
+	TLSClientCertFile  *string  `yaml:"tls_client_cert_file"`
+	TLSClientKeyFile   *string  `yaml:"tls_client_key_file"`
+	InsecureSkipVerify *bool    `yaml:"insecure_skip_verify"`
+}
+
+func readConfigFile(path string) (*providerConfigFile, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	decoder.KnownFields(true)
+
+	config := &providerConfigFile{}
+
+	err = decoder.Decode(config)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("invalid config file %q: %w", path, err)
+	}
+
+	for _, host := range config.Hosts {
+		if host == "" {
+			return nil, fmt.Errorf("invalid config file %q: hosts must not contain empty values", path)
+		}
+	}
+
+	return config, nil
+}
+
+// mergeInto fills the attributes which are not set in the provider configuration.
+// Values set inline in the provider block always take precedence over the file.
+func (f *providerConfigFile) mergeInto(data *MongodbProviderModel) {
+	if data.Hosts.IsNull() && len(f.Hosts) > 0 {
+		hosts := make([]attr.Value, 0, len(f.Hosts))
+		for _, host := range f.Hosts {
+			hosts = append(hosts, types.StringValue(host))
+		}
+
+		data.Hosts = types.ListValueMust(types.StringType, hosts)
+	}
+
+	mergeString(&data.Username, f.Username)
+	mergeString(&data.Password, f.Password)
+	mergeString(&data.AuthSource, f.AuthSource)
+	mergeString(&data.ReplicaSet, f.ReplicaSet)
+	// The conflicts between the file and the provider block are reported by validateCertificateAttributes
+	mergeString(&data.Certificate, f.Certificate)
+	mergeString(&data.CertificateFile, f.CertificateFile)
+	mergeString(&data.TLSClientCertFile, f.TLSClientCertFile)
+	mergeString(&data.TLSClientKeyFile, f.TLSClientKeyFile)
+
+	mergeBool(&data.TLS, f.TLS)
+	mergeBool(&data.InsecureSkipVerify, f.InsecureSkipVerify)
+}
+
+func mergeString(value *types.String, fileValue *string) {
+	if value.IsNull() && fileValue != nil {
+		*value = types.StringPointerValue(fileValue)
+	}
+}
+
+func mergeBool(value *types.Bool, fileValue *bool) {
+	if value.IsNull() && fileValue != nil {
+		*value = types.BoolPointerValue(fileValue)
+	}
+}
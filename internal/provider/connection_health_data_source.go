@@ -0,0 +1,151 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/megum1n/terraform-provider-mongodb/internal/mongodb"
+)
+
+var _ datasource.DataSource = &ConnectionHealthDataSource{}
+var _ datasource.DataSourceWithConfigure = &ConnectionHealthDataSource{}
+
+func NewConnectionHealthDataSource() datasource.DataSource {
+	return &ConnectionHealthDataSource{}
+}
+
+type ConnectionHealthDataSource struct {
+	client *mongodb.Client
+}
+
+type ConnectionHealthDataSourceModel struct {
+	PoolMetrics        types.Bool  `tfsdk:"pool_metrics"`
+	ConnectionsCreated types.Int64 `tfsdk:"connections_created"`
+	ConnectionsClosed  types.Int64 `tfsdk:"connections_closed"`
+	CheckedOut         types.Int64 `tfsdk:"checked_out"`
+	CheckedIn          types.Int64 `tfsdk:"checked_in"`
+	CheckOutFailed     types.Int64 `tfsdk:"check_out_failed"`
+	CheckOutTimeouts   types.Int64 `tfsdk:"check_out_timeouts"`
+}
+
+func (m *ConnectionHealthDataSourceModel) updateState(stats mongodb.PoolStats, enabled bool) {
+	m.PoolMetrics = types.BoolValue(enabled)
+
+	if !enabled {
+		m.ConnectionsCreated = types.Int64Null()
+		m.ConnectionsClosed = types.Int64Null()
+		m.CheckedOut = types.Int64Null()
+		m.CheckedIn = types.Int64Null()
+		m.CheckOutFailed = types.Int64Null()
+		m.CheckOutTimeouts = types.Int64Null()
+
+		return
+	}
+
+	m.ConnectionsCreated = types.Int64Value(stats.ConnectionsCreated)
+	m.ConnectionsClosed = types.Int64Value(stats.ConnectionsClosed)
+	m.CheckedOut = types.Int64Value(stats.CheckedOut)
+	m.CheckedIn = types.Int64Value(stats.CheckedIn)
+	m.CheckOutFailed = types.Int64Value(stats.CheckOutFailed)
+	m.CheckOutTimeouts = types.Int64Value(stats.CheckOutTimeouts)
+}
+
+func (d *ConnectionHealthDataSource) Metadata(
+	_ context.Context,
+	req datasource.MetadataRequest,
+	resp *datasource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_connection_health"
+}
+
+func (d *ConnectionHealthDataSource) Schema(
+	_ context.Context,
+	_ datasource.SchemaRequest,
+	resp *datasource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Connection pool event counts of the provider since it was configured. " +
+			"The counts are null unless `pool_metrics` is enabled in the provider",
+
+		Attributes: map[string]schema.Attribute{
+			"pool_metrics": schema.BoolAttribute{
+				MarkdownDescription: "Whether `pool_metrics` is enabled in the provider",
+				Computed:            true,
+			},
+			"connections_created": schema.Int64Attribute{
+				MarkdownDescription: "Number of connections opened",
+				Computed:            true,
+			},
+			"connections_closed": schema.Int64Attribute{
+				MarkdownDescription: "Number of connections closed",
+				Computed:            true,
+			},
+			"checked_out": schema.Int64Attribute{
+				MarkdownDescription: "Number of connections checked out of the pool",
+				Computed:            true,
+			},
+			"checked_in": schema.Int64Attribute{
+				MarkdownDescription: "Number of connections returned to the pool",
+				Computed:            true,
+			},
+			"check_out_failed": schema.Int64Attribute{
+				MarkdownDescription: "Number of failed check outs, including timeouts",
+				Computed:            true,
+			},
+			"check_out_timeouts": schema.Int64Attribute{
+				MarkdownDescription: "Number of check outs which timed out waiting for a free connection. " +
+					"A growing value means the pool is saturated, consider raising `max_pool_size` " +
+					"or lowering the Terraform parallelism",
+				Computed: true,
+			},
+		},
+	}
+}
+
+func (d *ConnectionHealthDataSource) Configure(
+	_ context.Context,
+	req datasource.ConfigureRequest,
+	resp *datasource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	p, ok := req.ProviderData.(*MongodbProvider)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *MongodbProvider, got: %T. "+
+				"Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = p.client
+}
+
+func (d *ConnectionHealthDataSource) Read(
+	ctx context.Context,
+	_ datasource.ReadRequest,
+	resp *datasource.ReadResponse,
+) {
+	if d.client == nil {
+		resp.Diagnostics.AddError(
+			"MongoDB client is not configured",
+			"Expected configured MongoDB client. Please report this issue to the provider developers.",
+		)
+
+		return
+	}
+
+	var state ConnectionHealthDataSourceModel
+
+	state.updateState(d.client.PoolStats())
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
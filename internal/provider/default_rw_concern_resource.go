@@ -0,0 +1,421 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/megum1n/terraform-provider-mongodb/internal/mongodb"
+)
+
+var (
+	_ resource.Resource                = &DefaultRWConcernResource{}
+	_ resource.ResourceWithConfigure   = &DefaultRWConcernResource{}
+	_ resource.ResourceWithImportState = &DefaultRWConcernResource{}
+)
+
+// defaultRWConcernID is the ID of the single default read and write concern of the cluster.
+const defaultRWConcernID = "default_rw_concern"
+
+func NewDefaultRWConcernResource() resource.Resource {
+	return &DefaultRWConcernResource{}
+}
+
+type DefaultRWConcernResource struct {
+	client *mongodb.Client
+}
+
+type DefaultRWConcernResourceModel struct {
+	ID                  types.String `tfsdk:"id"`
+	DefaultReadConcern  types.Object `tfsdk:"default_read_concern"`
+	DefaultWriteConcern types.Object `tfsdk:"default_write_concern"`
+}
+
+type ReadConcernModel struct {
+	Level types.String `tfsdk:"level"`
+}
+
+func (m ReadConcernModel) AttributeTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"level": types.StringType,
+	}
+}
+
+func (m WriteConcernModel) AttributeTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"w":        types.StringType,
+		"wtimeout": types.StringType,
+		"journal":  types.BoolType,
+	}
+}
+
+func (m *DefaultRWConcernResourceModel) concern(ctx context.Context) (*mongodb.DefaultRWConcern, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	concern := &mongodb.DefaultRWConcern{}
+
+	if !m.DefaultReadConcern.IsNull() && !m.DefaultReadConcern.IsUnknown() {
+		var readConcern ReadConcernModel
+
+		diags.Append(m.DefaultReadConcern.As(ctx, &readConcern, basetypes.ObjectAsOptions{})...)
+		concern.ReadConcernLevel = readConcern.Level.ValueString()
+	}
+
+	if !m.DefaultWriteConcern.IsNull() && !m.DefaultWriteConcern.IsUnknown() {
+		var writeConcern WriteConcernModel
+
+		diags.Append(m.DefaultWriteConcern.As(ctx, &writeConcern, basetypes.ObjectAsOptions{})...)
+
+		concern.WriteConcern = &mongodb.WriteConcern{
+			W:       writeConcern.W.ValueString(),
+			Journal: writeConcern.Journal.ValueBoolPointer(),
+		}
+
+		concern.WriteConcern.WTimeout = durationValue(
+			writeConcern.WTimeout,
+			path.Root("default_write_concern").AtName("wtimeout"),
+			&diags,
+		)
+	}
+
+	return concern, diags
+}
+
+// updateState stores the defaults read from the server. The default write concern is only tracked
+// when it's managed, as MongoDB 5.0 and later can't unset it once it's set.
+func (m *DefaultRWConcernResourceModel) updateState(
+	ctx context.Context,
+	concern *mongodb.DefaultRWConcern,
+	trackWriteConcern bool,
+) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	m.ID = types.StringValue(defaultRWConcernID)
+
+	if concern.ReadConcernLevel == "" {
+		m.DefaultReadConcern = types.ObjectNull(ReadConcernModel{}.AttributeTypes())
+	} else {
+		readConcern, d := types.ObjectValueFrom(ctx, ReadConcernModel{}.AttributeTypes(), ReadConcernModel{
+			Level: types.StringValue(concern.ReadConcernLevel),
+		})
+		diags.Append(d...)
+
+		m.DefaultReadConcern = readConcern
+	}
+
+	if !trackWriteConcern {
+		return diags
+	}
+
+	if concern.WriteConcern == nil {
+		m.DefaultWriteConcern = types.ObjectNull(WriteConcernModel{}.AttributeTypes())
+
+		return diags
+	}
+
+	var current WriteConcernModel
+
+	if !m.DefaultWriteConcern.IsNull() && !m.DefaultWriteConcern.IsUnknown() {
+		diags.Append(m.DefaultWriteConcern.As(ctx, &current, basetypes.ObjectAsOptions{})...)
+	}
+
+	writeConcern := WriteConcernModel{
+		W:        types.StringNull(),
+		WTimeout: types.StringNull(),
+		Journal:  types.BoolPointerValue(concern.WriteConcern.Journal),
+	}
+
+	if concern.WriteConcern.W != "" {
+		writeConcern.W = types.StringValue(concern.WriteConcern.W)
+	}
+
+	// Keep the configured duration when it's the same, e.g. "1m" instead of "1m0s"
+	if concern.WriteConcern.WTimeout > 0 {
+		configured, err := time.ParseDuration(current.WTimeout.ValueString())
+		if err == nil && configured == concern.WriteConcern.WTimeout {
+			writeConcern.WTimeout = current.WTimeout
+		} else {
+			writeConcern.WTimeout = types.StringValue(concern.WriteConcern.WTimeout.String())
+		}
+	}
+
+	object, d := types.ObjectValueFrom(ctx, WriteConcernModel{}.AttributeTypes(), writeConcern)
+	diags.Append(d...)
+
+	m.DefaultWriteConcern = object
+
+	return diags
+}
+
+func (r *DefaultRWConcernResource) Metadata(
+	_ context.Context,
+	req resource.MetadataRequest,
+	resp *resource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_default_rw_concern"
+}
+
+func (r *DefaultRWConcernResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages the cluster wide default read and write concern set with `setDefaultRWConcern`. " +
+			"There is a single default per cluster, so declare this resource once. Destroying it restores " +
+			"the implicit server defaults. MongoDB 5.0 and later can't unset the default write concern, " +
+			"so removing `default_write_concern` or destroying the resource leaves it in place.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Always `" + defaultRWConcernID + "`",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"default_read_concern": schema.SingleNestedAttribute{
+				MarkdownDescription: "Default read concern of the operations which don't request one",
+				Optional:            true,
+				Attributes: map[string]schema.Attribute{
+					"level": schema.StringAttribute{
+						MarkdownDescription: "Read concern level: `local`, `available` or `majority`",
+						Required:            true,
+						Validators: []validator.String{
+							stringvalidator.OneOf("local", "available", "majority"),
+						},
+					},
+				},
+			},
+			"default_write_concern": schema.SingleNestedAttribute{
+				MarkdownDescription: "Default write concern of the operations which don't request one",
+				Optional:            true,
+				Attributes: map[string]schema.Attribute{
+					"w": schema.StringAttribute{
+						MarkdownDescription: "Number of nodes, `majority` or a custom write concern tag name",
+						Optional:            true,
+						Validators: []validator.String{
+							stringvalidator.LengthAtLeast(1),
+						},
+					},
+					"wtimeout": schema.StringAttribute{
+						MarkdownDescription: "Time limit for the write concern, e.g. `10s`",
+						Optional:            true,
+					},
+					"journal": schema.BoolAttribute{
+						MarkdownDescription: "Request acknowledgment that the write has been written to the on-disk journal",
+						Optional:            true,
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *DefaultRWConcernResource) Configure(
+	_ context.Context,
+	req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	p, ok := req.ProviderData.(*MongodbProvider)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *MongodbProvider, got: %T. "+
+				"Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = p.client
+}
+
+func (r *DefaultRWConcernResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if !r.checkClient(&resp.Diagnostics) {
+		return
+	}
+
+	var plan DefaultRWConcernResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.set(ctx, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "Default read and write concern set")
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *DefaultRWConcernResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if !r.checkClient(&resp.Diagnostics) {
+		return
+	}
+
+	var state DefaultRWConcernResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	concern, err := r.client.GetDefaultRWConcern(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			commandErrorSummary(err, "failed to get default read and write concern"),
+			err.Error(),
+		)
+
+		return
+	}
+
+	resp.Diagnostics.Append(state.updateState(ctx, concern, !state.DefaultWriteConcern.IsNull())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *DefaultRWConcernResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if !r.checkClient(&resp.Diagnostics) {
+		return
+	}
+
+	var plan DefaultRWConcernResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.set(ctx, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "Default read and write concern updated")
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *DefaultRWConcernResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if !r.checkClient(&resp.Diagnostics) {
+		return
+	}
+
+	var state DefaultRWConcernResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	writeConcernKept, err := r.client.UnsetDefaultRWConcern(ctx, !state.DefaultWriteConcern.IsNull())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			commandErrorSummary(err, "failed to unset default read and write concern"),
+			err.Error(),
+		)
+
+		return
+	}
+
+	if writeConcernKept {
+		resp.Diagnostics.AddWarning(
+			"Default write concern not unset",
+			"The server refused to unset the default write concern, which MongoDB 5.0 and later don't allow. "+
+				"The default read concern was unset, the default write concern was left in place",
+		)
+	}
+
+	tflog.Trace(ctx, "Default read and write concern unset")
+}
+
+// ImportState imports the current defaults, the import identifier is ignored.
+func (r *DefaultRWConcernResource) ImportState(
+	ctx context.Context,
+	_ resource.ImportStateRequest,
+	resp *resource.ImportStateResponse,
+) {
+	if !r.checkClient(&resp.Diagnostics) {
+		return
+	}
+
+	concern, err := r.client.GetDefaultRWConcern(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to get default read and write concern",
+			err.Error(),
+		)
+
+		return
+	}
+
+	state := DefaultRWConcernResourceModel{
+		DefaultWriteConcern: types.ObjectNull(WriteConcernModel{}.AttributeTypes()),
+	}
+
+	resp.Diagnostics.Append(state.updateState(ctx, concern, true)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// set applies the planned defaults and stores the values read back from the server.
+func (r *DefaultRWConcernResource) set(
+	ctx context.Context,
+	model *DefaultRWConcernResourceModel,
+	diags *diag.Diagnostics,
+) {
+	concern, d := model.concern(ctx)
+
+	diags.Append(d...)
+	if diags.HasError() {
+		return
+	}
+
+	current, err := r.client.SetDefaultRWConcern(ctx, concern)
+	if err != nil {
+		diags.AddError(
+			commandErrorSummary(err, "failed to set default read and write concern"),
+			err.Error(),
+		)
+
+		return
+	}
+
+	diags.Append(model.updateState(ctx, current, concern.WriteConcern != nil)...)
+}
+
+func (r *DefaultRWConcernResource) checkClient(diags *diag.Diagnostics) bool {
+	if r.client == nil {
+		diags.AddError(
+			"MongoDB client is not configured",
+			"Expected configured MongoDB client. Please report this issue to the provider developers.",
+		)
+
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,27 @@
+package provider
+
+import (
+	"errors"
+
+	"github.com/megum1n/terraform-provider-mongodb/internal/mongodb"
+)
+
+// commandErrorSummary returns the diagnostic summary for the well known command errors.
+func commandErrorSummary(err error, summary string) string {
+	switch {
+	case errors.As(err, &mongodb.MaxTimeExpiredError{}):
+		return "command timed out"
+	case errors.As(err, &mongodb.OperationTimeoutError{}):
+		return "operation timed out"
+	case errors.As(err, &mongodb.WriteConcernTimeoutError{}):
+		return "write concern timeout"
+	case mongodb.IsAuthError(err):
+		return "not authorized"
+	case mongodb.IsDuplicateError(err):
+		return "already exists"
+	case mongodb.IsNotPrimaryError(err):
+		return "not connected to the primary"
+	default:
+		return summary
+	}
+}
@@ -0,0 +1,378 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/megum1n/terraform-provider-mongodb/internal/mongodb"
+)
+
+var (
+	_ resource.Resource                   = &DocumentResource{}
+	_ resource.ResourceWithConfigure      = &DocumentResource{}
+	_ resource.ResourceWithImportState    = &DocumentResource{}
+	_ resource.ResourceWithValidateConfig = &DocumentResource{}
+)
+
+func NewDocumentResource() resource.Resource {
+	return &DocumentResource{}
+}
+
+type DocumentResource struct {
+	client *mongodb.Client
+}
+
+type DocumentResourceModel struct {
+	ID         types.String `tfsdk:"id"`
+	Database   types.String `tfsdk:"database"`
+	Collection types.String `tfsdk:"collection"`
+	Body       types.String `tfsdk:"body"`
+}
+
+func (m *DocumentResourceModel) document() (*mongodb.Document, error) {
+	body, err := mongodb.ParseDocumentJSON(m.Body.ValueString())
+	if err != nil {
+		return nil, err
+	}
+
+	document := &mongodb.Document{
+		Database:   m.Database.ValueString(),
+		Collection: m.Collection.ValueString(),
+		Body:       body,
+	}
+
+	if !m.ID.IsNull() && !m.ID.IsUnknown() {
+		document.ID = m.ID.ValueString()
+	}
+
+	return document, nil
+}
+
+// updateState keeps the configured body when it describes the same document, so the order of the fields
+// and the formatting do not cause a diff.
+func (m *DocumentResourceModel) updateState(document *mongodb.Document) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	body, err := mongodb.DocumentJSON(document.Body)
+	if err != nil {
+		diags.AddError("Failed to parse document", err.Error())
+
+		return diags
+	}
+
+	m.ID = types.StringValue(document.ID)
+	m.Database = types.StringValue(document.Database)
+	m.Collection = types.StringValue(document.Collection)
+
+	if !isSameDocument(m.Body, []byte(body)) {
+		m.Body = types.StringValue(body)
+	}
+
+	return diags
+}
+
+func (r *DocumentResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_document"
+}
+
+func (r *DocumentResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a single document, e.g. the reference data of a configuration collection. " +
+			"The document is inserted, or replaced when a document with the same `_id` exists, " +
+			"and deleted when the resource is destroyed.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The `_id` of the document. Generated by the server as an ObjectID " +
+					"when not set, ObjectIDs are given as hex strings. The documents with another `_id` type, " +
+					"e.g. a number, can't be managed. Changing it recreates the document",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"database": schema.StringAttribute{
+				MarkdownDescription: "Database name",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"collection": schema.StringAttribute{
+				MarkdownDescription: "Collection name",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"body": schema.StringAttribute{
+				MarkdownDescription: "Document as JSON in the MongoDB extended JSON format, without the `_id` field. " +
+					"Changes replace the whole document",
+				Required: true,
+			},
+		},
+	}
+}
+
+func (r *DocumentResource) ValidateConfig(
+	ctx context.Context,
+	req resource.ValidateConfigRequest,
+	resp *resource.ValidateConfigResponse,
+) {
+	var config DocumentResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.Body.IsNull() || config.Body.IsUnknown() {
+		return
+	}
+
+	body, err := mongodb.ParseDocumentJSON(config.Body.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("body"), "Failed to parse document", err.Error())
+
+		return
+	}
+
+	for _, e := range body {
+		if e.Key == "_id" {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("body"),
+				"Invalid document",
+				"The body must not hold the _id field, set the id attribute instead",
+			)
+		}
+	}
+}
+
+func (r *DocumentResource) Configure(
+	_ context.Context,
+	req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	p, ok := req.ProviderData.(*MongodbProvider)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *MongodbProvider, got: %T. "+
+				"Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = p.client
+}
+
+func (r *DocumentResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if !r.checkClient(&resp.Diagnostics) {
+		return
+	}
+
+	var plan DocumentResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.save(ctx, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "Document saved")
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *DocumentResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if !r.checkClient(&resp.Diagnostics) {
+		return
+	}
+
+	var state DocumentResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	document, err := r.client.GetDocument(ctx, &mongodb.GetDocumentOptions{
+		Database:   state.Database.ValueString(),
+		Collection: state.Collection.ValueString(),
+		ID:         state.ID.ValueString(),
+	})
+	if err != nil {
+		if !errors.As(err, &mongodb.NotFoundError{}) {
+			resp.Diagnostics.AddError(
+				commandErrorSummary(err, "failed to get document"),
+				err.Error(),
+			)
+
+			return
+		}
+
+		tflog.Debug(ctx, "document not found, removing from state")
+		resp.State.RemoveResource(ctx)
+
+		return
+	}
+
+	resp.Diagnostics.Append(state.updateState(document)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *DocumentResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if !r.checkClient(&resp.Diagnostics) {
+		return
+	}
+
+	var plan DocumentResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.save(ctx, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "Document replaced")
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *DocumentResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if !r.checkClient(&resp.Diagnostics) {
+		return
+	}
+
+	var state DocumentResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteDocument(ctx, &mongodb.GetDocumentOptions{
+		Database:   state.Database.ValueString(),
+		Collection: state.Collection.ValueString(),
+		ID:         state.ID.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			commandErrorSummary(err, "failed to delete document"),
+			err.Error(),
+		)
+
+		return
+	}
+
+	tflog.Trace(ctx, "Document deleted")
+}
+
+// ImportState imports a document by its namespace and _id: database.collection/id.
+func (r *DocumentResource) ImportState(
+	ctx context.Context,
+	req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse,
+) {
+	if !r.checkClient(&resp.Diagnostics) {
+		return
+	}
+
+	namespace, id, _ := strings.Cut(req.ID, "/")
+	database, collection, _ := strings.Cut(namespace, ".")
+
+	if database == "" || collection == "" || id == "" {
+		resp.Diagnostics.AddError(
+			"Invalid import ID",
+			"Import ID should be in the format: database.collection/id",
+		)
+
+		return
+	}
+
+	document, err := r.client.GetDocument(ctx, &mongodb.GetDocumentOptions{
+		Database:   database,
+		Collection: collection,
+		ID:         id,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error importing document",
+			fmt.Sprintf("Failed to read document %s: %s", req.ID, err),
+		)
+
+		return
+	}
+
+	var state DocumentResourceModel
+
+	resp.Diagnostics.Append(state.updateState(document)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// save inserts or replaces the planned document and stores the _id and the body read back in the model.
+func (r *DocumentResource) save(ctx context.Context, model *DocumentResourceModel, diags *diag.Diagnostics) {
+	document, err := model.document()
+	if err != nil {
+		diags.AddAttributeError(path.Root("body"), "Failed to parse document", err.Error())
+
+		return
+	}
+
+	saved, err := r.client.SaveDocument(ctx, document)
+	if err != nil {
+		diags.AddError(
+			commandErrorSummary(err, "failed to save document"),
+			err.Error(),
+		)
+
+		return
+	}
+
+	diags.Append(model.updateState(saved)...)
+}
+
+func (r *DocumentResource) checkClient(diags *diag.Diagnostics) bool {
+	if r.client == nil {
+		diags.AddError(
+			"MongoDB client is not configured",
+			"Expected configured MongoDB client. Please report this issue to the provider developers.",
+		)
+
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,76 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"go.mongodb.org/mongo-driver/v2/bson"
+
+	"github.com/megum1n/terraform-provider-mongodb/internal/mongodb"
+)
+
+func TestDocumentUpdateStateKeepsCanonicalBody(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		body   string
+		server bson.D
+		keep   bool
+	}{
+		"relaxed": {
+			body:   `{"n":5}`,
+			server: bson.D{{Key: "n", Value: int32(5)}},
+			keep:   true,
+		},
+		"canonical int64": {
+			body:   `{"n":{"$numberLong":"5"}}`,
+			server: bson.D{{Key: "n", Value: int64(5)}},
+			keep:   true,
+		},
+		"canonical int32": {
+			body:   `{"n":{"$numberInt":"5"}}`,
+			server: bson.D{{Key: "n", Value: int32(5)}},
+			keep:   true,
+		},
+		"canonical double": {
+			body:   `{"n":{"$numberDouble":"5.5"}}`,
+			server: bson.D{{Key: "n", Value: 5.5}},
+			keep:   true,
+		},
+		"canonical nested": {
+			body:   `{"a":{"b":[{"$numberLong":"1"},{"$numberLong":"2"}]},"c":"x"}`,
+			server: bson.D{{Key: "c", Value: "x"}, {Key: "a", Value: bson.D{{Key: "b", Value: bson.A{int64(1), int64(2)}}}}},
+			keep:   true,
+		},
+		"different value": {
+			body:   `{"n":{"$numberLong":"5"}}`,
+			server: bson.D{{Key: "n", Value: int64(6)}},
+		},
+		"missing field": {
+			body:   `{"n":5,"m":1}`,
+			server: bson.D{{Key: "n", Value: int32(5)}},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			model := DocumentResourceModel{Body: types.StringValue(test.body)}
+
+			diags := model.updateState(&mongodb.Document{
+				Database:   "app",
+				Collection: "settings",
+				ID:         "feature-flags",
+				Body:       test.server,
+			})
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+
+			if kept := model.Body.ValueString() == test.body; kept != test.keep {
+				t.Errorf("expected the configured body to be kept: %t, got body %s", test.keep, model.Body.ValueString())
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+var _ validator.String = hostValidator{}
+
+// hostValidator checks that the host is a host or host:port address, as expected by the driver in the hosts list.
+type hostValidator struct{}
+
+func hostAddress() validator.String {
+	return hostValidator{}
+}
+
+func (v hostValidator) Description(_ context.Context) string {
+	return "value must be a host or host:port address"
+}
+
+func (v hostValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v hostValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	err := validateHost(req.ConfigValue.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid MongoDB host", err.Error())
+	}
+}
+
+// validateHost checks the host or host:port address. The driver connects to the port 27017 when it's missing.
+// An IPv6 address is written in brackets, e.g. [::1]:27017.
+func validateHost(host string) error {
+	if strings.Contains(host, "://") {
+		return fmt.Errorf("%q must be a host or host:port address without a scheme, "+
+			"the connection string goes in the %s environment variable", host, uriEnvVar)
+	}
+
+	if strings.ContainsAny(host, "/?") {
+		return fmt.Errorf("%q must be a host or host:port address without a path or options", host)
+	}
+
+	hostname, port, err := net.SplitHostPort(host)
+	if err != nil {
+		var addrErr *net.AddrError
+		if !errors.As(err, &addrErr) || addrErr.Err != "missing port in address" {
+			return fmt.Errorf("%q must be a host or host:port address: %w", host, err)
+		}
+
+		// The driver connects to the default port
+		hostname, port = strings.TrimSuffix(strings.TrimPrefix(host, "["), "]"), "27017"
+	}
+
+	if hostname == "" {
+		return fmt.Errorf("%q is missing the host name", host)
+	}
+
+	n, err := strconv.ParseUint(port, 10, 16)
+	if err != nil || n == 0 {
+		return fmt.Errorf("%q has the invalid port %q, expected a number between 1 and 65535", host, port)
+	}
+
+	return nil
+}
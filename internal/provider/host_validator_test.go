@@ -0,0 +1,37 @@
+package provider
+
+import "testing"
+
+func TestValidateHost(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]bool{
+		"db.example.com:27017":          true,
+		"db.example.com":                true,
+		"localhost":                     true,
+		"10.0.0.1":                      true,
+		"[::1]:27017":                   true,
+		"[::1]":                         true,
+		"db.example.com:":               false,
+		"db.example.com:0":              false,
+		"db.example.com:65536":          false,
+		"db.example.com:port":           false,
+		":27017":                        false,
+		"":                              false,
+		"[]":                            false,
+		"::1":                           false,
+		"mongodb://db.example.com":      false,
+		"db.example.com:27017/admin":    false,
+		"db.example.com:27017?ssl=true": false,
+	}
+
+	for host, valid := range tests {
+		t.Run(host, func(t *testing.T) {
+			t.Parallel()
+
+			if err := validateHost(host); (err == nil) != valid {
+				t.Errorf("expected %q valid: %t, got %v", host, valid, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,277 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/megum1n/terraform-provider-mongodb/internal/mongodb"
+)
+
+var _ datasource.DataSource = &IndexDataSource{}
+var _ datasource.DataSourceWithConfigure = &IndexDataSource{}
+
+func NewIndexDataSource() datasource.DataSource {
+	return &IndexDataSource{}
+}
+
+type IndexDataSource struct {
+	client *mongodb.Client
+}
+
+// collationDataSourceAttribute returns the computed collation block of the data sources.
+func collationDataSourceAttribute() schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		Description: "Collation settings for string comparison",
+		Computed:    true,
+		Attributes: map[string]schema.Attribute{
+			"locale": schema.StringAttribute{
+				Description: "The locale for string comparison",
+				Computed:    true,
+			},
+			"case_level": schema.BoolAttribute{
+				Description: "Whether to consider case in the 'Level=1' comparison",
+				Computed:    true,
+			},
+			"case_first": schema.StringAttribute{
+				Description: "Whether uppercase or lowercase should sort first",
+				Computed:    true,
+			},
+			"strength": schema.Int64Attribute{
+				Description: "Comparison level (1-5)",
+				Computed:    true,
+			},
+			"numeric_ordering": schema.BoolAttribute{
+				Description: "Whether to compare numeric strings as numbers",
+				Computed:    true,
+			},
+			"alternate": schema.StringAttribute{
+				Description: "Whether spaces and punctuation are considered base characters",
+				Computed:    true,
+			},
+			"max_variable": schema.StringAttribute{
+				Description: "Which characters are affected by 'alternate'",
+				Computed:    true,
+			},
+			"backwards": schema.BoolAttribute{
+				Description: "Whether to reverse secondary differences",
+				Computed:    true,
+			},
+			"version": schema.StringAttribute{
+				Description: "Version of the collation rules",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// indexDataSourceAttributes returns the computed index attributes, except the ones identifying the index.
+func indexDataSourceAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			Description: "Index identifier in the format database.collection.index_name",
+			Computed:    true,
+		},
+		"collation": collationDataSourceAttribute(),
+		"keys": schema.ListNestedAttribute{
+			Description: "Index key fields in the order of the compound index",
+			Computed:    true,
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{
+					"field": schema.StringAttribute{
+						Description: "Indexed field name",
+						Computed:    true,
+					},
+					"type": schema.StringAttribute{
+						Description: "Index key type",
+						Computed:    true,
+					},
+				},
+			},
+		},
+		"keys_json": schema.StringAttribute{
+			Description: "JSON encoded index key document in the order of the fields",
+			Computed:    true,
+		},
+		"unique": schema.BoolAttribute{
+			Description: "Whether the index enforces unique values",
+			Computed:    true,
+		},
+		"partial_filter_expression": schema.StringAttribute{
+			Description: "JSON encoded filter expression that limits indexed documents.",
+			Computed:    true,
+		},
+		"storage_engine": schema.StringAttribute{
+			Description: "JSON encoded storage engine options of the index.",
+			Computed:    true,
+		},
+		"expire_after_seconds": schema.Int32Attribute{
+			Description: "TTL in seconds for TTL indexes",
+			Computed:    true,
+		},
+		"sparse": schema.BoolAttribute{
+			Description: "Whether the index is sparse",
+			Computed:    true,
+		},
+		"sphere_index_version": schema.Int32Attribute{
+			Description: "The index version number for a 2dsphere index",
+			Computed:    true,
+		},
+		"wildcard_projection": schema.MapAttribute{
+			Description: "Field inclusion/exclusion for wildcard index (1=include, 0=exclude)",
+			Computed:    true,
+			ElementType: types.Int32Type,
+		},
+		"columnstore_projection": schema.MapAttribute{
+			Description: "Field inclusion/exclusion for columnstore index (1=include, 0=exclude)",
+			Computed:    true,
+			ElementType: types.Int32Type,
+		},
+		"hidden": schema.BoolAttribute{
+			Description: "Whether the index is hidden from the query planner",
+			Computed:    true,
+		},
+		"bucket_size": schema.Int32Attribute{
+			Description: "Grouping distance of the geoHaystack index locations",
+			Computed:    true,
+		},
+		"bits": schema.Int32Attribute{
+			Description: "Number of bits for geospatial index precision",
+			Computed:    true,
+		},
+		"min": schema.Float64Attribute{
+			Description: "Minimum value for 2d index",
+			Computed:    true,
+		},
+		"max": schema.Float64Attribute{
+			Description: "Maximum value for 2d index",
+			Computed:    true,
+		},
+		"weights": schema.MapAttribute{
+			Description: "Field weights for text index",
+			Computed:    true,
+			ElementType: types.Int32Type,
+		},
+		"default_language": schema.StringAttribute{
+			Description: "Default language for text index",
+			Computed:    true,
+		},
+		"language_override": schema.StringAttribute{
+			Description: "Field name that contains document language",
+			Computed:    true,
+		},
+		"text_index_version": schema.Int32Attribute{
+			Description: "Text index version number",
+			Computed:    true,
+		},
+		"version": schema.Int32Attribute{
+			Description: "Index format version",
+			Computed:    true,
+		},
+	}
+}
+
+func (d *IndexDataSource) Metadata(
+	_ context.Context,
+	req datasource.MetadataRequest,
+	resp *datasource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_index"
+}
+
+func (d *IndexDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	attributes := indexDataSourceAttributes()
+
+	attributes["database"] = schema.StringAttribute{
+		Description: "Database name",
+		Required:    true,
+	}
+	attributes["collection"] = schema.StringAttribute{
+		Description: "Collection name",
+		Required:    true,
+	}
+	attributes["name"] = schema.StringAttribute{
+		Description: "Index name",
+		Required:    true,
+	}
+
+	resp.Schema = schema.Schema{
+		Description: "Reads an existing MongoDB index without managing it",
+		Attributes:  attributes,
+	}
+}
+
+func (d *IndexDataSource) Configure(
+	_ context.Context,
+	req datasource.ConfigureRequest,
+	resp *datasource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	p, ok := req.ProviderData.(*MongodbProvider)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *MongodbProvider, got: %T. "+
+				"Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = p.client
+}
+
+func (d *IndexDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if d.client == nil {
+		resp.Diagnostics.AddError(
+			"MongoDB client is not configured",
+			"Expected configured MongoDB client. Please report this issue to the provider developers.",
+		)
+
+		return
+	}
+
+	var config IndexModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	index, err := d.client.GetIndex(ctx, &mongodb.GetIndexOptions{
+		Name:       config.Name.ValueString(),
+		Database:   config.Database.ValueString(),
+		Collection: config.Collection.ValueString(),
+	})
+	if err != nil {
+		if errors.As(err, &mongodb.NotFoundError{}) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("name"),
+				"Index not found",
+				fmt.Sprintf("Index %q does not exist in %s.%s", config.Name.ValueString(),
+					config.Database.ValueString(), config.Collection.ValueString()),
+			)
+
+			return
+		}
+
+		resp.Diagnostics.AddError("Failed to read index", err.Error())
+
+		return
+	}
+
+	resp.Diagnostics.Append(config.updateState(ctx, index)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
+}
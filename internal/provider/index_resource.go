@@ -5,45 +5,50 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"maps"
+	"regexp"
+	"slices"
 	"strings"
+	"time"
 
-	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/int32validator"
-	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/float64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int32planmodifier"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectdefault"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
-	"go.mongodb.org/mongo-driver/v2/mongo/options"
+	"go.mongodb.org/mongo-driver/v2/bson"
 
 	"github.com/megum1n/terraform-provider-mongodb/internal/mongodb"
 )
 
 var (
-	_ resource.Resource                   = &IndexResource{}
-	_ resource.ResourceWithConfigure      = &IndexResource{}
-	_ resource.ResourceWithImportState    = &IndexResource{}
-	_ resource.ResourceWithValidateConfig = &IndexResource{}
+	_ resource.Resource                     = &IndexResource{}
+	_ resource.ResourceWithConfigure        = &IndexResource{}
+	_ resource.ResourceWithImportState      = &IndexResource{}
+	_ resource.ResourceWithValidateConfig   = &IndexResource{}
+	_ resource.ResourceWithConfigValidators = &IndexResource{}
+	_ resource.ResourceWithModifyPlan       = &IndexResource{}
 )
 
+var indexKeyTypes = []string{
+	"1", "-1", "2d", "2dsphere", "text", "hashed", mongodb.GeoHaystackIndexType, mongodb.ColumnstoreIndexType,
+}
+
 func NewIndexResource() resource.Resource {
 	return &IndexResource{}
 }
@@ -52,38 +57,19 @@ type IndexResource struct {
 	client *mongodb.Client
 }
 
-type CollationModel struct {
-	Locale          types.String `tfsdk:"locale"`
-	CaseLevel       types.Bool   `tfsdk:"case_level"`
-	CaseFirst       types.String `tfsdk:"case_first"`
-	Strength        types.Int64  `tfsdk:"strength"`
-	NumericOrdering types.Bool   `tfsdk:"numeric_ordering"`
-	Alternate       types.String `tfsdk:"alternate"`
-	MaxVariable     types.String `tfsdk:"max_variable"`
-	Backwards       types.Bool   `tfsdk:"backwards"`
-}
-
-func (c CollationModel) AttributeTypes() map[string]attr.Type {
-	return map[string]attr.Type{
-		"locale":           types.StringType,
-		"case_level":       types.BoolType,
-		"case_first":       types.StringType,
-		"strength":         types.Int64Type,
-		"numeric_ordering": types.BoolType,
-		"alternate":        types.StringType,
-		"max_variable":     types.StringType,
-		"backwards":        types.BoolType,
-	}
-}
-
-type IndexResourceModel struct {
+// IndexModel holds the index attributes read back from the server. It is shared by the resource and the data source.
+type IndexModel struct {
+	ID                      types.String  `tfsdk:"id"`
 	Database                types.String  `tfsdk:"database"`
 	Collection              types.String  `tfsdk:"collection"`
 	Name                    types.String  `tfsdk:"name"`
-	Keys                    types.Map     `tfsdk:"keys"`
+	Keys                    types.List    `tfsdk:"keys"`
+	KeysJSON                types.String  `tfsdk:"keys_json"`
 	Collation               types.Object  `tfsdk:"collation"`
 	WildcardProjection      types.Map     `tfsdk:"wildcard_projection"`
+	ColumnstoreProjection   types.Map     `tfsdk:"columnstore_projection"`
 	PartialFilterExpression types.String  `tfsdk:"partial_filter_expression"`
+	StorageEngine           types.String  `tfsdk:"storage_engine"`
 	Unique                  types.Bool    `tfsdk:"unique"`
 	Sparse                  types.Bool    `tfsdk:"sparse"`
 	Hidden                  types.Bool    `tfsdk:"hidden"`
@@ -92,21 +78,126 @@ type IndexResourceModel struct {
 	Bits                    types.Int32   `tfsdk:"bits"`
 	Min                     types.Float64 `tfsdk:"min"`
 	Max                     types.Float64 `tfsdk:"max"`
+	BucketSize              types.Int32   `tfsdk:"bucket_size"`
 	Weights                 types.Map     `tfsdk:"weights"`
 	DefaultLanguage         types.String  `tfsdk:"default_language"`
 	LanguageOverride        types.String  `tfsdk:"language_override"`
 	TextIndexVersion        types.Int32   `tfsdk:"text_index_version"`
+	Version                 types.Int32   `tfsdk:"version"`
+}
+
+type IndexKeyModel struct {
+	Field types.String `tfsdk:"field"`
+	Type  types.String `tfsdk:"type"`
+}
+
+func (k IndexKeyModel) AttributeTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"field": types.StringType,
+		"type":  types.StringType,
+	}
+}
+
+// indexKeysListValue converts the index keys to the "keys" list keeping the order of the fields.
+func indexKeysListValue(ctx context.Context, keys mongodb.IndexKeys) (types.List, diag.Diagnostics) {
+	models := make([]IndexKeyModel, 0, len(keys))
+
+	for i, keyType := range keys.Types() {
+		models = append(models, IndexKeyModel{
+			Field: types.StringValue(keys[i].Key),
+			Type:  types.StringValue(keyType),
+		})
+	}
+
+	return types.ListValueFrom(ctx, types.ObjectType{AttrTypes: IndexKeyModel{}.AttributeTypes()}, models)
+}
+
+type IndexResourceModel struct {
+	IndexModel
+
+	ExpectedMultikey        types.Bool   `tfsdk:"expected_multikey"`
+	AcknowledgeSparseUnique types.Bool   `tfsdk:"acknowledge_sparse_unique"`
+	CommitQuorum            types.String `tfsdk:"commit_quorum"`
+	IgnoreOptionDrift       types.List   `tfsdk:"ignore_option_drift"`
+	WaitForReady            types.Bool   `tfsdk:"wait_for_ready"`
+	WaitForReadyTimeout     types.String `tfsdk:"wait_for_ready_timeout"`
+}
+
+// ignorableIndexOptions are the options which can be listed in ignore_option_drift. They keep the value
+// of the configuration or of the previous state instead of the one read from the server.
+var ignorableIndexOptions = map[string]func(d, s *IndexModel){
+	"collation":                 func(d, s *IndexModel) { keepKnown(&d.Collation, s.Collation) },
+	"wildcard_projection":       func(d, s *IndexModel) { keepKnown(&d.WildcardProjection, s.WildcardProjection) },
+	"columnstore_projection":    func(d, s *IndexModel) { keepKnown(&d.ColumnstoreProjection, s.ColumnstoreProjection) },
+	"partial_filter_expression": func(d, s *IndexModel) { keepKnown(&d.PartialFilterExpression, s.PartialFilterExpression) },
+	"storage_engine":            func(d, s *IndexModel) { keepKnown(&d.StorageEngine, s.StorageEngine) },
+	"unique":                    func(d, s *IndexModel) { keepKnown(&d.Unique, s.Unique) },
+	"sparse":                    func(d, s *IndexModel) { keepKnown(&d.Sparse, s.Sparse) },
+	"sphere_index_version":      func(d, s *IndexModel) { keepKnown(&d.SphereVersion, s.SphereVersion) },
+	"bits":                      func(d, s *IndexModel) { keepKnown(&d.Bits, s.Bits) },
+	"min":                       func(d, s *IndexModel) { keepKnown(&d.Min, s.Min) },
+	"max":                       func(d, s *IndexModel) { keepKnown(&d.Max, s.Max) },
+	"bucket_size":               func(d, s *IndexModel) { keepKnown(&d.BucketSize, s.BucketSize) },
+	"weights":                   func(d, s *IndexModel) { keepKnown(&d.Weights, s.Weights) },
+	"default_language":          func(d, s *IndexModel) { keepKnown(&d.DefaultLanguage, s.DefaultLanguage) },
+	"language_override":         func(d, s *IndexModel) { keepKnown(&d.LanguageOverride, s.LanguageOverride) },
+	"text_index_version":        func(d, s *IndexModel) { keepKnown(&d.TextIndexVersion, s.TextIndexVersion) },
+}
+
+// keepKnown sets the value unless it's not known yet, e.g. an option the server defaults on create.
+func keepKnown[T attr.Value](dst *T, value T) {
+	if !value.IsUnknown() {
+		*dst = value
+	}
+}
+
+// ignoredOptions returns the options listed in ignore_option_drift.
+func (ind *IndexResourceModel) ignoredOptions(ctx context.Context) ([]string, diag.Diagnostics) {
+	var out []string
+
+	if ind.IgnoreOptionDrift.IsNull() || ind.IgnoreOptionDrift.IsUnknown() {
+		return out, nil
+	}
+
+	diags := ind.IgnoreOptionDrift.ElementsAs(ctx, &out, false)
+
+	return out, diags
 }
 
+// updateState reads the index like IndexModel.updateState, except for the options listed in ignore_option_drift,
+// which keep their current value unless it's not known yet.
 func (ind *IndexResourceModel) updateState(ctx context.Context, index *mongodb.Index) diag.Diagnostics {
+	ignored, diags := ind.ignoredOptions(ctx)
+	if diags.HasError() {
+		return diags
+	}
+
+	prior := ind.IndexModel
+
+	diags.Append(ind.IndexModel.updateState(ctx, index)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	for _, name := range ignored {
+		if restore, ok := ignorableIndexOptions[name]; ok {
+			restore(&ind.IndexModel, &prior)
+		}
+	}
+
+	return diags
+}
+
+func (ind *IndexModel) updateState(ctx context.Context, index *mongodb.Index) diag.Diagnostics {
 	diags := diag.Diagnostics{}
 
 	ind.Database = types.StringValue(index.Database)
 	ind.Collection = types.StringValue(index.Collection)
 	ind.Name = types.StringValue(index.Name)
+	ind.ID = types.StringValue(indexID(index.Database, index.Collection, index.Name))
 
 	// Parse keys
-	keys, d := types.MapValueFrom(ctx, types.StringType, index.Keys.ToStringMap())
+	keys, d := indexKeysListValue(ctx, index.Keys)
 
 	diags.Append(d...)
 	if diags.HasError() {
@@ -115,27 +206,24 @@ func (ind *IndexResourceModel) updateState(ctx context.Context, index *mongodb.I
 
 	ind.Keys = keys
 
-	// Parse collation
-	if index.Options.Collation != nil {
-		collation := CollationModel{
-			Locale:          types.StringValue(index.Options.Collation.Locale),
-			CaseLevel:       types.BoolValue(index.Options.Collation.CaseLevel),
-			CaseFirst:       types.StringValue(index.Options.Collation.CaseFirst),
-			Strength:        types.Int64Value(int64(index.Options.Collation.Strength)),
-			NumericOrdering: types.BoolValue(index.Options.Collation.NumericOrdering),
-			Alternate:       types.StringValue(index.Options.Collation.Alternate),
-			MaxVariable:     types.StringValue(index.Options.Collation.MaxVariable),
-			Backwards:       types.BoolValue(index.Options.Collation.Backwards),
-		}
-
-		ind.Collation, d = types.ObjectValueFrom(ctx, collation.AttributeTypes(), collation)
-
-		diags.Append(d...)
-		if diags.HasError() {
+	// Keep the configured JSON when it describes the same key document
+	if !isSameIndexKeysJSON(ind.KeysJSON, index.Keys) {
+		keysJSON, err := index.Keys.JSON()
+		if err != nil {
+			diags.AddError("Failed to parse index keys", err.Error())
+
 			return diags
 		}
-	} else {
-		ind.Collation = types.ObjectNull(CollationModel{}.AttributeTypes())
+
+		ind.KeysJSON = types.StringValue(keysJSON)
+	}
+
+	// Parse collation
+	ind.Collation, d = collationObjectValue(ctx, ind.Collation, index.Options.Collation)
+
+	diags.Append(d...)
+	if diags.HasError() {
+		return diags
 	}
 
 	// Parse wildcard projection
@@ -148,16 +236,46 @@ func (ind *IndexResourceModel) updateState(ctx context.Context, index *mongodb.I
 
 	ind.WildcardProjection = wildcardProjection
 
-	// Parse partial filter expression
-	if len(index.Options.PartialFilterExpression) > 0 {
-		partialFilterExpression, err := json.Marshal(index.Options.PartialFilterExpression)
+	columnstoreProjection, d := types.MapValueFrom(ctx, types.Int32Type, index.Options.ColumnstoreProjection)
+
+	diags.Append(d...)
+	if diags.HasError() {
+		return diags
+	}
+
+	ind.ColumnstoreProjection = columnstoreProjection
+
+	// Parse partial filter expression, keeping the configured JSON when it describes the same filter
+	if len(index.Options.PartialFilterExpression) == 0 {
+		ind.PartialFilterExpression = types.StringNull()
+	} else {
+		partialFilterExpression, err := mongodb.DocumentJSON(index.Options.PartialFilterExpression)
 		if err != nil {
 			diags.AddError("Failed to parse partial filter expression", err.Error())
 
 			return diags
 		}
 
-		ind.PartialFilterExpression = types.StringValue(string(partialFilterExpression))
+		if !isSameJSON(ind.PartialFilterExpression, []byte(partialFilterExpression)) {
+			ind.PartialFilterExpression = types.StringValue(partialFilterExpression)
+		}
+	}
+
+	// Parse storage engine options, keeping the configured JSON when it describes the same options,
+	// including a WiredTiger configString normalized by the server
+	if len(index.Options.StorageEngine) == 0 {
+		ind.StorageEngine = types.StringNull()
+	} else {
+		storageEngine, err := mongodb.DocumentJSON(index.Options.StorageEngine)
+		if err != nil {
+			diags.AddError("Failed to parse storage engine options", err.Error())
+
+			return diags
+		}
+
+		if !isSameStorageEngine(ind.StorageEngine, []byte(storageEngine)) {
+			ind.StorageEngine = types.StringValue(storageEngine)
+		}
 	}
 
 	// Parse weights
@@ -179,13 +297,14 @@ func (ind *IndexResourceModel) updateState(ctx context.Context, index *mongodb.I
 		ind.Sparse = types.BoolPointerValue(index.Options.Sparse)
 	}
 
-	if index.Options.Hidden != nil {
-		ind.Hidden = types.BoolPointerValue(index.Options.Hidden)
+	// Keep the unset option null instead of false
+	if index.Options.Hidden != nil && *index.Options.Hidden {
+		ind.Hidden = types.BoolValue(true)
+	} else if !ind.Hidden.IsNull() {
+		ind.Hidden = types.BoolValue(false)
 	}
 
-	if index.Options.SphereVersion != nil {
-		ind.SphereVersion = types.Int32PointerValue(index.Options.SphereVersion)
-	}
+	ind.SphereVersion = types.Int32PointerValue(index.Options.SphereVersion)
 
 	if index.Options.Bits != nil {
 		ind.Bits = types.Int32PointerValue(index.Options.Bits)
@@ -199,10 +318,13 @@ func (ind *IndexResourceModel) updateState(ctx context.Context, index *mongodb.I
 		ind.Max = types.Float64PointerValue(index.Options.Max)
 	}
 
-	if index.Options.TextIndexVersion != nil {
-		ind.TextIndexVersion = types.Int32PointerValue(index.Options.TextIndexVersion)
+	if index.Options.BucketSize != nil {
+		ind.BucketSize = types.Int32PointerValue(index.Options.BucketSize)
 	}
 
+	ind.TextIndexVersion = types.Int32PointerValue(index.Options.TextIndexVersion)
+	ind.Version = types.Int32PointerValue(index.Options.IndexVersion)
+
 	ind.ExpireAfterSeconds = types.Int32PointerValue(index.Options.ExpireAfterSeconds)
 	ind.DefaultLanguage = types.StringPointerValue(index.Options.DefaultLanguage)
 	ind.LanguageOverride = types.StringPointerValue(index.Options.LanguageOverride)
@@ -210,6 +332,69 @@ func (ind *IndexResourceModel) updateState(ctx context.Context, index *mongodb.I
 	return diags
 }
 
+// indexKeys returns the index keys from either "keys" or "keys_json" attribute.
+// Nil is returned when the keys are not known yet.
+func (ind *IndexResourceModel) indexKeys(ctx context.Context) (mongodb.IndexKeys, diag.Diagnostics) {
+	diags := diag.Diagnostics{}
+
+	if !ind.KeysJSON.IsNull() && !ind.KeysJSON.IsUnknown() && (ind.Keys.IsNull() || ind.Keys.IsUnknown()) {
+		keys, err := mongodb.ParseIndexKeysJSON(ind.KeysJSON.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("keys_json"), "Failed to parse index keys json", err.Error())
+
+			return nil, diags
+		}
+
+		return keys, diags
+	}
+
+	if ind.Keys.IsNull() || ind.Keys.IsUnknown() {
+		return nil, diags
+	}
+
+	var models []IndexKeyModel
+
+	diags.Append(ind.Keys.ElementsAs(ctx, &models, false)...)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	indexKeys := mongodb.IndexKeys{}
+
+	for _, model := range models {
+		// Unknown until the referenced values are known
+		if model.Field.IsUnknown() || model.Type.IsUnknown() {
+			return nil, diags
+		}
+
+		indexKeys = append(indexKeys, mongodb.NewIndexKey(model.Field.ValueString(), model.Type.ValueString()))
+	}
+
+	return indexKeys, diags
+}
+
+// defaultIndexBuildTimeout limits the wait for the index build when wait_for_ready_timeout is not set.
+const defaultIndexBuildTimeout = 10 * time.Minute
+
+// indexID builds the import identifier. The index name goes last, the import tries every split of the
+// collection and the index name, so the names containing dots like a.b_1 are preserved.
+func indexID(database, collection, name string) string {
+	return strings.Join([]string{database, collection, name}, ".")
+}
+
+func isSameIndexKeysJSON(current types.String, keys mongodb.IndexKeys) bool {
+	if current.IsNull() || current.IsUnknown() {
+		return false
+	}
+
+	currentKeys, err := mongodb.ParseIndexKeysJSON(current.ValueString())
+	if err != nil {
+		return false
+	}
+
+	return currentKeys.Equal(keys)
+}
+
 func (r *IndexResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_index"
 }
@@ -217,7 +402,17 @@ func (r *IndexResource) Metadata(_ context.Context, req resource.MetadataRequest
 func (r *IndexResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "Manages MongoDB indexes",
+		Version:     1,
 		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Import identifier in the format database.collection.index_name. " +
+					"The indexes of the collections whose name contains dots are imported with a JSON object: " +
+					`{"database":"db","collection":"a.b","name":"c_1"}`,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
 			"database": schema.StringAttribute{
 				Description: "Database name",
 				Required:    true,
@@ -239,108 +434,45 @@ func (r *IndexResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
-			"collation": schema.SingleNestedAttribute{
-				Description: "Collation settings for string comparison",
+			"collation": collationAttribute(),
+			"keys": schema.ListNestedAttribute{
+				Description: "Index key fields in the order of the compound index",
 				Optional:    true,
 				Computed:    true,
-				Default:     objectdefault.StaticValue(types.ObjectNull(CollationModel{}.AttributeTypes())),
-				PlanModifiers: []planmodifier.Object{
-					objectplanmodifier.RequiresReplace(),
-				},
-				Attributes: map[string]schema.Attribute{
-					"locale": schema.StringAttribute{
-						Description: "The locale for string comparison",
-						Required:    true,
-						PlanModifiers: []planmodifier.String{
-							stringplanmodifier.RequiresReplace(),
-						},
-					},
-					"case_level": schema.BoolAttribute{
-						Description: "Whether to consider case in the 'Level=1' comparison",
-						Optional:    true,
-						Computed:    true,
-						Default:     booldefault.StaticBool(false),
-						PlanModifiers: []planmodifier.Bool{
-							boolplanmodifier.RequiresReplace(),
-						},
-					},
-					"case_first": schema.StringAttribute{
-						Description: "Whether uppercase or lowercase should sort first",
-						Optional:    true,
-						Computed:    true,
-						Default:     stringdefault.StaticString("off"),
-						PlanModifiers: []planmodifier.String{
-							stringplanmodifier.RequiresReplace(),
-						},
-						Validators: []validator.String{
-							stringvalidator.OneOf("upper", "lower", "off"),
-						},
-					},
-					"strength": schema.Int64Attribute{
-						Description: "Comparison level (1-5)",
-						Optional:    true,
-						Computed:    true,
-						Default:     int64default.StaticInt64(3),
-						PlanModifiers: []planmodifier.Int64{
-							int64planmodifier.RequiresReplace(),
-						},
-						Validators: []validator.Int64{
-							int64validator.Between(1, 5),
-						},
-					},
-					"numeric_ordering": schema.BoolAttribute{
-						Description: "Whether to compare numeric strings as numbers",
-						Optional:    true,
-						Computed:    true,
-						Default:     booldefault.StaticBool(false),
-						PlanModifiers: []planmodifier.Bool{
-							boolplanmodifier.RequiresReplace(),
-						},
-					},
-					"alternate": schema.StringAttribute{
-						Description: "Whether spaces and punctuation are considered base characters",
-						Optional:    true,
-						Computed:    true,
-						Default:     stringdefault.StaticString("non-ignorable"),
-						PlanModifiers: []planmodifier.String{
-							stringplanmodifier.RequiresReplace(),
-						},
-						Validators: []validator.String{
-							stringvalidator.OneOf("non-ignorable", "shifted"),
-						},
-					},
-					"max_variable": schema.StringAttribute{
-						Description: "Which characters are affected by 'alternate'",
-						Optional:    true,
-						Computed:    true,
-						Default:     stringdefault.StaticString("punct"),
-						PlanModifiers: []planmodifier.String{
-							stringplanmodifier.RequiresReplace(),
-						},
-						Validators: []validator.String{
-							stringvalidator.OneOf("punct", "space"),
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+					listplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"field": schema.StringAttribute{
+							Description: "Indexed field name. Use the dot notation for the fields of embedded " +
+								"documents and arrays, e.g. address.zip. The name is sent verbatim. " +
+								"$** or a path ending with .$** is a wildcard key, which can be combined with " +
+								"other ascending or descending keys since MongoDB 7.0",
+							Required: true,
 						},
-					},
-					"backwards": schema.BoolAttribute{
-						Description: "Whether to reverse secondary differences",
-						Optional:    true,
-						Computed:    true,
-						Default:     booldefault.StaticBool(false),
-						PlanModifiers: []planmodifier.Bool{
-							boolplanmodifier.RequiresReplace(),
+						"type": schema.StringAttribute{
+							Description: "Index key type: " + strings.Join(indexKeyTypes, ", "),
+							Required:    true,
+							Validators: []validator.String{
+								stringvalidator.OneOf(indexKeyTypes...),
+							},
 						},
 					},
 				},
 			},
-			"keys": schema.MapAttribute{
-				Description: "Index key fields",
-				Required:    true,
-				ElementType: types.StringType,
-				PlanModifiers: []planmodifier.Map{
-					mapplanmodifier.RequiresReplace(),
-				},
-				Validators: []validator.Map{
-					mapvalidator.ValueStringsAre(stringvalidator.OneOf("1", "-1", "2d", "2dsphere", "text", "hashed")),
+			"keys_json": schema.StringAttribute{
+				Description: "JSON encoded index key document, e.g. {\"a\": 1, \"b\": -1}. " +
+					"The order and the types of the fields are preserved",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
 				},
 			},
 			"unique": schema.BoolAttribute{
@@ -351,17 +483,34 @@ func (r *IndexResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 				},
 			},
 			"partial_filter_expression": schema.StringAttribute{
-				Description: "JSON encoded filter expression that limits indexed documents.",
-				Optional:    true,
+				Description: "JSON encoded filter expression that limits indexed documents. " +
+					"Supports equality, $exists, $gt, $gte, $lt, $lte, $type, $and, $or and $in, " +
+					"which takes an array of strings, numbers or booleans of the same type",
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"storage_engine": schema.StringAttribute{
+				Description: "JSON encoded storage engine options of the index, " +
+					`e.g. {"wiredTiger": {"configString": "block_compressor=zstd"}}`,
+				Optional: true,
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
 			"expire_after_seconds": schema.Int32Attribute{
-				Description: "TTL in seconds for TTL indexes",
-				Optional:    true,
+				Description: "TTL in seconds for TTL indexes. Changed in place with collMod, " +
+					"adding or removing the TTL requires replacing the index",
+				Optional: true,
 				PlanModifiers: []planmodifier.Int32{
-					int32planmodifier.RequiresReplace(),
+					int32planmodifier.RequiresReplaceIf(
+						func(_ context.Context, req planmodifier.Int32Request, resp *int32planmodifier.RequiresReplaceIfFuncResponse) {
+							resp.RequiresReplace = req.StateValue.IsNull() != req.PlanValue.IsNull()
+						},
+						"Adding or removing the TTL requires replacing the index",
+						"Adding or removing the TTL requires replacing the index",
+					),
 				},
 				Validators: []validator.Int32{
 					int32validator.AtLeast(0),
@@ -375,9 +524,11 @@ func (r *IndexResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 				},
 			},
 			"sphere_index_version": schema.Int32Attribute{
-				Description: "The index version number for a 2dsphere index",
+				Description: "The index version number for a 2dsphere index. Defaults to the latest version",
 				Optional:    true,
+				Computed:    true,
 				PlanModifiers: []planmodifier.Int32{
+					int32planmodifier.UseStateForUnknown(),
 					int32planmodifier.RequiresReplace(),
 				},
 				Validators: []validator.Int32{
@@ -385,7 +536,23 @@ func (r *IndexResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 				},
 			},
 			"wildcard_projection": schema.MapAttribute{
-				Description: "Field inclusion/exclusion for wildcard index (1=include, 0=exclude)",
+				Description: "Field inclusion/exclusion for wildcard index (1=include, 0=exclude). " +
+					"Requires the $** key. The fields are either all included or all excluded, except for _id. " +
+					"For a compound wildcard index, it must leave out the other keys of the index",
+				Optional:    true,
+				ElementType: types.Int32Type,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.Map{
+					mapvalidator.ValueInt32sAre(
+						int32validator.OneOf(0, 1),
+					),
+				},
+			},
+			"columnstore_projection": schema.MapAttribute{
+				Description: "Field inclusion/exclusion for columnstore index (1=include, 0=exclude). " +
+					"Columnstore indexes require MongoDB 6.3 or later",
 				Optional:    true,
 				ElementType: types.Int32Type,
 				PlanModifiers: []planmodifier.Map{
@@ -398,10 +565,18 @@ func (r *IndexResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 				},
 			},
 			"hidden": schema.BoolAttribute{
-				Description: "Whether the index should be hidden from the query planner",
+				Description: "Whether the index should be hidden from the query planner. Changed in place with collMod",
 				Optional:    true,
-				PlanModifiers: []planmodifier.Bool{
-					boolplanmodifier.RequiresReplace(),
+			},
+			"bucket_size": schema.Int32Attribute{
+				Description: "Distance in the units of the location field within which the geoHaystack index groups " +
+					"the location values. Required by the geoHaystack indexes, which MongoDB 5.0 removed",
+				Optional: true,
+				PlanModifiers: []planmodifier.Int32{
+					int32planmodifier.RequiresReplace(),
+				},
+				Validators: []validator.Int32{
+					int32validator.AtLeast(1),
 				},
 			},
 			"bits": schema.Int32Attribute{
@@ -415,30 +590,28 @@ func (r *IndexResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 				},
 			},
 			"min": schema.Float64Attribute{
-				Description: "Minimum value for 2d index",
-				Optional:    true,
+				Description: "Minimum coordinate of a 2d index, inclusive. Defaults to -180. " +
+					"Values beyond the longitude range are allowed for non geographic coordinate spaces",
+				Optional: true,
 				PlanModifiers: []planmodifier.Float64{
 					float64planmodifier.RequiresReplace(),
 				},
-				Validators: []validator.Float64{
-					float64validator.Between(-180.0, 180.0),
-				},
 			},
 			"max": schema.Float64Attribute{
-				Description: "Maximum value for 2d index",
-				Optional:    true,
+				Description: "Maximum coordinate of a 2d index, inclusive. Defaults to 180. " +
+					"Values beyond the longitude range are allowed for non geographic coordinate spaces",
+				Optional: true,
 				PlanModifiers: []planmodifier.Float64{
 					float64planmodifier.RequiresReplace(),
 				},
-				Validators: []validator.Float64{
-					float64validator.Between(-180.0, 180.0),
-				},
 			},
 			"weights": schema.MapAttribute{
-				Description: "Field weights for text index",
+				Description: "Field weights for text index. Defaults to 1 for every indexed field",
 				Optional:    true,
+				Computed:    true,
 				ElementType: types.Int32Type,
 				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.UseStateForUnknown(),
 					mapplanmodifier.RequiresReplace(),
 				},
 				Validators: []validator.Map{
@@ -446,33 +619,127 @@ func (r *IndexResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 				},
 			},
 			"default_language": schema.StringAttribute{
-				Description: "Default language for text index",
+				Description: "Default language for text index. Defaults to english",
 				Optional:    true,
+				Computed:    true,
 				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
 			"language_override": schema.StringAttribute{
-				Description: "Field name that contains document language",
+				Description: "Field name that contains document language. Defaults to language",
 				Optional:    true,
+				Computed:    true,
 				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
 			"text_index_version": schema.Int32Attribute{
-				Description: "Text index version number",
+				Description: "Text index version number. Defaults to the latest version",
 				Optional:    true,
+				Computed:    true,
 				PlanModifiers: []planmodifier.Int32{
+					int32planmodifier.UseStateForUnknown(),
 					int32planmodifier.RequiresReplace(),
 				},
 				Validators: []validator.Int32{
 					int32validator.Between(1, 3),
 				},
 			},
+			"version": schema.Int32Attribute{
+				Description: "Index format version set by the server",
+				Computed:    true,
+				PlanModifiers: []planmodifier.Int32{
+					int32planmodifier.UseStateForUnknown(),
+				},
+			},
+			"expected_multikey": schema.BoolAttribute{
+				Description: "Acknowledge that the indexed fields hold arrays and the index becomes multikey. " +
+					"Only one field of a compound multikey index can hold an array",
+				Optional: true,
+			},
+			"acknowledge_sparse_unique": schema.BoolAttribute{
+				Description: "Acknowledge that a sparse unique index does not enforce uniqueness " +
+					"for the documents missing the indexed field. Silences the warning for such indexes",
+				Optional: true,
+			},
+			"commit_quorum": schema.StringAttribute{
+				Description: "Number of data bearing voting members, \"majority\" or \"votingMembers\", " +
+					"which must finish building the index before it is ready. Lowering it lets the build complete " +
+					"while a member is down. Only used when the index is created, requires MongoDB 4.4 or later",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.Any(
+						stringvalidator.RegexMatches(regexp.MustCompile(`^[0-9]+$`), "must be a number of members"),
+						stringvalidator.OneOf("majority", "votingMembers"),
+					),
+				},
+			},
+			"wait_for_ready": schema.BoolAttribute{
+				Description: "Wait after the creation until the index build is complete, so the resources depending " +
+					"on the index only proceed once it's usable. The build is tracked with $currentOp on the primary, " +
+					"which completes it once the commit_quorum members are done",
+				Optional: true,
+			},
+			"wait_for_ready_timeout": schema.StringAttribute{
+				Description: fmt.Sprintf("Maximum time to wait for the index build, e.g. 30m. "+
+					"Defaults to %s. Requires wait_for_ready. The operation_timeout of the provider doesn't limit "+
+					"the wait, only each check of the build", defaultIndexBuildTimeout),
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("wait_for_ready")),
+				},
+			},
+			"ignore_option_drift": schema.ListAttribute{
+				Description: "Options which are not compared with the index read from the server, e.g. to adopt " +
+					"an existing index whose options differ from the configuration. A listed option keeps " +
+					"its configured value and changing it doesn't replace the index. Supported options: " +
+					strings.Join(slices.Sorted(maps.Keys(ignorableIndexOptions)), ", "),
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.List{
+					listvalidator.UniqueValues(),
+					listvalidator.ValueStringsAre(
+						stringvalidator.OneOf(slices.Sorted(maps.Keys(ignorableIndexOptions))...),
+					),
+				},
+			},
 		},
 	}
 }
 
+// ModifyPlan keeps the index when only the options listed in ignore_option_drift change.
+func (r *IndexResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() || len(resp.RequiresReplace) == 0 {
+		return
+	}
+
+	var plan IndexResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ignored, d := plan.ignoredOptions(ctx)
+
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() || len(ignored) == 0 {
+		return
+	}
+
+	// The nested attributes of the collation are matched by their root attribute
+	resp.RequiresReplace = slices.DeleteFunc(resp.RequiresReplace, func(p path.Path) bool {
+		steps := p.Steps()
+
+		return len(steps) > 0 && slices.ContainsFunc(ignored, func(name string) bool {
+			return steps[0].Equal(path.PathStepAttributeName(name))
+		})
+	})
+}
+
 func (r *IndexResource) ValidateConfig(
 	ctx context.Context,
 	req resource.ValidateConfigRequest,
@@ -485,66 +752,524 @@ func (r *IndexResource) ValidateConfig(
 		return
 	}
 
-	if config.Keys.IsNull() || config.Keys.IsUnknown() {
+	validateCollation(config.Collation, path.Root("collation"), &resp.Diagnostics)
+
+	indexKeys, d := config.indexKeys(ctx)
+
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() || indexKeys == nil {
 		return
 	}
 
-	var keysMap map[string]string
+	keysPath := path.Root("keys")
+	if !config.KeysJSON.IsNull() {
+		keysPath = path.Root("keys_json")
+	}
 
-	resp.Diagnostics.Append(config.Keys.ElementsAs(ctx, &keysMap, false)...)
-	if resp.Diagnostics.HasError() {
+	if !validateIndexKeys(indexKeys, keysPath, &resp.Diagnostics) {
 		return
 	}
 
-	if !config.ExpireAfterSeconds.IsNull() {
-		isWildcard := false
-		if _, exists := keysMap["$**"]; exists {
-			isWildcard = true
+	keysMap := indexKeys.ToStringMap()
+
+	if config.Sparse.ValueBool() && config.Unique.ValueBool() && !config.AcknowledgeSparseUnique.ValueBool() {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("sparse"),
+			"Sparse unique index",
+			"A sparse unique index skips the documents missing the indexed field, "+
+				"so any number of such documents is allowed, while explicit null values are still deduplicated. "+
+				"Set acknowledge_sparse_unique = true to confirm this behavior.",
+		)
+	}
+
+	if !config.PartialFilterExpression.IsNull() {
+		if config.Sparse.ValueBool() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("sparse"),
+				"Invalid partial index configuration",
+				"sparse can't be combined with partial_filter_expression. A partial index already skips the "+
+					"documents not matching the filter, use {\"field\": {\"$exists\": true}} to skip the documents "+
+					"missing the field",
+			)
 		}
 
-		if isWildcard {
-			resp.Diagnostics.AddError(
-				"Invalid TTL Index Configuration",
-				"TTL index (expire_after_seconds) cannot be used with wildcard indexes")
+		if config.Unique.ValueBool() {
+			resp.Diagnostics.AddAttributeWarning(
+				path.Root("partial_filter_expression"),
+				"Partial unique index",
+				"The uniqueness is only enforced for the documents matching partial_filter_expression. "+
+					"Documents not matching the filter can hold duplicate values, and the queries must include "+
+					"the filter condition for the index to be used.",
+			)
+		}
+	}
 
-			return
+	if config.ExpectedMultikey.ValueBool() {
+		for field, keyType := range keysMap {
+			if keyType == "hashed" {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("expected_multikey"),
+					"Invalid multikey index configuration",
+					fmt.Sprintf("Hashed index key %q cannot be built over an array field", field),
+				)
+
+				return
+			}
+		}
+
+		if len(keysMap) > 1 {
+			resp.Diagnostics.AddAttributeWarning(
+				path.Root("expected_multikey"),
+				"Compound multikey index",
+				"MongoDB allows at most one array field per document in a compound index. "+
+					"Documents holding arrays in more than one indexed field will fail the index build "+
+					"and subsequent inserts.",
+			)
 		}
 	}
 
-	// Validate partial filter expression operators
-	if config.PartialFilterExpression.IsNull() {
+	validateColumnstoreIndex(&config, indexKeys, &resp.Diagnostics)
+	validate2dBounds(&config, &resp.Diagnostics)
+
+	if !config.StorageEngine.IsNull() && !config.StorageEngine.IsUnknown() {
+		if _, err := mongodb.ParseDocumentJSON(config.StorageEngine.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("storage_engine"),
+				"Failed to parse storage engine json",
+				err.Error(),
+			)
+		}
+	}
+
+	validateWildcardIndex(&config, indexKeys, keysPath, &resp.Diagnostics)
+	validateIndexTypeOptions(&config, indexKeys, &resp.Diagnostics)
+
+	if !config.ExpireAfterSeconds.IsNull() && len(indexKeys.WildcardFields()) > 0 {
+		resp.Diagnostics.AddError(
+			"Invalid TTL Index Configuration",
+			"TTL index (expire_after_seconds) cannot be used with wildcard indexes")
+
 		return
 	}
 
-	var filterExpr map[string]interface{}
+	// The server accepts these TTL indexes, but never removes the documents
+	if !config.ExpireAfterSeconds.IsNull() && (len(indexKeys) > 1 || indexKeys[0].Key == "_id") {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("expire_after_seconds"),
+			"Invalid TTL Index Configuration",
+			"expire_after_seconds is ignored by MongoDB for compound indexes and indexes on _id, "+
+				"a TTL index must have a single key other than _id",
+		)
 
-	err := json.Unmarshal([]byte(config.PartialFilterExpression.ValueString()), &filterExpr)
-	if err != nil {
-		resp.Diagnostics.AddError("Failed to parse partial filter expression json", err.Error())
+		return
+	}
 
+	// Validate partial filter expression operators
+	if config.PartialFilterExpression.IsNull() {
 		return
 	}
-}
 
-func (r *IndexResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
-	if req.ProviderData == nil {
+	if config.PartialFilterExpression.IsUnknown() {
 		return
 	}
 
-	p, ok := req.ProviderData.(*MongodbProvider)
-	if !ok {
-		resp.Diagnostics.AddError(
-			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *MongodbProvider, got: %T.", req.ProviderData),
+	filter, err := mongodb.ParseDocumentJSON(config.PartialFilterExpression.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("partial_filter_expression"),
+			"Failed to parse partial filter expression json",
+			err.Error(),
 		)
 
 		return
 	}
 
-	r.client = p.client
+	validatePartialFilter(filter, "", &resp.Diagnostics)
 }
 
-func (r *IndexResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+// indexTypeOptions are the options which only apply to an index with a key of the given type.
+// MongoDB stores them for the other indexes as well, where they have no effect.
+var indexTypeOptions = []struct {
+	attribute string
+	keyType   string
+	value     func(config *IndexResourceModel) attr.Value
+}{
+	{"bits", "2d", func(c *IndexResourceModel) attr.Value { return c.Bits }},
+	{"min", "2d", func(c *IndexResourceModel) attr.Value { return c.Min }},
+	{"max", "2d", func(c *IndexResourceModel) attr.Value { return c.Max }},
+	{"bucket_size", mongodb.GeoHaystackIndexType, func(c *IndexResourceModel) attr.Value { return c.BucketSize }},
+	{"sphere_index_version", "2dsphere", func(c *IndexResourceModel) attr.Value { return c.SphereVersion }},
+	{"weights", "text", func(c *IndexResourceModel) attr.Value { return c.Weights }},
+	{"default_language", "text", func(c *IndexResourceModel) attr.Value { return c.DefaultLanguage }},
+	{"language_override", "text", func(c *IndexResourceModel) attr.Value { return c.LanguageOverride }},
+	{"text_index_version", "text", func(c *IndexResourceModel) attr.Value { return c.TextIndexVersion }},
+}
+
+// validateIndexTypeOptions rejects the options which would be silently ignored for the index type,
+// and the geoHaystack indexes without bucket_size.
+func validateIndexTypeOptions(config *IndexResourceModel, keys mongodb.IndexKeys, diags *diag.Diagnostics) {
+	for _, option := range indexTypeOptions {
+		if option.value(config).IsNull() || keys.HasType(option.keyType) {
+			continue
+		}
+
+		diags.AddAttributeError(
+			path.Root(option.attribute),
+			"Invalid index option",
+			fmt.Sprintf("%s only applies to %s indexes, MongoDB ignores it for the other index types",
+				option.attribute, option.keyType),
+		)
+	}
+
+	if keys.HasType(mongodb.GeoHaystackIndexType) && config.BucketSize.IsNull() {
+		diags.AddAttributeError(
+			path.Root("bucket_size"),
+			"Missing index option",
+			"bucket_size is required by geoHaystack indexes",
+		)
+	}
+}
+
+// validateColumnstoreIndex checks that the columnstore key is the only key and the projection is set for it only.
+func validateColumnstoreIndex(config *IndexResourceModel, keys mongodb.IndexKeys, diags *diag.Diagnostics) {
+	columnstore := keys.HasType(mongodb.ColumnstoreIndexType)
+
+	if columnstore && len(keys) > 1 {
+		diags.AddAttributeError(
+			path.Root("keys"),
+			"Invalid columnstore index configuration",
+			"A columnstore index can't be compound",
+		)
+	}
+
+	if !columnstore && !config.ColumnstoreProjection.IsNull() {
+		diags.AddAttributeError(
+			path.Root("columnstore_projection"),
+			"Invalid columnstore index configuration",
+			"columnstore_projection is only supported for columnstore indexes",
+		)
+	}
+}
+
+// validateWildcardIndex checks that the wildcard keys are ascending or descending and that the projection
+// is set for a $** key only. The projection either includes or excludes the fields, except for _id.
+// A compound wildcard index has a single wildcard key, and the projection of its $** key must leave out
+// the other keys of the index.
+func validateWildcardIndex(
+	config *IndexResourceModel,
+	keys mongodb.IndexKeys,
+	keysPath path.Path,
+	diags *diag.Diagnostics,
+) {
+	keysMap := keys.ToStringMap()
+	wildcardFields := keys.WildcardFields()
+
+	if len(wildcardFields) > 1 {
+		diags.AddAttributeError(
+			keysPath,
+			"Invalid wildcard index configuration",
+			fmt.Sprintf("An index can have only one wildcard key, got: %s", strings.Join(wildcardFields, ", ")),
+		)
+
+		return
+	}
+
+	compound := keys.IsCompoundWildcard()
+
+	for field, keyType := range keysMap {
+		if (compound || slices.Contains(wildcardFields, field)) && keyType != "1" && keyType != "-1" {
+			diags.AddAttributeError(
+				keysPath,
+				"Invalid wildcard index configuration",
+				fmt.Sprintf("Key %q of a wildcard index has type %q, expected 1 or -1", field, keyType),
+			)
+		}
+	}
+
+	if config.WildcardProjection.IsUnknown() {
+		return
+	}
+
+	if config.WildcardProjection.IsNull() {
+		if compound && slices.Contains(wildcardFields, mongodb.WildcardIndexField) {
+			diags.AddAttributeError(
+				path.Root("wildcard_projection"),
+				"Invalid wildcard index configuration",
+				"A compound wildcard index on $** requires a wildcard_projection leaving out the other keys",
+			)
+		}
+
+		return
+	}
+
+	if !slices.Contains(wildcardFields, mongodb.WildcardIndexField) {
+		diags.AddAttributeError(
+			path.Root("wildcard_projection"),
+			"Invalid wildcard index configuration",
+			"wildcard_projection is only supported with the $** key, "+
+				"a wildcard key on a path like a.$** already selects the indexed fields",
+		)
+
+		return
+	}
+
+	var (
+		included []string
+		excluded []string
+	)
+
+	for field, value := range config.WildcardProjection.Elements() {
+		v, ok := value.(types.Int32)
+		if !ok || v.IsUnknown() || field == "_id" {
+			continue
+		}
+
+		if v.ValueInt32() == 1 {
+			included = append(included, field)
+		} else {
+			excluded = append(excluded, field)
+		}
+	}
+
+	if len(included) > 0 && len(excluded) > 0 {
+		slices.Sort(included)
+		slices.Sort(excluded)
+
+		diags.AddAttributeError(
+			path.Root("wildcard_projection"),
+			"Invalid wildcard index configuration",
+			fmt.Sprintf("wildcard_projection can't both include (%s) and exclude (%s) fields, only _id can be "+
+				"set either way", strings.Join(included, ", "), strings.Join(excluded, ", ")),
+		)
+
+		return
+	}
+
+	if !compound {
+		return
+	}
+
+	for _, key := range keys {
+		if key.Key == mongodb.WildcardIndexField {
+			continue
+		}
+
+		if slices.Contains(included, key.Key) || (len(included) == 0 && !slices.Contains(excluded, key.Key)) {
+			diags.AddAttributeError(
+				path.Root("wildcard_projection"),
+				"Invalid wildcard index configuration",
+				fmt.Sprintf("wildcard_projection must leave out %q, which is already a key of the compound index",
+					key.Key),
+			)
+		}
+	}
+}
+
+// validate2dBounds checks that the 2d index range is not empty. Bounds beyond the longitude range
+// only cause a warning, as they are valid for the legacy coordinate spaces of non geographic 2d indexes.
+func validate2dBounds(config *IndexResourceModel, diags *diag.Diagnostics) {
+	if config.Min.IsUnknown() || config.Max.IsUnknown() {
+		return
+	}
+
+	minimum := config.Min.ValueFloat64Pointer()
+	maximum := config.Max.ValueFloat64Pointer()
+
+	if minimum != nil && maximum != nil && *minimum >= *maximum {
+		diags.AddAttributeError(
+			path.Root("max"),
+			"Invalid 2d index bounds",
+			fmt.Sprintf("max (%g) must be greater than min (%g)", *maximum, *minimum),
+		)
+
+		return
+	}
+
+	for attribute, value := range map[string]*float64{"min": minimum, "max": maximum} {
+		if value != nil && (*value < -180 || *value > 180) {
+			diags.AddAttributeWarning(
+				path.Root(attribute),
+				"2d index bounds beyond the longitude range",
+				fmt.Sprintf("%s = %g is outside of [-180, 180]. This is fine for a non geographic coordinate space, "+
+					"but spherical queries like $nearSphere expect longitude and latitude pairs", attribute, *value),
+			)
+		}
+	}
+}
+
+// partialFilterOperators are the operators supported in partial filter expressions,
+// see https://www.mongodb.com/docs/manual/core/index-partial/
+var partialFilterOperators = []string{"$eq", "$exists", "$gt", "$gte", "$lt", "$lte", "$type", "$and", "$or", "$in"}
+
+// validatePartialFilter walks the parsed filter checking the operators and that $in holds arrays
+// of strings, numbers or booleans, all of the same type, so the filter is read back unchanged.
+func validatePartialFilter(filter bson.D, location string, diags *diag.Diagnostics) {
+	for _, element := range filter {
+		fieldLocation := element.Key
+		if location != "" {
+			fieldLocation = location + "." + element.Key
+		}
+
+		if strings.HasPrefix(element.Key, "$") && !slices.Contains(partialFilterOperators, element.Key) {
+			diags.AddAttributeError(
+				path.Root("partial_filter_expression"),
+				"Invalid partial filter expression",
+				fmt.Sprintf("%s: operator %s is not supported in partial filter expressions, expected one of: %s",
+					fieldLocation, element.Key, strings.Join(partialFilterOperators, ", ")),
+			)
+
+			continue
+		}
+
+		switch value := element.Value.(type) {
+		case bson.D:
+			validatePartialFilter(value, fieldLocation, diags)
+		case bson.A:
+			if element.Key == "$in" {
+				validatePartialFilterArray(value, fieldLocation, diags)
+
+				continue
+			}
+
+			for i, nested := range value {
+				if document, ok := nested.(bson.D); ok {
+					validatePartialFilter(document, fmt.Sprintf("%s[%d]", fieldLocation, i), diags)
+				}
+			}
+		default:
+			if element.Key == "$in" {
+				diags.AddAttributeError(
+					path.Root("partial_filter_expression"),
+					"Invalid partial filter expression",
+					fmt.Sprintf("%s must be an array", fieldLocation),
+				)
+			}
+		}
+	}
+}
+
+func validatePartialFilterArray(values bson.A, location string, diags *diag.Diagnostics) {
+	var firstType string
+
+	for i, v := range values {
+		var valueType string
+
+		switch v.(type) {
+		case string:
+			valueType = "string"
+		case int32, int64, float64:
+			valueType = "number"
+		case bool:
+			valueType = "boolean"
+		default:
+			diags.AddAttributeError(
+				path.Root("partial_filter_expression"),
+				"Invalid partial filter expression",
+				fmt.Sprintf("%s[%d] must be a string, a number or a boolean", location, i),
+			)
+
+			return
+		}
+
+		if firstType == "" {
+			firstType = valueType
+		}
+
+		if valueType != firstType {
+			diags.AddAttributeError(
+				path.Root("partial_filter_expression"),
+				"Invalid partial filter expression",
+				fmt.Sprintf("%s must hold values of the same type, got a %s and a %s", location, firstType, valueType),
+			)
+
+			return
+		}
+	}
+}
+
+func (r *IndexResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.ExactlyOneOf(
+			path.MatchRoot("keys"),
+			path.MatchRoot("keys_json"),
+		),
+	}
+}
+
+// validateIndexKeys checks the key types and that every field is indexed only once.
+func validateIndexKeys(keys mongodb.IndexKeys, attribute path.Path, diags *diag.Diagnostics) bool {
+	fields := map[string]bool{}
+
+	for field, keyType := range keys.ToStringMap() {
+		if !slices.Contains(indexKeyTypes, keyType) {
+			diags.AddAttributeError(
+				attribute,
+				"Invalid index key type",
+				fmt.Sprintf("Field %q has type %q, expected one of: %s", field, keyType, strings.Join(indexKeyTypes, ", ")),
+			)
+		}
+	}
+
+	for _, key := range keys {
+		if err := validateIndexKeyField(key.Key); err != nil {
+			diags.AddAttributeError(
+				attribute,
+				"Invalid index key field",
+				fmt.Sprintf("Field %q: %s", key.Key, err),
+			)
+		}
+
+		if fields[key.Key] {
+			diags.AddAttributeError(
+				attribute,
+				"Duplicate index key",
+				fmt.Sprintf("Field %q is specified more than once", key.Key),
+			)
+		}
+
+		fields[key.Key] = true
+	}
+
+	return !diags.HasError()
+}
+
+// validateIndexKeyField checks the dot notation of the field. Only the wildcard segment may start with $.
+func validateIndexKeyField(field string) error {
+	segments := strings.Split(field, ".")
+
+	for i, segment := range segments {
+		switch {
+		case segment == "":
+			return errors.New("the dot notation must not have empty segments")
+		case segment == "$**" && i == len(segments)-1:
+		case strings.HasPrefix(segment, "$"):
+			return fmt.Errorf("segment %q must not start with $, only the last segment can be the $** wildcard",
+				segment)
+		}
+	}
+
+	return nil
+}
+
+func (r *IndexResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	p, ok := req.ProviderData.(*MongodbProvider)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *MongodbProvider, got: %T.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = p.client
+}
+
+func (r *IndexResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	if !r.checkClient(resp.Diagnostics) {
 		return
 	}
@@ -561,6 +1286,8 @@ func (r *IndexResource) Create(ctx context.Context, req resource.CreateRequest,
 		Collection: plan.Collection.ValueString(),
 		Name:       plan.Name.ValueString(),
 
+		CommitQuorum: plan.CommitQuorum.ValueString(),
+
 		Options: mongodb.IndexOptions{
 			Unique:             plan.Unique.ValueBoolPointer(),
 			Sparse:             plan.Sparse.ValueBoolPointer(),
@@ -570,44 +1297,32 @@ func (r *IndexResource) Create(ctx context.Context, req resource.CreateRequest,
 			Bits:               plan.Bits.ValueInt32Pointer(),
 			Min:                plan.Min.ValueFloat64Pointer(),
 			Max:                plan.Max.ValueFloat64Pointer(),
+			BucketSize:         plan.BucketSize.ValueInt32Pointer(),
 			DefaultLanguage:    plan.DefaultLanguage.ValueStringPointer(),
 			LanguageOverride:   plan.LanguageOverride.ValueStringPointer(),
 			TextIndexVersion:   plan.TextIndexVersion.ValueInt32Pointer(),
 		},
 	}
 
-	if !plan.Collation.IsNull() && !plan.Collation.IsUnknown() {
-		collation := &CollationModel{}
-		resp.Diagnostics.Append(plan.Collation.As(ctx, collation, basetypes.ObjectAsOptions{})...)
-
-		if resp.Diagnostics.HasError() {
-			return
-		}
+	collation, d := collationOptions(ctx, plan.Collation)
 
-		index.Options.Collation = &options.Collation{
-			Locale:          collation.Locale.ValueString(),
-			CaseLevel:       collation.CaseLevel.ValueBool(),
-			CaseFirst:       collation.CaseFirst.ValueString(),
-			Strength:        int(collation.Strength.ValueInt64()),
-			NumericOrdering: collation.NumericOrdering.ValueBool(),
-			Alternate:       collation.Alternate.ValueString(),
-			MaxVariable:     collation.MaxVariable.ValueString(),
-			Backwards:       collation.Backwards.ValueBool(),
-		}
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	// Parse keys
-	if !plan.Keys.IsNull() && !plan.Keys.IsUnknown() {
-		indexKeys := map[string]string{}
-		resp.Diagnostics.Append(plan.Keys.ElementsAs(ctx, &indexKeys, false)...)
+	index.Options.Collation = collation
 
-		if resp.Diagnostics.HasError() {
-			return
-		}
+	// Parse keys
+	indexKeys, d := plan.indexKeys(ctx)
 
-		index.Keys = mongodb.ConvertMap(indexKeys, true)
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
+	index.Keys = indexKeys
+
 	// Parse WildcardProjection
 	if !plan.WildcardProjection.IsNull() && !plan.WildcardProjection.IsUnknown() {
 		wildcardProjection := make(map[string]int32)
@@ -620,14 +1335,40 @@ func (r *IndexResource) Create(ctx context.Context, req resource.CreateRequest,
 		index.Options.WildcardProjection = wildcardProjection
 	}
 
+	// Parse ColumnstoreProjection
+	if !plan.ColumnstoreProjection.IsNull() && !plan.ColumnstoreProjection.IsUnknown() {
+		columnstoreProjection := make(map[string]int32)
+		resp.Diagnostics.Append(plan.ColumnstoreProjection.ElementsAs(ctx, &columnstoreProjection, false)...)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		index.Options.ColumnstoreProjection = columnstoreProjection
+	}
+
 	// Parse PartialFilterExpression
 	if !plan.PartialFilterExpression.IsNull() && !plan.PartialFilterExpression.IsUnknown() {
-		err := json.Unmarshal([]byte(plan.PartialFilterExpression.ValueString()), &index.Options.PartialFilterExpression)
+		filter, err := mongodb.ParseDocumentJSON(plan.PartialFilterExpression.ValueString())
 		if err != nil {
 			resp.Diagnostics.AddError("Failed to parse partial filter expression json", err.Error())
 
 			return
 		}
+
+		index.Options.PartialFilterExpression = filter
+	}
+
+	// Parse StorageEngine
+	if !plan.StorageEngine.IsNull() && !plan.StorageEngine.IsUnknown() {
+		storageEngine, err := mongodb.ParseDocumentJSON(plan.StorageEngine.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to parse storage engine json", err.Error())
+
+			return
+		}
+
+		index.Options.StorageEngine = storageEngine
 	}
 
 	// Parse Weights
@@ -642,16 +1383,55 @@ func (r *IndexResource) Create(ctx context.Context, req resource.CreateRequest,
 		index.Options.Weights = weights
 	}
 
+	waitTimeout := defaultIndexBuildTimeout
+	if !plan.WaitForReadyTimeout.IsNull() {
+		waitTimeout = durationValue(plan.WaitForReadyTimeout, path.Root("wait_for_ready_timeout"), &resp.Diagnostics)
+	}
+
+	resp.Diagnostics.Append(r.checkNotView(ctx, index.Database, index.Collection)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	dbIndex, err := r.client.CreateIndex(ctx, index)
 	if err != nil {
+		if errors.As(err, &mongodb.ParallelArraysError{}) {
+			resp.Diagnostics.AddError(
+				"Cannot index parallel arrays",
+				err.Error(),
+			)
+
+			return
+		}
+
 		resp.Diagnostics.AddError(
-			"Error creating MongoDB index",
+			commandErrorSummary(err, "Error creating MongoDB index"),
 			err.Error(),
 		)
 
 		return
 	}
 
+	if plan.WaitForReady.ValueBool() {
+		err = r.client.WaitForIndexBuild(ctx, &mongodb.GetIndexOptions{
+			Name:       index.Name,
+			Database:   index.Database,
+			Collection: index.Collection,
+		}, waitTimeout)
+		if err != nil {
+			summary := commandErrorSummary(err, "Error waiting for the MongoDB index build")
+			if errors.As(err, &mongodb.IndexBuildTimeoutError{}) {
+				summary = "Index build not finished"
+			}
+
+			// The index is not saved in the state, as a tainted index would be dropped by the next apply.
+			// Creating it again with the same definition waits for the build in progress instead
+			resp.Diagnostics.AddError(summary, err.Error())
+
+			return
+		}
+	}
+
 	resp.Diagnostics.Append(plan.updateState(ctx, dbIndex)...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -701,12 +1481,66 @@ func (r *IndexResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
 
+// Update changes the options which can be modified in place with collMod: hidden and expire_after_seconds.
+// The other index options require replacement, while the attributes which only drive the provider,
+// like commit_quorum, ignore_option_drift or wait_for_ready, are only stored in the state.
 func (r *IndexResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	// MongoDB indexes are immutable, so just setting the plan as the new state
-	var plan IndexResourceModel
-	diags := req.Plan.Get(ctx, &plan)
+	if !r.checkClient(resp.Diagnostics) {
+		return
+	}
+
+	var plan, state IndexResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	options := &mongodb.GetIndexOptions{
+		Primary:    true,
+		Name:       plan.Name.ValueString(),
+		Database:   plan.Database.ValueString(),
+		Collection: plan.Collection.ValueString(),
+	}
+
+	if plan.Hidden.ValueBool() != state.Hidden.ValueBool() {
+		_, err := r.client.SetIndexHidden(ctx, options, plan.Hidden.ValueBool())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				commandErrorSummary(err, "Error updating MongoDB index"),
+				err.Error(),
+			)
+
+			return
+		}
+	}
+
+	// Adding or removing the TTL replaces the index, so both values are set here
+	if !plan.ExpireAfterSeconds.IsNull() && !plan.ExpireAfterSeconds.Equal(state.ExpireAfterSeconds) {
+		_, err := r.client.SetIndexTTL(ctx, options, plan.ExpireAfterSeconds.ValueInt32())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				commandErrorSummary(err, "Error updating MongoDB index TTL"),
+				err.Error(),
+			)
+
+			return
+		}
+	}
 
-	resp.Diagnostics.Append(diags...)
+	index, err := r.client.GetIndex(ctx, options)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading MongoDB index",
+			err.Error(),
+		)
+
+		return
+	}
+
+	resp.Diagnostics.Append(plan.updateState(ctx, index)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -733,7 +1567,7 @@ func (r *IndexResource) Delete(ctx context.Context, req resource.DeleteRequest,
 	})
 	if err != nil {
 		resp.Diagnostics.AddError(
-			"Error deleting MongoDB index",
+			commandErrorSummary(err, "Error deleting MongoDB index"),
 			err.Error(),
 		)
 	}
@@ -747,32 +1581,44 @@ func (r *IndexResource) ImportState(
 	req resource.ImportStateRequest,
 	resp *resource.ImportStateResponse,
 ) {
-	idParts := strings.Split(req.ID, ".")
-	if len(idParts) < 3 {
-		resp.Diagnostics.AddError(
-			"Invalid import ID",
-			"Import ID should be in the format: database.collection.index_name",
-		)
+	ids, err := parseIndexImportID(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid import ID", err.Error())
 
 		return
 	}
 
-	database := idParts[0]
-	collection := idParts[1]
-	indexName := strings.Join(idParts[2:], ".")
+	plan := IndexResourceModel{
+		IgnoreOptionDrift: types.ListNull(types.StringType),
+	}
 
-	var plan IndexResourceModel
+	var index *mongodb.Index
+
+	for _, id := range ids {
+		index, err = r.client.GetIndex(ctx, &mongodb.GetIndexOptions{
+			Name:       id.Name,
+			Database:   id.Database,
+			Collection: id.Collection,
+		})
+		if err == nil || !mongodb.IsNotFoundError(err) {
+			break
+		}
+	}
 
-	index, err := r.client.GetIndex(ctx, &mongodb.GetIndexOptions{
-		Name:       indexName,
-		Database:   database,
-		Collection: collection,
-	})
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error importing index",
-			fmt.Sprintf("Failed to read index %s: %s", req.ID, err),
-		)
+		detail := fmt.Sprintf("Failed to read index %s: %s", req.ID, err)
+
+		if len(ids) > 1 && mongodb.IsNotFoundError(err) {
+			tried := make([]string, 0, len(ids))
+
+			for _, id := range ids {
+				tried = append(tried, fmt.Sprintf("index %q of %s.%s", id.Name, id.Database, id.Collection))
+			}
+
+			detail += ". Tried: " + strings.Join(tried, "; ")
+		}
+
+		resp.Diagnostics.AddError("Error importing index", detail)
 
 		return
 	}
@@ -785,6 +1631,111 @@ func (r *IndexResource) ImportState(
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
 
+// indexImportID is the JSON form of the import identifier, unambiguous when the collection name contains dots.
+type indexImportID struct {
+	Database   string `json:"database"`
+	Collection string `json:"collection"`
+	Name       string `json:"name"`
+}
+
+// parseIndexImportID accepts the database.collection.index_name format, or a JSON object
+// like {"database":"db","collection":"a.b","name":"c_1"} recognized by the leading brace.
+// The dotted format is ambiguous when the collection or the index name contains dots, e.g. the index a.b_1,
+// so every possible split is returned, the longest index name first.
+func parseIndexImportID(value string) ([]indexImportID, error) {
+	if strings.HasPrefix(strings.TrimSpace(value), "{") {
+		id := indexImportID{}
+
+		decoder := json.NewDecoder(strings.NewReader(value))
+		decoder.DisallowUnknownFields()
+
+		err := decoder.Decode(&id)
+		if err != nil {
+			return nil, fmt.Errorf("import ID %q is not a valid JSON object with the database, "+
+				"collection and name keys: %w", value, err)
+		}
+
+		if id.Database == "" || id.Collection == "" || id.Name == "" {
+			return nil, fmt.Errorf("import ID %q must set the database, collection and name keys", value)
+		}
+
+		return []indexImportID{id}, nil
+	}
+
+	// Tolerate the whitespace and the trailing dot of a copied identifier
+	idParts := strings.Split(strings.TrimSuffix(strings.TrimSpace(value), "."), ".")
+	if len(idParts) < 3 || slices.Contains(idParts, "") {
+		return nil, fmt.Errorf("import ID %q should be in the format database.collection.index_name, "+
+			`or {"database":"...","collection":"...","name":"..."} when the collection name contains dots. `+
+			"Detected parts: %s", value, describeImportIDParts(idParts))
+	}
+
+	ids := make([]indexImportID, 0, len(idParts)-2)
+
+	for i := 2; i < len(idParts); i++ {
+		ids = append(ids, indexImportID{
+			Database:   idParts[0],
+			Collection: strings.Join(idParts[1:i], "."),
+			Name:       strings.Join(idParts[i:], "."),
+		})
+	}
+
+	return ids, nil
+}
+
+// describeImportIDParts lists the parts of a dotted import identifier for the error message.
+func describeImportIDParts(idParts []string) string {
+	names := []string{"database", "collection", "index name"}
+	described := make([]string, 0, max(len(idParts), len(names)))
+
+	for i, part := range idParts {
+		name := names[min(i, len(names)-1)]
+
+		if part == "" {
+			described = append(described, fmt.Sprintf("empty part %d", i+1))
+
+			continue
+		}
+
+		described = append(described, fmt.Sprintf("%s %q", name, part))
+	}
+
+	for _, name := range names[min(len(idParts), len(names)):] {
+		described = append(described, "missing "+name)
+	}
+
+	return strings.Join(described, ", ")
+}
+
+// checkNotView fails when the index target is a view, which can't be indexed.
+// A missing collection is fine, as it's created together with the index.
+func (r *IndexResource) checkNotView(ctx context.Context, database, collection string) diag.Diagnostics {
+	diags := diag.Diagnostics{}
+
+	target, err := r.client.GetCollection(ctx, &mongodb.GetCollectionOptions{
+		Name:     collection,
+		Database: database,
+	})
+	if err != nil {
+		if !errors.As(err, &mongodb.NotFoundError{}) {
+			diags.AddError("Error reading MongoDB collection", err.Error())
+		}
+
+		return diags
+	}
+
+	if target.IsView() {
+		diags.AddAttributeError(
+			path.Root("collection"),
+			"Cannot create an index on a view",
+			fmt.Sprintf("%s.%s is a view. Indexes must be created on the source collection of the view.",
+				database, collection),
+		)
+	}
+
+	return diags
+}
+
 func (r *IndexResource) checkClient(diag diag.Diagnostics) bool {
 	if r.client == nil {
 		diag.AddError(
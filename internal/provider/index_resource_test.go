@@ -0,0 +1,718 @@
+package provider
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"go.mongodb.org/mongo-driver/v2/bson"
+
+	"github.com/megum1n/terraform-provider-mongodb/internal/mongodb"
+)
+
+// testIndex returns the index read back from the server with the given options.
+func testIndex(keys mongodb.IndexKeys, options mongodb.IndexOptions) *mongodb.Index {
+	return &mongodb.Index{
+		Name:       "test_index",
+		Database:   "db",
+		Collection: "coll",
+		Keys:       keys,
+		Options:    options,
+	}
+}
+
+func TestIndexStorageEngineConfigStringNoDiff(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	readBack := bson.D{{Key: "wiredTiger", Value: bson.D{
+		{Key: "configString", Value: "prefix_compression=false,block_compressor=zstd"},
+	}}}
+
+	tests := map[string]struct {
+		configured string
+		same       bool
+	}{
+		"identical": {
+			configured: `{"wiredTiger":{"configString":"prefix_compression=false,block_compressor=zstd"}}`,
+			same:       true,
+		},
+		"entries in another order with spaces": {
+			configured: `{"wiredTiger": {"configString": "block_compressor=zstd, prefix_compression=false"}}`,
+			same:       true,
+		},
+		"trailing comma": {
+			configured: `{"wiredTiger":{"configString":"block_compressor=zstd,prefix_compression=false,"}}`,
+			same:       true,
+		},
+		"different compressor": {
+			configured: `{"wiredTiger":{"configString":"block_compressor=snappy,prefix_compression=false"}}`,
+		},
+		"missing entry": {
+			configured: `{"wiredTiger":{"configString":"block_compressor=zstd"}}`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			model := IndexModel{StorageEngine: types.StringValue(test.configured)}
+
+			diags := model.updateState(ctx, testIndex(
+				mongodb.IndexKeys{{Key: "a", Value: int32(1)}},
+				mongodb.IndexOptions{StorageEngine: readBack},
+			))
+			if diags.HasError() {
+				t.Fatalf("updateState failed: %v", diags)
+			}
+
+			kept := model.StorageEngine.ValueString() == test.configured
+			if kept != test.same {
+				t.Errorf("expected the configured value to be kept: %t, got storage_engine %s",
+					test.same, model.StorageEngine.ValueString())
+			}
+		})
+	}
+}
+
+func TestConfigStringEntriesKeepsNestedValues(t *testing.T) {
+	t.Parallel()
+
+	entries := configStringEntries("block_compressor=zstd,app_metadata=(owner=team,tier=2)")
+
+	expected := []string{"app_metadata=(owner=team,tier=2)", "block_compressor=zstd"}
+	if !slices.Equal(entries, expected) {
+		t.Errorf("expected %q, got %q", expected, entries)
+	}
+}
+
+// requiresReplace runs the plan modifiers of the index attribute for a change from state to plan.
+func requiresReplace(t *testing.T, attribute schema.Attribute, state, plan attr.Value) bool {
+	t.Helper()
+
+	ctx := context.Background()
+	// Any non null value, the modifiers only skip the creation and the destruction of the resource
+	raw := tftypes.NewValue(tftypes.Bool, true)
+	stateData := tfsdk.State{Raw: raw}
+	planData := tfsdk.Plan{Raw: raw}
+
+	var (
+		replace bool
+		diags   diag.Diagnostics
+	)
+
+	switch a := attribute.(type) {
+	case schema.BoolAttribute:
+		req := planmodifier.BoolRequest{State: stateData, Plan: planData,
+			StateValue: state.(types.Bool), PlanValue: plan.(types.Bool), ConfigValue: plan.(types.Bool)}
+
+		for _, modifier := range a.PlanModifiers {
+			resp := &planmodifier.BoolResponse{PlanValue: req.PlanValue}
+			modifier.PlanModifyBool(ctx, req, resp)
+			replace, diags = replace || resp.RequiresReplace, append(diags, resp.Diagnostics...)
+		}
+	case schema.Int32Attribute:
+		req := planmodifier.Int32Request{State: stateData, Plan: planData,
+			StateValue: state.(types.Int32), PlanValue: plan.(types.Int32), ConfigValue: plan.(types.Int32)}
+
+		for _, modifier := range a.PlanModifiers {
+			resp := &planmodifier.Int32Response{PlanValue: req.PlanValue}
+			modifier.PlanModifyInt32(ctx, req, resp)
+			replace, diags = replace || resp.RequiresReplace, append(diags, resp.Diagnostics...)
+		}
+	case schema.Float64Attribute:
+		req := planmodifier.Float64Request{State: stateData, Plan: planData,
+			StateValue: state.(types.Float64), PlanValue: plan.(types.Float64), ConfigValue: plan.(types.Float64)}
+
+		for _, modifier := range a.PlanModifiers {
+			resp := &planmodifier.Float64Response{PlanValue: req.PlanValue}
+			modifier.PlanModifyFloat64(ctx, req, resp)
+			replace, diags = replace || resp.RequiresReplace, append(diags, resp.Diagnostics...)
+		}
+	case schema.StringAttribute:
+		req := planmodifier.StringRequest{State: stateData, Plan: planData,
+			StateValue: state.(types.String), PlanValue: plan.(types.String), ConfigValue: plan.(types.String)}
+
+		for _, modifier := range a.PlanModifiers {
+			resp := &planmodifier.StringResponse{PlanValue: req.PlanValue}
+			modifier.PlanModifyString(ctx, req, resp)
+			replace, diags = replace || resp.RequiresReplace, append(diags, resp.Diagnostics...)
+		}
+	case schema.MapAttribute:
+		req := planmodifier.MapRequest{State: stateData, Plan: planData,
+			StateValue: state.(types.Map), PlanValue: plan.(types.Map), ConfigValue: plan.(types.Map)}
+
+		for _, modifier := range a.PlanModifiers {
+			resp := &planmodifier.MapResponse{PlanValue: req.PlanValue}
+			modifier.PlanModifyMap(ctx, req, resp)
+			replace, diags = replace || resp.RequiresReplace, append(diags, resp.Diagnostics...)
+		}
+	default:
+		t.Fatalf("unsupported attribute type %T", attribute)
+	}
+
+	if diags.HasError() {
+		t.Fatalf("plan modifier failed: %v", diags)
+	}
+
+	return replace
+}
+
+func TestIndexOptionMutability(t *testing.T) {
+	t.Parallel()
+
+	resp := &resource.SchemaResponse{}
+	(&IndexResource{}).Schema(context.Background(), resource.SchemaRequest{}, resp)
+
+	int32Map := func(value int32) types.Map {
+		return types.MapValueMust(types.Int32Type, map[string]attr.Value{"a": types.Int32Value(value)})
+	}
+
+	tests := map[string]struct {
+		attribute   string
+		state, plan attr.Value
+		replace     bool
+	}{
+		"unique":                  {"unique", types.BoolValue(false), types.BoolValue(true), true},
+		"sparse":                  {"sparse", types.BoolValue(false), types.BoolValue(true), true},
+		"hidden changes in place": {"hidden", types.BoolValue(false), types.BoolValue(true), false},
+		"ttl changes in place":    {"expire_after_seconds", types.Int32Value(60), types.Int32Value(120), false},
+		"adding a ttl":            {"expire_after_seconds", types.Int32Null(), types.Int32Value(60), true},
+		"removing the ttl":        {"expire_after_seconds", types.Int32Value(60), types.Int32Null(), true},
+		"partial_filter_expression": {
+			"partial_filter_expression", types.StringValue(`{"a":1}`), types.StringValue(`{"a":2}`), true,
+		},
+		"storage_engine": {
+			"storage_engine", types.StringNull(), types.StringValue(`{"wiredTiger":{}}`), true,
+		},
+		"sphere_index_version":   {"sphere_index_version", types.Int32Value(2), types.Int32Value(3), true},
+		"wildcard_projection":    {"wildcard_projection", int32Map(1), int32Map(0), true},
+		"columnstore_projection": {"columnstore_projection", int32Map(1), int32Map(0), true},
+		"bucket_size":            {"bucket_size", types.Int32Value(1), types.Int32Value(2), true},
+		"bits":                   {"bits", types.Int32Value(26), types.Int32Value(32), true},
+		"min":                    {"min", types.Float64Value(-180), types.Float64Value(-90), true},
+		"max":                    {"max", types.Float64Value(180), types.Float64Value(90), true},
+		"weights":                {"weights", int32Map(1), int32Map(10), true},
+		"default_language": {
+			"default_language", types.StringValue("english"), types.StringValue("french"), true,
+		},
+		"language_override": {
+			"language_override", types.StringValue("language"), types.StringValue("lang"), true,
+		},
+		"text_index_version": {"text_index_version", types.Int32Value(2), types.Int32Value(3), true},
+		"commit_quorum is only a create": {
+			"commit_quorum", types.StringValue("majority"), types.StringValue("1"), false,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			attribute, ok := resp.Schema.Attributes[test.attribute]
+			if !ok {
+				t.Fatalf("attribute %s not found in the schema", test.attribute)
+			}
+
+			if replace := requiresReplace(t, attribute, test.state, test.plan); replace != test.replace {
+				t.Errorf("expected %s to require replace: %t, got %t", test.attribute, test.replace, replace)
+			}
+		})
+	}
+}
+
+func TestValidateIndexTypeOptions(t *testing.T) {
+	t.Parallel()
+
+	keys := func(keyType interface{}) mongodb.IndexKeys {
+		return mongodb.IndexKeys{{Key: "location", Value: keyType}}
+	}
+	ascending := keys(int32(1))
+
+	tests := map[string]struct {
+		config    IndexResourceModel
+		keys      mongodb.IndexKeys
+		attribute string
+	}{
+		"bits on a 2d index": {
+			config: IndexResourceModel{IndexModel: IndexModel{Bits: types.Int32Value(26)}},
+			keys:   keys("2d"),
+		},
+		"bits": {
+			config:    IndexResourceModel{IndexModel: IndexModel{Bits: types.Int32Value(26)}},
+			keys:      ascending,
+			attribute: "bits",
+		},
+		"min": {
+			config:    IndexResourceModel{IndexModel: IndexModel{Min: types.Float64Value(-90)}},
+			keys:      ascending,
+			attribute: "min",
+		},
+		"max": {
+			config:    IndexResourceModel{IndexModel: IndexModel{Max: types.Float64Value(90)}},
+			keys:      ascending,
+			attribute: "max",
+		},
+		"bucket_size on geoHaystack": {
+			config: IndexResourceModel{IndexModel: IndexModel{BucketSize: types.Int32Value(1)}},
+			keys:   keys(mongodb.GeoHaystackIndexType),
+		},
+		"bucket_size": {
+			config:    IndexResourceModel{IndexModel: IndexModel{BucketSize: types.Int32Value(1)}},
+			keys:      ascending,
+			attribute: "bucket_size",
+		},
+		"geoHaystack without bucket_size": {keys: keys(mongodb.GeoHaystackIndexType), attribute: "bucket_size"},
+		"sphere_index_version on a 2dsphere index": {
+			config: IndexResourceModel{IndexModel: IndexModel{SphereVersion: types.Int32Value(3)}},
+			keys:   keys("2dsphere"),
+		},
+		"sphere_index_version": {
+			config:    IndexResourceModel{IndexModel: IndexModel{SphereVersion: types.Int32Value(3)}},
+			keys:      ascending,
+			attribute: "sphere_index_version",
+		},
+		"weights on a text index": {
+			config: IndexResourceModel{IndexModel: IndexModel{Weights: types.MapValueMust(types.Int32Type, nil)}},
+			keys:   keys("text"),
+		},
+		"weights": {
+			config:    IndexResourceModel{IndexModel: IndexModel{Weights: types.MapValueMust(types.Int32Type, nil)}},
+			keys:      ascending,
+			attribute: "weights",
+		},
+		"default_language": {
+			config:    IndexResourceModel{IndexModel: IndexModel{DefaultLanguage: types.StringValue("french")}},
+			keys:      ascending,
+			attribute: "default_language",
+		},
+		"language_override": {
+			config:    IndexResourceModel{IndexModel: IndexModel{LanguageOverride: types.StringValue("lang")}},
+			keys:      ascending,
+			attribute: "language_override",
+		},
+		"text_index_version": {
+			config:    IndexResourceModel{IndexModel: IndexModel{TextIndexVersion: types.Int32Value(3)}},
+			keys:      ascending,
+			attribute: "text_index_version",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			config := test.config
+
+			var diags diag.Diagnostics
+			validateIndexTypeOptions(&config, test.keys, &diags)
+
+			if test.attribute == "" {
+				if diags.HasError() {
+					t.Errorf("expected no error, got %v", diags)
+				}
+
+				return
+			}
+
+			if len(diags.Errors()) != 1 {
+				t.Fatalf("expected one error on %s, got %v", test.attribute, diags)
+			}
+
+			attributeDiag, ok := diags.Errors()[0].(diag.DiagnosticWithPath)
+			if !ok || !attributeDiag.Path().Equal(path.Root(test.attribute)) {
+				t.Errorf("expected the error on %s, got %v", test.attribute, diags.Errors()[0])
+			}
+		})
+	}
+}
+
+func TestValidateWildcardProjection(t *testing.T) {
+	t.Parallel()
+
+	projection := func(values map[string]int32) types.Map {
+		elements := map[string]attr.Value{}
+		for field, value := range values {
+			elements[field] = types.Int32Value(value)
+		}
+
+		return types.MapValueMust(types.Int32Type, elements)
+	}
+	wildcard := mongodb.IndexKeys{mongodb.NewIndexKey(mongodb.WildcardIndexField, "1")}
+
+	tests := map[string]struct {
+		keys       mongodb.IndexKeys
+		projection types.Map
+		valid      bool
+	}{
+		"inclusion": {
+			keys:       wildcard,
+			projection: projection(map[string]int32{"a": 1, "b.c": 1}),
+			valid:      true,
+		},
+		"exclusion": {
+			keys:       wildcard,
+			projection: projection(map[string]int32{"a": 0, "b.c": 0}),
+			valid:      true,
+		},
+		"exclusion including _id": {
+			keys:       wildcard,
+			projection: projection(map[string]int32{"a": 0, "_id": 1}),
+			valid:      true,
+		},
+		"inclusion excluding _id": {
+			keys:       wildcard,
+			projection: projection(map[string]int32{"a": 1, "_id": 0}),
+			valid:      true,
+		},
+		"inclusion and exclusion": {
+			keys:       wildcard,
+			projection: projection(map[string]int32{"a": 1, "b": 0}),
+		},
+		"non wildcard keys": {
+			keys:       mongodb.IndexKeys{mongodb.NewIndexKey("a", "1")},
+			projection: projection(map[string]int32{"a": 1}),
+		},
+		"wildcard path": {
+			keys:       mongodb.IndexKeys{mongodb.NewIndexKey("a.$**", "1")},
+			projection: projection(map[string]int32{"a.b": 1}),
+		},
+		"compound exclusion of the other key": {
+			keys:       append(mongodb.IndexKeys{mongodb.NewIndexKey("status", "1")}, wildcard...),
+			projection: projection(map[string]int32{"status": 0}),
+			valid:      true,
+		},
+		"compound inclusion of the other key": {
+			keys:       append(mongodb.IndexKeys{mongodb.NewIndexKey("status", "1")}, wildcard...),
+			projection: projection(map[string]int32{"status": 1, "a": 1}),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			config := IndexResourceModel{IndexModel: IndexModel{WildcardProjection: test.projection}}
+
+			var diags diag.Diagnostics
+			validateWildcardIndex(&config, test.keys, path.Root("keys"), &diags)
+
+			if diags.HasError() == test.valid {
+				t.Errorf("expected valid: %t, got %v", test.valid, diags)
+			}
+		})
+	}
+}
+
+func TestWildcardProjectionRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	tests := map[string]mongodb.IndexProjection{
+		"inclusion": {"a": 1, "b.c": 1, "_id": 0},
+		"exclusion": {"a": 0, "b.c": 0, "_id": 1},
+	}
+
+	for name, projection := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			model := IndexModel{}
+
+			diags := model.updateState(ctx, testIndex(
+				mongodb.IndexKeys{mongodb.NewIndexKey(mongodb.WildcardIndexField, "1")},
+				mongodb.IndexOptions{WildcardProjection: projection},
+			))
+			if diags.HasError() {
+				t.Fatalf("updateState failed: %v", diags)
+			}
+
+			var state map[string]int32
+
+			diags = model.WildcardProjection.ElementsAs(ctx, &state, false)
+			if diags.HasError() {
+				t.Fatalf("invalid wildcard_projection: %v", diags)
+			}
+
+			if !maps.Equal(state, projection) {
+				t.Errorf("expected wildcard_projection %v, got %v", projection, state)
+			}
+
+			if model.KeysJSON.ValueString() != `{"$**":1}` {
+				t.Errorf("expected the $** key, got %s", model.KeysJSON.ValueString())
+			}
+		})
+	}
+}
+
+func TestIndexKeysJSONRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	tests := map[string]struct {
+		configured string
+		expected   string
+	}{
+		"configured JSON is kept": {
+			configured: `{"b": -1, "a": 1, "loc": "2dsphere"}`,
+			expected:   `{"b": -1, "a": 1, "loc": "2dsphere"}`,
+		},
+		"numbers of another type are the same key": {
+			configured: `{"b": -1.0, "a": {"$numberLong": "1"}, "loc": "2dsphere"}`,
+			expected:   `{"b": -1.0, "a": {"$numberLong": "1"}, "loc": "2dsphere"}`,
+		},
+		"another order is a different key": {
+			configured: `{"a": 1, "b": -1, "loc": "2dsphere"}`,
+			expected:   `{"b":-1,"a":1,"loc":"2dsphere"}`,
+		},
+		"unset": {
+			expected: `{"b":-1,"a":1,"loc":"2dsphere"}`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			model := IndexModel{}
+			if test.configured != "" {
+				model.KeysJSON = types.StringValue(test.configured)
+			}
+
+			keys, err := mongodb.ParseIndexKeysJSON(`{"b": -1, "a": 1, "loc": "2dsphere"}`)
+			if err != nil {
+				t.Fatalf("failed to parse the keys: %v", err)
+			}
+
+			diags := model.updateState(ctx, testIndex(keys, mongodb.IndexOptions{}))
+			if diags.HasError() {
+				t.Fatalf("updateState failed: %v", diags)
+			}
+
+			if model.KeysJSON.ValueString() != test.expected {
+				t.Errorf("expected keys_json %s, got %s", test.expected, model.KeysJSON.ValueString())
+			}
+
+			var stateKeys []IndexKeyModel
+
+			diags = model.Keys.ElementsAs(ctx, &stateKeys, false)
+			if diags.HasError() {
+				t.Fatalf("invalid keys: %v", diags)
+			}
+
+			expectedKeys := [][2]string{{"b", "-1"}, {"a", "1"}, {"loc", "2dsphere"}}
+			for i, key := range stateKeys {
+				if key.Field.ValueString() != expectedKeys[i][0] || key.Type.ValueString() != expectedKeys[i][1] {
+					t.Errorf("key %d: expected %v, got %s %s", i, expectedKeys[i], key.Field, key.Type)
+				}
+			}
+		})
+	}
+}
+
+func TestIndexKeysRefreshNoDiff(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	tests := map[string]struct {
+		configured mongodb.IndexKeys
+		readBack   mongodb.IndexKeys
+	}{
+		"single field": {
+			configured: mongodb.IndexKeys{mongodb.NewIndexKey("a", "1")},
+			readBack:   mongodb.IndexKeys{{Key: "a", Value: 1.0}},
+		},
+		"single field descending": {
+			configured: mongodb.IndexKeys{mongodb.NewIndexKey("a", "-1")},
+			readBack:   mongodb.IndexKeys{{Key: "a", Value: int64(-1)}},
+		},
+		"compound": {
+			configured: mongodb.IndexKeys{
+				mongodb.NewIndexKey("b", "-1"),
+				mongodb.NewIndexKey("a", "1"),
+				mongodb.NewIndexKey("c", "hashed"),
+			},
+			readBack: mongodb.IndexKeys{{Key: "b", Value: -1.0}, {Key: "a", Value: int32(1)}, {Key: "c", Value: "hashed"}},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			configured, diags := indexKeysListValue(ctx, test.configured)
+			if diags.HasError() {
+				t.Fatalf("invalid keys: %v", diags)
+			}
+
+			model := IndexModel{Keys: configured}
+
+			// Refresh twice, the state must not change between the refreshes either
+			for range 2 {
+				diags = model.updateState(ctx, testIndex(test.readBack, mongodb.IndexOptions{}))
+				if diags.HasError() {
+					t.Fatalf("updateState failed: %v", diags)
+				}
+
+				if !model.Keys.Equal(configured) {
+					t.Fatalf("expected keys %s, got %s", configured, model.Keys)
+				}
+			}
+		})
+	}
+}
+
+func TestValidatePartialFilterIn(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		filter string
+		valid  bool
+	}{
+		"strings":  {filter: `{"status": {"$in": ["active", "pending"]}}`, valid: true},
+		"numbers":  {filter: `{"priority": {"$in": [1, 2.5, {"$numberLong": "3"}]}}`, valid: true},
+		"booleans": {filter: `{"archived": {"$in": [true, false]}}`, valid: true},
+		"nested in $and": {
+			filter: `{"$and": [{"status": {"$in": ["active"]}}, {"age": {"$gt": 18}}]}`,
+			valid:  true,
+		},
+		"mixed types":      {filter: `{"status": {"$in": ["active", 1]}}`},
+		"nested array":     {filter: `{"status": {"$in": [["active"]]}}`},
+		"document":         {filter: `{"status": {"$in": [{"a": 1}]}}`},
+		"null":             {filter: `{"status": {"$in": [null]}}`},
+		"not an array":     {filter: `{"status": {"$in": "active"}}`},
+		"unsupported $nin": {filter: `{"status": {"$nin": ["active"]}}`},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			filter, err := mongodb.ParseDocumentJSON(test.filter)
+			if err != nil {
+				t.Fatalf("failed to parse the filter: %v", err)
+			}
+
+			var diags diag.Diagnostics
+			validatePartialFilter(filter, "", &diags)
+
+			if diags.HasError() == test.valid {
+				t.Errorf("expected valid: %t, got %v", test.valid, diags)
+			}
+		})
+	}
+}
+
+func TestPartialFilterInRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	tests := map[string]string{
+		"strings": `{"status": {"$in": ["active", "pending"]}}`,
+		"numbers": `{"priority": {"$in": [1, 2, 3]}}`,
+	}
+
+	for name, configured := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			filter, err := mongodb.ParseDocumentJSON(configured)
+			if err != nil {
+				t.Fatalf("failed to parse the filter: %v", err)
+			}
+
+			// Read the filter back like listIndexes returns it
+			spec, err := bson.Marshal(bson.D{
+				{Key: "name", Value: "test_index"},
+				{Key: "key", Value: bson.D{{Key: "a", Value: int32(1)}}},
+				{Key: "partialFilterExpression", Value: filter},
+			})
+			if err != nil {
+				t.Fatalf("failed to marshal the index: %v", err)
+			}
+
+			var index mongodb.Index
+			if err := bson.Unmarshal(spec, &index); err != nil {
+				t.Fatalf("failed to unmarshal the index: %v", err)
+			}
+
+			model := IndexModel{PartialFilterExpression: types.StringValue(configured)}
+
+			diags := model.updateState(ctx, testIndex(index.Keys, index.Options))
+			if diags.HasError() {
+				t.Fatalf("updateState failed: %v", diags)
+			}
+
+			if model.PartialFilterExpression.ValueString() != configured {
+				t.Errorf("expected partial_filter_expression %s, got %s",
+					configured, model.PartialFilterExpression.ValueString())
+			}
+		})
+	}
+}
+
+func TestParseIndexImportIDDottedNames(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		id       string
+		expected []indexImportID
+	}{
+		"dotted field index": {
+			id: "app.users.addr.zip_1",
+			expected: []indexImportID{
+				{Database: "app", Collection: "users", Name: "addr.zip_1"},
+				{Database: "app", Collection: "users.addr", Name: "zip_1"},
+			},
+		},
+		"dotted collection": {
+			id: "app.events.archive.ts_1",
+			expected: []indexImportID{
+				{Database: "app", Collection: "events", Name: "archive.ts_1"},
+				{Database: "app", Collection: "events.archive", Name: "ts_1"},
+			},
+		},
+		"dotted collection and field": {
+			id: "app.events.archive.addr.zip_1",
+			expected: []indexImportID{
+				{Database: "app", Collection: "events", Name: "archive.addr.zip_1"},
+				{Database: "app", Collection: "events.archive", Name: "addr.zip_1"},
+				{Database: "app", Collection: "events.archive.addr", Name: "zip_1"},
+			},
+		},
+		"JSON with a dotted collection and field": {
+			id:       `{"database":"app","collection":"events.archive","name":"addr.zip_1"}`,
+			expected: []indexImportID{{Database: "app", Collection: "events.archive", Name: "addr.zip_1"}},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ids, err := parseIndexImportID(test.id)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !slices.Equal(ids, test.expected) {
+				t.Errorf("expected %+v, got %+v", test.expected, ids)
+			}
+		})
+	}
+}
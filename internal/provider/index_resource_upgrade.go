@@ -0,0 +1,151 @@
+package provider
+
+import (
+	"context"
+	"maps"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/megum1n/terraform-provider-mongodb/internal/mongodb"
+)
+
+var _ resource.ResourceWithUpgradeState = &IndexResource{}
+
+// indexResourceModelV0 is the state before the keys became an ordered list.
+type indexResourceModelV0 struct {
+	ID                      types.String  `tfsdk:"id"`
+	Database                types.String  `tfsdk:"database"`
+	Collection              types.String  `tfsdk:"collection"`
+	Name                    types.String  `tfsdk:"name"`
+	Keys                    types.Map     `tfsdk:"keys"`
+	KeysJSON                types.String  `tfsdk:"keys_json"`
+	Collation               types.Object  `tfsdk:"collation"`
+	WildcardProjection      types.Map     `tfsdk:"wildcard_projection"`
+	PartialFilterExpression types.String  `tfsdk:"partial_filter_expression"`
+	Unique                  types.Bool    `tfsdk:"unique"`
+	Sparse                  types.Bool    `tfsdk:"sparse"`
+	Hidden                  types.Bool    `tfsdk:"hidden"`
+	ExpireAfterSeconds      types.Int32   `tfsdk:"expire_after_seconds"`
+	SphereVersion           types.Int32   `tfsdk:"sphere_index_version"`
+	Bits                    types.Int32   `tfsdk:"bits"`
+	Min                     types.Float64 `tfsdk:"min"`
+	Max                     types.Float64 `tfsdk:"max"`
+	Weights                 types.Map     `tfsdk:"weights"`
+	DefaultLanguage         types.String  `tfsdk:"default_language"`
+	LanguageOverride        types.String  `tfsdk:"language_override"`
+	TextIndexVersion        types.Int32   `tfsdk:"text_index_version"`
+	ExpectedMultikey        types.Bool    `tfsdk:"expected_multikey"`
+	AcknowledgeSparseUnique types.Bool    `tfsdk:"acknowledge_sparse_unique"`
+}
+
+// indexAttributesAddedAfterV0 are set to null by the upgrade.
+var indexAttributesAddedAfterV0 = []string{
+	"columnstore_projection",
+	"storage_engine",
+	"commit_quorum",
+	"ignore_option_drift",
+	"version",
+	"wait_for_ready",
+	"wait_for_ready_timeout",
+	"bucket_size",
+}
+
+func (r *IndexResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	current := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, current)
+
+	// Only the keys attribute changed in version 1, the attributes added since are not in the version 0 state
+	attributes := maps.Clone(current.Schema.Attributes)
+	for _, name := range indexAttributesAddedAfterV0 {
+		delete(attributes, name)
+	}
+
+	attributes["keys"] = schema.MapAttribute{
+		Optional:    true,
+		Computed:    true,
+		ElementType: types.StringType,
+	}
+
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema:   &schema.Schema{Attributes: attributes},
+			StateUpgrader: upgradeIndexStateV0,
+		},
+	}
+}
+
+// upgradeIndexStateV0 converts the keys map to the ordered list. The keys_json attribute holds
+// the order read back from the server. Without it the fields are sorted by name, the order they were created in.
+func upgradeIndexStateV0(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	var prior indexResourceModelV0
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	keys, err := mongodb.ParseIndexKeysJSON(prior.KeysJSON.ValueString())
+	if err != nil {
+		fields := map[string]string{}
+
+		resp.Diagnostics.Append(prior.Keys.ElementsAs(ctx, &fields, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		keys = mongodb.IndexKeys{}
+
+		for _, field := range slices.Sorted(maps.Keys(fields)) {
+			keys = append(keys, mongodb.NewIndexKey(field, fields[field]))
+		}
+	}
+
+	state := IndexResourceModel{
+		IndexModel: IndexModel{
+			ID:                      prior.ID,
+			Database:                prior.Database,
+			Collection:              prior.Collection,
+			Name:                    prior.Name,
+			KeysJSON:                prior.KeysJSON,
+			Collation:               prior.Collation,
+			WildcardProjection:      prior.WildcardProjection,
+			ColumnstoreProjection:   types.MapNull(types.Int32Type),
+			PartialFilterExpression: prior.PartialFilterExpression,
+			StorageEngine:           types.StringNull(),
+			Unique:                  prior.Unique,
+			Sparse:                  prior.Sparse,
+			Hidden:                  prior.Hidden,
+			ExpireAfterSeconds:      prior.ExpireAfterSeconds,
+			SphereVersion:           prior.SphereVersion,
+			Bits:                    prior.Bits,
+			Min:                     prior.Min,
+			Max:                     prior.Max,
+			BucketSize:              types.Int32Null(),
+			Weights:                 prior.Weights,
+			DefaultLanguage:         prior.DefaultLanguage,
+			LanguageOverride:        prior.LanguageOverride,
+			TextIndexVersion:        prior.TextIndexVersion,
+			Version:                 types.Int32Null(),
+		},
+		ExpectedMultikey:        prior.ExpectedMultikey,
+		AcknowledgeSparseUnique: prior.AcknowledgeSparseUnique,
+		CommitQuorum:            types.StringNull(),
+		IgnoreOptionDrift:       types.ListNull(types.StringType),
+		WaitForReady:            types.BoolNull(),
+		WaitForReadyTimeout:     types.StringNull(),
+	}
+
+	keysList, d := indexKeysListValue(ctx, keys)
+
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state.Keys = keysList
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
@@ -0,0 +1,153 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/megum1n/terraform-provider-mongodb/internal/mongodb"
+)
+
+var _ datasource.DataSource = &IndexesDataSource{}
+var _ datasource.DataSourceWithConfigure = &IndexesDataSource{}
+
+func NewIndexesDataSource() datasource.DataSource {
+	return &IndexesDataSource{}
+}
+
+type IndexesDataSource struct {
+	client *mongodb.Client
+}
+
+type IndexesDataSourceModel struct {
+	Database   types.String          `tfsdk:"database"`
+	Collection types.String          `tfsdk:"collection"`
+	Indexes    []IndexesElementModel `tfsdk:"indexes"`
+}
+
+type IndexesElementModel struct {
+	IndexModel
+
+	Removable types.Bool `tfsdk:"removable"`
+}
+
+func (d *IndexesDataSource) Metadata(
+	_ context.Context,
+	req datasource.MetadataRequest,
+	resp *datasource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_indexes"
+}
+
+func (d *IndexesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	attributes := indexDataSourceAttributes()
+
+	attributes["database"] = schema.StringAttribute{
+		Description: "Database name",
+		Computed:    true,
+	}
+	attributes["collection"] = schema.StringAttribute{
+		Description: "Collection name",
+		Computed:    true,
+	}
+	attributes["name"] = schema.StringAttribute{
+		Description: "Index name",
+		Computed:    true,
+	}
+	attributes["removable"] = schema.BoolAttribute{
+		Description: "Whether the index can be dropped. False for the default _id index",
+		Computed:    true,
+	}
+
+	resp.Schema = schema.Schema{
+		Description: "Lists all indexes of a MongoDB collection, including the default _id index",
+		Attributes: map[string]schema.Attribute{
+			"database": schema.StringAttribute{
+				Description: "Database name",
+				Required:    true,
+			},
+			"collection": schema.StringAttribute{
+				Description: "Collection name",
+				Required:    true,
+			},
+			"indexes": schema.ListNestedAttribute{
+				Description: "Indexes of the collection",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: attributes,
+				},
+			},
+		},
+	}
+}
+
+func (d *IndexesDataSource) Configure(
+	_ context.Context,
+	req datasource.ConfigureRequest,
+	resp *datasource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	p, ok := req.ProviderData.(*MongodbProvider)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *MongodbProvider, got: %T. "+
+				"Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = p.client
+}
+
+func (d *IndexesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if d.client == nil {
+		resp.Diagnostics.AddError(
+			"MongoDB client is not configured",
+			"Expected configured MongoDB client. Please report this issue to the provider developers.",
+		)
+
+		return
+	}
+
+	var config IndexesDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	indexes, err := d.client.ListIndexes(ctx, &mongodb.ListIndexesOptions{
+		Database:   config.Database.ValueString(),
+		Collection: config.Collection.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to list indexes", err.Error())
+
+		return
+	}
+
+	config.Indexes = make([]IndexesElementModel, 0, len(indexes))
+
+	for i := range indexes {
+		element := IndexesElementModel{
+			Removable: types.BoolValue(!indexes[i].IsDefault()),
+		}
+
+		resp.Diagnostics.Append(element.updateState(ctx, &indexes[i])...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		config.Indexes = append(config.Indexes, element)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
+}
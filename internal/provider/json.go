@@ -0,0 +1,132 @@
+package provider
+
+import (
+	"encoding/json"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/megum1n/terraform-provider-mongodb/internal/mongodb"
+)
+
+// isSameJSON reports whether the configured JSON describes the same value as the one read from the server,
+// so the formatting, the order of the object fields and the numeric types do not matter.
+func isSameJSON(current types.String, other []byte) bool {
+	if current.IsNull() || current.IsUnknown() {
+		return false
+	}
+
+	var currentValue, otherValue interface{}
+
+	err := json.Unmarshal([]byte(current.ValueString()), &currentValue)
+	if err != nil {
+		return false
+	}
+
+	err = json.Unmarshal(other, &otherValue)
+	if err != nil {
+		return false
+	}
+
+	return reflect.DeepEqual(currentValue, otherValue)
+}
+
+// isSameDocument reports whether the configured extended JSON document is the one read from the server.
+// The canonical values, e.g. {"$numberLong":"5"}, are read back in the relaxed form.
+func isSameDocument(current types.String, other []byte) bool {
+	if current.IsNull() || current.IsUnknown() {
+		return false
+	}
+
+	document, err := mongodb.ParseDocumentJSON(current.ValueString())
+	if err != nil {
+		return false
+	}
+
+	relaxed, err := mongodb.DocumentJSON(document)
+	if err != nil {
+		return false
+	}
+
+	return isSameJSON(types.StringValue(relaxed), other)
+}
+
+// isSameStorageEngine reports whether the configured storage engine options are the ones read from the server.
+// WiredTiger returns the configString in its own form, so the entries of the config strings are compared
+// in any order and without the whitespace.
+func isSameStorageEngine(current types.String, other []byte) bool {
+	if current.IsNull() || current.IsUnknown() {
+		return false
+	}
+
+	var currentValue, otherValue interface{}
+
+	err := json.Unmarshal([]byte(current.ValueString()), &currentValue)
+	if err != nil {
+		return false
+	}
+
+	err = json.Unmarshal(other, &otherValue)
+	if err != nil {
+		return false
+	}
+
+	return reflect.DeepEqual(normalizeConfigStrings(currentValue), normalizeConfigStrings(otherValue))
+}
+
+// normalizeConfigStrings replaces the configString values of the storage engine options by their sorted entries.
+func normalizeConfigStrings(value interface{}) interface{} {
+	object, ok := value.(map[string]interface{})
+	if !ok {
+		return value
+	}
+
+	out := make(map[string]interface{}, len(object))
+
+	for key, field := range object {
+		if configString, ok := field.(string); ok && key == "configString" {
+			out[key] = configStringEntries(configString)
+
+			continue
+		}
+
+		out[key] = normalizeConfigStrings(field)
+	}
+
+	return out
+}
+
+// configStringEntries splits a WiredTiger config string like "block_compressor=zstd,prefix_compression=true"
+// on the top level commas, the nested values like "app_metadata=(a=1,b=2)" are kept whole.
+func configStringEntries(configString string) []string {
+	entries := []string{}
+	depth := 0
+	start := 0
+
+	appendEntry := func(entry string) {
+		if entry = strings.Join(strings.Fields(entry), ""); entry != "" {
+			entries = append(entries, entry)
+		}
+	}
+
+	for i, r := range configString {
+		switch r {
+		case '(', '[':
+			depth++
+		case ')', ']':
+			depth--
+		case ',':
+			if depth == 0 {
+				appendEntry(configString[start:i])
+				start = i + 1
+			}
+		}
+	}
+
+	appendEntry(configString[start:])
+	slices.Sort(entries)
+
+	return entries
+}
@@ -0,0 +1,396 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"go.mongodb.org/mongo-driver/v2/bson"
+
+	"github.com/megum1n/terraform-provider-mongodb/internal/mongodb"
+)
+
+var (
+	_ resource.Resource                = &ParameterResource{}
+	_ resource.ResourceWithConfigure   = &ParameterResource{}
+	_ resource.ResourceWithImportState = &ParameterResource{}
+)
+
+func NewParameterResource() resource.Resource {
+	return &ParameterResource{}
+}
+
+type ParameterResource struct {
+	client *mongodb.Client
+}
+
+type ParameterResourceModel struct {
+	ID               types.String `tfsdk:"id"`
+	Name             types.String `tfsdk:"name"`
+	Value            types.String `tfsdk:"value"`
+	RestoreOnDestroy types.Bool   `tfsdk:"restore_on_destroy"`
+	InitialValue     types.String `tfsdk:"initial_value"`
+}
+
+// updateState keeps the configured value when it converts to the same value as the one read from the server,
+// e.g. "1.0" for a double parameter set to 1.
+func (m *ParameterResourceModel) updateState(parameter *mongodb.Parameter) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	value, err := parameter.String()
+	if err != nil {
+		diags.AddError("Failed to read parameter value", err.Error())
+
+		return diags
+	}
+
+	m.ID = types.StringValue(parameter.Name)
+	m.Name = types.StringValue(parameter.Name)
+
+	if m.Value.IsNull() || m.Value.IsUnknown() || !sameParameterValue(parameter, m.Value.ValueString(), value) {
+		m.Value = types.StringValue(value)
+	}
+
+	return diags
+}
+
+func sameParameterValue(parameter *mongodb.Parameter, configured, current string) bool {
+	if configured == current {
+		return true
+	}
+
+	if parameter.Value.Type == bson.TypeEmbeddedDocument {
+		return isSameJSON(types.StringValue(configured), []byte(current))
+	}
+
+	configuredValue, err := parameter.ParseValue(configured)
+	if err != nil {
+		return false
+	}
+
+	currentValue, err := parameter.ParseValue(current)
+	if err != nil {
+		return false
+	}
+
+	return configuredValue == currentValue
+}
+
+func (r *ParameterResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_parameter"
+}
+
+func (r *ParameterResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a server parameter changed at runtime with `setParameter`, " +
+			"e.g. `maxTransactionLockRequestTimeoutMillis`. The parameter is set on the member the provider " +
+			"is connected to, and read back with `getParameter`. Parameters which can only be set " +
+			"at startup are rejected by the server. Destroying the resource leaves the parameter as it is, " +
+			"unless `restore_on_destroy` is set.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Name of the parameter",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the parameter",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"value": schema.StringAttribute{
+				MarkdownDescription: "Value of the parameter. It's converted to the type of the current value: " +
+					"numbers and booleans are written as they are, e.g. `5000` or `true`, " +
+					"documents as JSON in the MongoDB extended JSON format",
+				Required: true,
+			},
+			"restore_on_destroy": schema.BoolAttribute{
+				MarkdownDescription: "Restore the value the parameter had before the resource was created " +
+					"when the resource is destroyed",
+				Optional: true,
+			},
+			"initial_value": schema.StringAttribute{
+				MarkdownDescription: "Value of the parameter before the resource was created, " +
+					"restored on destroy when `restore_on_destroy` is set. Not known for imported parameters",
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *ParameterResource) Configure(
+	_ context.Context,
+	req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	p, ok := req.ProviderData.(*MongodbProvider)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *MongodbProvider, got: %T. "+
+				"Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = p.client
+}
+
+func (r *ParameterResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if !r.checkClient(&resp.Diagnostics) {
+		return
+	}
+
+	var plan ParameterResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	current, err := r.client.GetParameter(ctx, plan.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			commandErrorSummary(err, "failed to get parameter"),
+			err.Error(),
+		)
+
+		return
+	}
+
+	initialValue, err := current.String()
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to read parameter value", err.Error())
+
+		return
+	}
+
+	plan.InitialValue = types.StringValue(initialValue)
+
+	r.set(ctx, current, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "Parameter set")
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *ParameterResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if !r.checkClient(&resp.Diagnostics) {
+		return
+	}
+
+	var state ParameterResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	parameter, err := r.client.GetParameter(ctx, state.Name.ValueString())
+	if err != nil {
+		if !errors.As(err, &mongodb.NotFoundError{}) {
+			resp.Diagnostics.AddError(
+				commandErrorSummary(err, "failed to get parameter"),
+				err.Error(),
+			)
+
+			return
+		}
+
+		tflog.Debug(ctx, "parameter not found, removing from state")
+		resp.State.RemoveResource(ctx)
+
+		return
+	}
+
+	resp.Diagnostics.Append(state.updateState(parameter)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *ParameterResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if !r.checkClient(&resp.Diagnostics) {
+		return
+	}
+
+	var plan ParameterResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	current, err := r.client.GetParameter(ctx, plan.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			commandErrorSummary(err, "failed to get parameter"),
+			err.Error(),
+		)
+
+		return
+	}
+
+	r.set(ctx, current, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "Parameter updated")
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *ParameterResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if !r.checkClient(&resp.Diagnostics) {
+		return
+	}
+
+	var state ParameterResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !state.RestoreOnDestroy.ValueBool() {
+		tflog.Debug(ctx, "parameter left as it is, removing from state")
+
+		return
+	}
+
+	if state.InitialValue.IsNull() {
+		resp.Diagnostics.AddWarning(
+			"Parameter not restored",
+			fmt.Sprintf("The value of the %s parameter before it was managed is not known, "+
+				"it was only removed from the Terraform state", state.Name.ValueString()),
+		)
+
+		return
+	}
+
+	current, err := r.client.GetParameter(ctx, state.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			commandErrorSummary(err, "failed to get parameter"),
+			err.Error(),
+		)
+
+		return
+	}
+
+	value, err := current.ParseValue(state.InitialValue.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to restore parameter", err.Error())
+
+		return
+	}
+
+	_, err = r.client.SetParameter(ctx, state.Name.ValueString(), value)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			commandErrorSummary(err, "failed to restore parameter"),
+			err.Error(),
+		)
+
+		return
+	}
+
+	tflog.Trace(ctx, "Parameter restored")
+}
+
+func (r *ParameterResource) ImportState(
+	ctx context.Context,
+	req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse,
+) {
+	if !r.checkClient(&resp.Diagnostics) {
+		return
+	}
+
+	parameter, err := r.client.GetParameter(ctx, req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to get parameter",
+			err.Error(),
+		)
+
+		return
+	}
+
+	state := ParameterResourceModel{
+		RestoreOnDestroy: types.BoolNull(),
+		InitialValue:     types.StringNull(),
+	}
+
+	resp.Diagnostics.Append(state.updateState(parameter)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// set converts the planned value to the type of the current value, sets it and stores the value read back.
+func (r *ParameterResource) set(
+	ctx context.Context,
+	current *mongodb.Parameter,
+	model *ParameterResourceModel,
+	diags *diag.Diagnostics,
+) {
+	value, err := current.ParseValue(model.Value.ValueString())
+	if err != nil {
+		diags.AddAttributeError(path.Root("value"), "Invalid parameter value", err.Error())
+
+		return
+	}
+
+	parameter, err := r.client.SetParameter(ctx, model.Name.ValueString(), value)
+	if err != nil {
+		diags.AddError(
+			commandErrorSummary(err, "failed to set parameter"),
+			err.Error(),
+		)
+
+		return
+	}
+
+	diags.Append(model.updateState(parameter)...)
+}
+
+func (r *ParameterResource) checkClient(diags *diag.Diagnostics) bool {
+	if r.client == nil {
+		diags.AddError(
+			"MongoDB client is not configured",
+			"Expected configured MongoDB client. Please report this issue to the provider developers.",
+		)
+
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,137 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/megum1n/terraform-provider-mongodb/internal/mongodb"
+)
+
+var _ datasource.DataSource = &PingDataSource{}
+var _ datasource.DataSourceWithConfigure = &PingDataSource{}
+
+func NewPingDataSource() datasource.DataSource {
+	return &PingDataSource{}
+}
+
+type PingDataSource struct {
+	client *mongodb.Client
+}
+
+type PingDataSourceModel struct {
+	Ok           types.Bool   `tfsdk:"ok"`
+	RoundTripMS  types.Int64  `tfsdk:"round_trip_ms"`
+	TopologyType types.String `tfsdk:"topology_type"`
+	Primary      types.String `tfsdk:"primary"`
+	ReplicaSet   types.String `tfsdk:"replica_set"`
+}
+
+func (m *PingDataSourceModel) updateState(result *mongodb.PingResult) {
+	m.Ok = types.BoolValue(result.Ok)
+	m.RoundTripMS = types.Int64Value(result.RoundTrip.Milliseconds())
+	m.TopologyType = types.StringValue(result.TopologyType)
+	m.Primary = types.StringNull()
+	m.ReplicaSet = types.StringNull()
+
+	if result.Primary != "" {
+		m.Primary = types.StringValue(result.Primary)
+	}
+
+	if result.SetName != "" {
+		m.ReplicaSet = types.StringValue(result.SetName)
+	}
+}
+
+func (d *PingDataSource) Metadata(
+	_ context.Context,
+	req datasource.MetadataRequest,
+	resp *datasource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_ping"
+}
+
+func (d *PingDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Checks that the primary is reachable with the `hello` command and measures its latency. " +
+			"Reading it fails when the cluster is unreachable, so the resources depending on it " +
+			"are only created once the cluster is live",
+
+		Attributes: map[string]schema.Attribute{
+			"ok": schema.BoolAttribute{
+				MarkdownDescription: "Whether the server acknowledged the command",
+				Computed:            true,
+			},
+			"round_trip_ms": schema.Int64Attribute{
+				MarkdownDescription: "Duration of the `hello` command in milliseconds, including the server selection",
+				Computed:            true,
+			},
+			"topology_type": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("Detected topology: `%s`, `%s` or `%s`",
+					mongodb.TopologySingle, mongodb.TopologyReplicaSet, mongodb.TopologySharded),
+				Computed: true,
+			},
+			"primary": schema.StringAttribute{
+				MarkdownDescription: "Address of the replica set primary, null for the other topologies",
+				Computed:            true,
+			},
+			"replica_set": schema.StringAttribute{
+				MarkdownDescription: "Name of the replica set, null for the other topologies",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *PingDataSource) Configure(
+	_ context.Context,
+	req datasource.ConfigureRequest,
+	resp *datasource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	p, ok := req.ProviderData.(*MongodbProvider)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *MongodbProvider, got: %T. "+
+				"Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = p.client
+}
+
+func (d *PingDataSource) Read(ctx context.Context, _ datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if d.client == nil {
+		resp.Diagnostics.AddError(
+			"MongoDB client is not configured",
+			"Expected configured MongoDB client. Please report this issue to the provider developers.",
+		)
+
+		return
+	}
+
+	result, err := d.client.Ping(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			commandErrorSummary(err, "MongoDB cluster is unreachable"),
+			err.Error(),
+		)
+
+		return
+	}
+
+	var state PingDataSourceModel
+
+	state.updateState(result)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
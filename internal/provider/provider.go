@@ -2,38 +2,143 @@ package provider
 
 import (
 	"context"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/providervalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"go.mongodb.org/mongo-driver/v2/x/mongo/driver/connstring"
 
 	"github.com/megum1n/terraform-provider-mongodb/internal/mongodb"
 )
 
 var (
-	_ provider.Provider = &MongodbProvider{}
+	_ provider.Provider                     = &MongodbProvider{}
+	_ provider.ProviderWithConfigValidators = &MongodbProvider{}
+	_ provider.ProviderWithValidateConfig   = &MongodbProvider{}
 )
 
 const (
 	defaultDatabase = "admin"
+
+	usernameEnvVar = "MONGODB_USERNAME"
+	passwordEnvVar = "MONGODB_PASSWORD"
+	hostsEnvVar    = "MONGODB_HOSTS"
+	uriEnvVar      = "MONGODB_URI"
 )
 
 type MongodbProvider struct {
 	Version string
 	client  *mongodb.Client
+	// defaultDatabase is the database of the users and roles configured without database
+	defaultDatabase string
 }
 
 type MongodbProviderModel struct {
-	Hosts              types.List   `tfsdk:"hosts"`
-	Username           types.String `tfsdk:"username"`
-	Password           types.String `tfsdk:"password"`
-	AuthSource         types.String `tfsdk:"auth_source"`
-	ReplicaSet         types.String `tfsdk:"replica_set"`
-	TLS                types.Bool   `tfsdk:"tls"`
-	Certificate        types.String `tfsdk:"certificate"`
-	InsecureSkipVerify types.Bool   `tfsdk:"insecure_skip_verify"`
+	Hosts                types.List   `tfsdk:"hosts"`
+	Username             types.String `tfsdk:"username"`
+	Password             types.String `tfsdk:"password"`
+	AuthSource           types.String `tfsdk:"auth_source"`
+	ReplicaSet           types.String `tfsdk:"replica_set"`
+	TLS                  types.Bool   `tfsdk:"tls"`
+	Certificate          types.String `tfsdk:"certificate"`
+	CertificateFile      types.String `tfsdk:"certificate_file"`
+	TLSClientCertFile    types.String `tfsdk:"tls_client_cert_file"`
+	TLSClientKeyFile     types.String `tfsdk:"tls_client_key_file"`
+	InsecureSkipVerify   types.Bool   `tfsdk:"insecure_skip_verify"`
+	ConfigFile           types.String `tfsdk:"config_file"`
+	WriteConcern         types.Object `tfsdk:"write_concern"`
+	ReadPreference       types.String `tfsdk:"read_preference"`
+	ReadConcern          types.String `tfsdk:"read_concern"`
+	Compressors          types.List   `tfsdk:"compressors"`
+	ZlibLevel            types.Int64  `tfsdk:"zlib_level"`
+	RetryWrites          types.Bool   `tfsdk:"retry_writes"`
+	RetryReads           types.Bool   `tfsdk:"retry_reads"`
+	MaxPoolSize          types.Int64  `tfsdk:"max_pool_size"`
+	MinPoolSize          types.Int64  `tfsdk:"min_pool_size"`
+	MaxConnIdleTime      types.String `tfsdk:"max_conn_idle_time"`
+	HeartbeatInterval    types.String `tfsdk:"heartbeat_interval"`
+	LocalThreshold       types.String `tfsdk:"local_threshold"`
+	RetryableCommands    types.Set    `tfsdk:"retryable_commands"`
+	CommandRetries       types.Int64  `tfsdk:"command_retries"`
+	CommandRetryInterval types.String `tfsdk:"command_retry_interval"`
+	OperationTimeout     types.String `tfsdk:"operation_timeout"`
+	DirectConnection     types.Bool   `tfsdk:"direct_connection"`
+	ConnectRetries       types.Int64  `tfsdk:"connect_retries"`
+	ConnectRetryInterval types.String `tfsdk:"connect_retry_interval"`
+	PoolMetrics          types.Bool   `tfsdk:"pool_metrics"`
+	ServerType           types.String `tfsdk:"server_type"`
+	DefaultDatabase      types.String `tfsdk:"default_database"`
+	OperationComment     types.String `tfsdk:"operation_comment"`
+	SkipPing             types.Bool   `tfsdk:"skip_ping"`
+}
+
+type WriteConcernModel struct {
+	W        types.String `tfsdk:"w"`
+	WTimeout types.String `tfsdk:"wtimeout"`
+	Journal  types.Bool   `tfsdk:"journal"`
+}
+
+func (m *MongodbProviderModel) writeConcern(ctx context.Context) (*mongodb.WriteConcern, diag.Diagnostics) {
+	if m.WriteConcern.IsNull() || m.WriteConcern.IsUnknown() {
+		return nil, nil
+	}
+
+	var model WriteConcernModel
+
+	diags := m.WriteConcern.As(ctx, &model, basetypes.ObjectAsOptions{})
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	writeConcern := &mongodb.WriteConcern{
+		W:       model.W.ValueString(),
+		Journal: model.Journal.ValueBoolPointer(),
+	}
+
+	writeConcern.WTimeout = durationValue(model.WTimeout, path.Root("write_concern").AtName("wtimeout"), &diags)
+
+	return writeConcern, diags
+}
+
+// durationValue parses a positive duration attribute like "10s". Zero is returned for null values.
+func durationValue(value types.String, attributePath path.Path, diags *diag.Diagnostics) time.Duration {
+	if value.IsNull() || value.IsUnknown() {
+		return 0
+	}
+
+	duration, err := time.ParseDuration(value.ValueString())
+	if err != nil || duration <= 0 {
+		diags.AddAttributeError(
+			attributePath,
+			"Invalid duration",
+			fmt.Sprintf("Expected a positive duration like \"10s\", got: %q", value.ValueString()),
+		)
+
+		return 0
+	}
+
+	return duration
 }
 
 func New(version string) func() provider.Provider {
@@ -55,23 +160,31 @@ func (p *MongodbProvider) Schema(_ context.Context, _ provider.SchemaRequest, re
 
 		Attributes: map[string]schema.Attribute{
 			"hosts": schema.ListAttribute{
-				MarkdownDescription: "MongoDB hosts",
-				ElementType:         types.StringType,
-				Required:            true,
+				MarkdownDescription: "MongoDB hosts as `host` or `host:port` addresses, the port defaults to `27017`. " +
+					"Falls back to the comma separated `" + hostsEnvVar + "` environment variable. " +
+					"Required unless set in `config_file` or `" + uriEnvVar + "`",
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators: []validator.List{
+					listvalidator.ValueStringsAre(hostAddress()),
+				},
 			},
 			"username": schema.StringAttribute{
-				MarkdownDescription: "Username",
-				Required:            true,
-				Sensitive:           true,
+				MarkdownDescription: "Username. Falls back to the `" + usernameEnvVar + "` environment variable. " +
+					"Required unless set in `config_file` or `" + uriEnvVar + "`",
+				Optional:  true,
+				Sensitive: true,
 			},
 			"password": schema.StringAttribute{
-				MarkdownDescription: "Password",
-				Required:            true,
-				Sensitive:           true,
+				MarkdownDescription: "Password. Falls back to the `" + passwordEnvVar + "` environment variable. " +
+					"Required unless set in `config_file` or `" + uriEnvVar + "`",
+				Optional:  true,
+				Sensitive: true,
 			},
 			"auth_source": schema.StringAttribute{
-				MarkdownDescription: "AuthSource database",
-				Optional:            true,
+				MarkdownDescription: "AuthSource database. Defaults to the `authSource` of the connection string " +
+					"in `MONGODB_URI`, then to its database, otherwise to `admin`",
+				Optional: true,
 			},
 			"replica_set": schema.StringAttribute{
 				MarkdownDescription: "Replica set name",
@@ -82,17 +195,319 @@ func (p *MongodbProvider) Schema(_ context.Context, _ provider.SchemaRequest, re
 				Optional:            true,
 			},
 			"certificate": schema.StringAttribute{
-				MarkdownDescription: "Certificate PEM string",
+				MarkdownDescription: "Certificate PEM string. Requires `tls`",
 				Optional:            true,
 			},
+			"certificate_file": schema.StringAttribute{
+				MarkdownDescription: "Path to the certificate PEM file. Requires `tls`, conflicts with `certificate`",
+				Optional:            true,
+			},
+			"tls_client_cert_file": schema.StringAttribute{
+				MarkdownDescription: "Path to the client certificate PEM file presented for mutual TLS. " +
+					"Requires `tls` and `tls_client_key_file`",
+				Optional: true,
+			},
+			"tls_client_key_file": schema.StringAttribute{
+				MarkdownDescription: "Path to the client private key PEM file for mutual TLS. " +
+					"Requires `tls` and `tls_client_cert_file`",
+				Optional: true,
+			},
 			"insecure_skip_verify": schema.BoolAttribute{
-				MarkdownDescription: "Insecure TLS",
+				MarkdownDescription: "Insecure TLS. Requires `tls`",
+				Optional:            true,
+			},
+			"config_file": schema.StringAttribute{
+				MarkdownDescription: "Path to a JSON or YAML file with the connection settings. " +
+					"Keys match the provider attribute names. " +
+					"Attributes set in the provider block override the file values. " +
+					"`certificate` and `certificate_file` conflict across the file and the provider block",
+				Optional: true,
+			},
+			"write_concern": schema.SingleNestedAttribute{
+				MarkdownDescription: "Write concern applied to the user, role and index commands",
+				Optional:            true,
+				Attributes: map[string]schema.Attribute{
+					"w": schema.StringAttribute{
+						MarkdownDescription: "Number of nodes, `majority` or a custom write concern tag name",
+						Optional:            true,
+						Validators: []validator.String{
+							stringvalidator.LengthAtLeast(1),
+						},
+					},
+					"wtimeout": schema.StringAttribute{
+						MarkdownDescription: "Time limit for the write concern, e.g. `10s`. " +
+							"Applied to the user and role commands",
+						Optional: true,
+					},
+					"journal": schema.BoolAttribute{
+						MarkdownDescription: "Request acknowledgment that the write has been written to the on-disk journal",
+						Optional:            true,
+					},
+				},
+			},
+			"read_preference": schema.StringAttribute{
+				MarkdownDescription: "Read preference for the index reads. " +
+					"User and role lookups, and the reads following a write, are always routed to the primary. " +
+					"One of `primary`, `primaryPreferred`, `secondary`, `secondaryPreferred`, `nearest`",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("primary", "primaryPreferred", "secondary", "secondaryPreferred", "nearest"),
+				},
+			},
+			"default_database": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("Database of the users and roles configured without `database`, "+
+					"and of the user and role data sources. %q is used by default. "+
+					"Changing it doesn't move the existing resources, they keep their database", defaultDatabase),
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"operation_comment": schema.StringAttribute{
+				MarkdownDescription: "Comment attached to the user, role, collection, index and document commands, " +
+					"and to the create and delete commands of `mongodb_command`, " +
+					"to find them in the server logs and the profiler. " +
+					"Defaults to `terraform-provider-mongodb/<version>`, an empty string disables it. " +
+					"It's ignored before MongoDB 4.4 and on DocumentDB. " +
+					"With `skip_ping`, there is no default and the server version is not checked",
+				Optional: true,
+			},
+			"read_concern": schema.StringAttribute{
+				MarkdownDescription: "Read concern level of the index, collection and document reads, " +
+					"e.g. `majority` to read only the data acknowledged by a majority of the replica set. " +
+					"One of `local`, `available`, `majority`, `linearizable`, `snapshot`. " +
+					"The server default is used when unset",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("local", "available", "majority", "linearizable", "snapshot"),
+				},
+			},
+			"compressors": schema.ListAttribute{
+				MarkdownDescription: "Wire protocol compressors in the order of preference. " +
+					"Supported values are `zstd`, `zlib` and `snappy`. Compression is disabled by default",
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators: []validator.List{
+					listvalidator.UniqueValues(),
+					listvalidator.ValueStringsAre(stringvalidator.OneOf("zstd", "zlib", "snappy")),
+				},
+			},
+			"zlib_level": schema.Int64Attribute{
+				MarkdownDescription: "Compression level for the `zlib` compressor, from -1 (default) to 9",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.Between(-1, 9),
+				},
+			},
+			"retry_writes": schema.BoolAttribute{
+				MarkdownDescription: "Retry supported write operations once on network errors. " +
+					"Defaults to the `retryWrites` option of the connection string, `true` when it's not set",
+				Optional: true,
+			},
+			"retry_reads": schema.BoolAttribute{
+				MarkdownDescription: "Retry supported read operations once on network errors. " +
+					"Defaults to the `retryReads` option of the connection string, `true` when it's not set",
+				Optional: true,
+			},
+			"max_pool_size": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of connections in the connection pool. The driver default is 100",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"min_pool_size": schema.Int64Attribute{
+				MarkdownDescription: "Minimum number of connections kept in the connection pool. The driver default is 0",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"max_conn_idle_time": schema.StringAttribute{
+				MarkdownDescription: "Maximum time a connection can remain idle in the pool before being closed, " +
+					"e.g. `5m`. Idle connections are not closed by default. Set it below the idle timeout " +
+					"of the load balancers between Terraform and the cluster, so the pool does not hand out " +
+					"connections they already dropped",
+				Optional: true,
+			},
+			"heartbeat_interval": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("Time between the checks of every server, which also keep "+
+					"the monitoring connections active, e.g. `10s`. At least `%s`, the driver default is `10s`",
+					mongodb.MinHeartbeatInterval),
+				Optional: true,
+			},
+			"local_threshold": schema.StringAttribute{
+				MarkdownDescription: "Latency window above the fastest mongos or replica set member, e.g. `5ms`. " +
+					"Operations are spread among the servers inside it, a small value keeps them on the nearest " +
+					"ones. The driver default is `15ms`",
+				Optional: true,
+			},
+			"direct_connection": schema.BoolAttribute{
+				MarkdownDescription: "Connect to the single host directly without discovering the replica set topology. " +
+					"Conflicts with `replica_set`",
+				Optional: true,
+			},
+			"retryable_commands": schema.SetAttribute{
+				MarkdownDescription: fmt.Sprintf("Admin commands retried on transient errors like a primary step down. "+
+					"Set to an empty set to disable retries. Defaults to: `%s`",
+					strings.Join(mongodb.DefaultRetryableCommands, "`, `")),
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+			"command_retries": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("Number of additional attempts of the `retryable_commands` "+
+					"after a transient error. `%d` by default", mongodb.DefaultCommandRetries),
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"command_retry_interval": schema.StringAttribute{
+				MarkdownDescription: "Wait time before the first command retry, doubled after every attempt. `1s` by default",
+				Optional:            true,
+			},
+			"operation_timeout": schema.StringAttribute{
+				MarkdownDescription: "Maximum duration of every operation including its retries, e.g. `5m`. " +
+					"The provider stops waiting for the server when it is exceeded, so a change may still be applied " +
+					"in the background. The wait for an index build is limited by `wait_for_ready_timeout` of the index " +
+					"instead, each of its checks is limited by `operation_timeout`. No limit by default",
+				Optional: true,
+			},
+			"connect_retries": schema.Int64Attribute{
+				MarkdownDescription: "Number of additional connection checks when the cluster is not reachable on configure, " +
+					"e.g. during an election. `0` by default",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"connect_retry_interval": schema.StringAttribute{
+				MarkdownDescription: "Wait time before the first connection retry, doubled after every attempt. `1s` by default",
 				Optional:            true,
 			},
+			"pool_metrics": schema.BoolAttribute{
+				MarkdownDescription: "Log the connection pool events at the trace level and count them " +
+					"for the `mongodb_connection_health` data source",
+				Optional: true,
+			},
+			"server_type": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("Server flavor: `%s` or `%s`. "+
+					"DocumentDB does not return the user mechanisms, so the configured ones are kept in the state. "+
+					"`%s` by default", mongodb.ServerTypeMongoDB, mongodb.ServerTypeDocumentDB, mongodb.ServerTypeMongoDB),
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(mongodb.ServerTypeMongoDB, mongodb.ServerTypeDocumentDB),
+				},
+			},
+			"skip_ping": schema.BoolAttribute{
+				MarkdownDescription: "Configure the provider without checking that the cluster is reachable, " +
+					"which saves a round trip when it's known to be up. The connection errors are then reported " +
+					"by the first operation. `connect_retries` has no effect. `false` by default",
+				Optional: true,
+			},
 		},
 	}
 }
 
+func (p *MongodbProvider) ConfigValidators(_ context.Context) []provider.ConfigValidator {
+	return []provider.ConfigValidator{
+		providervalidator.Conflicting(
+			path.MatchRoot("certificate"),
+			path.MatchRoot("certificate_file"),
+		),
+		providervalidator.RequiredTogether(
+			path.MatchRoot("tls_client_cert_file"),
+			path.MatchRoot("tls_client_key_file"),
+		),
+	}
+}
+
+// ValidateConfig checks that direct_connection is not enabled with a replica set,
+// and the TLS settings are not set with TLS disabled, where they would be ignored.
+func (p *MongodbProvider) ValidateConfig(
+	ctx context.Context,
+	req provider.ValidateConfigRequest,
+	resp *provider.ValidateConfigResponse,
+) {
+	var data MongodbProviderModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// direct_connection = false is the default and combines with a replica set
+	if data.DirectConnection.ValueBool() && !data.ReplicaSet.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("direct_connection"),
+			"Invalid direct connection configuration",
+			"direct_connection = true conflicts with replica_set, the topology discovery is disabled",
+		)
+	}
+
+	// TLS can also be enabled in the config file, which is only read in Configure
+	if data.TLS.IsNull() && !data.ConfigFile.IsNull() {
+		return
+	}
+
+	validateTLSAttributes(&data, &resp.Diagnostics)
+}
+
+// validateTLSAttributes reports the TLS settings set with TLS disabled, where the client would ignore them.
+func validateTLSAttributes(data *MongodbProviderModel, diags *diag.Diagnostics) {
+	if data.TLS.IsUnknown() || data.TLS.ValueBool() {
+		return
+	}
+
+	tlsAttributes := []struct {
+		name  string
+		value attr.Value
+	}{
+		{"certificate", data.Certificate},
+		{"certificate_file", data.CertificateFile},
+		{"tls_client_cert_file", data.TLSClientCertFile},
+		{"tls_client_key_file", data.TLSClientKeyFile},
+		{"insecure_skip_verify", data.InsecureSkipVerify},
+	}
+
+	for _, a := range tlsAttributes {
+		if a.value.IsNull() || a.value.IsUnknown() || a.value.Equal(types.BoolValue(false)) {
+			continue
+		}
+
+		diags.AddAttributeError(
+			path.Root(a.name),
+			"TLS is not enabled",
+			a.name+" requires tls = true",
+		)
+	}
+}
+
+// validateCertificateAttributes applies the certificate validators of ConfigValidators to the settings
+// merged from the config file, which may come from both the file and the provider block.
+func validateCertificateAttributes(data *MongodbProviderModel, diags *diag.Diagnostics) {
+	if !data.Certificate.IsNull() && !data.CertificateFile.IsNull() {
+		diags.AddAttributeError(
+			path.Root("certificate_file"),
+			"Invalid certificate configuration",
+			"certificate conflicts with certificate_file, check the provider block and config_file",
+		)
+	}
+
+	if data.TLSClientCertFile.IsNull() != data.TLSClientKeyFile.IsNull() {
+		diags.AddAttributeError(
+			path.Root("tls_client_key_file"),
+			"Invalid client certificate configuration",
+			"tls_client_cert_file and tls_client_key_file must be set together, "+
+				"check the provider block and config_file",
+		)
+	}
+}
+
 func (p *MongodbProvider) Configure(
 	ctx context.Context,
 	req provider.ConfigureRequest,
@@ -106,8 +521,46 @@ func (p *MongodbProvider) Configure(
 		return
 	}
 
-	if data.AuthSource.IsNull() {
-		data.AuthSource = types.StringValue(defaultDatabase)
+	if !data.ConfigFile.IsNull() {
+		configFile, err := readConfigFile(data.ConfigFile.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("config_file"),
+				"Failed to read config file",
+				err.Error(),
+			)
+
+			return
+		}
+
+		configFile.mergeInto(&data)
+	}
+
+	mergeEnvironment(&data)
+
+	// The settings of the config file are only known now
+	validateCertificateAttributes(&data, &resp.Diagnostics)
+	validateTLSAttributes(&data, &resp.Diagnostics)
+
+	uri := os.Getenv(uriEnvVar)
+
+	validateRequiredAttributes(&data, uri, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.DefaultDatabase.IsNull() {
+		data.DefaultDatabase = types.StringValue(defaultDatabase)
+	}
+
+	// The server version isn't checked without the ping, so only a configured comment is sent
+	if data.OperationComment.IsNull() && !data.SkipPing.ValueBool() {
+		data.OperationComment = types.StringValue("terraform-provider-mongodb/" + p.Version)
+	}
+
+	if data.CommandRetries.IsNull() {
+		data.CommandRetries = types.Int64Value(mongodb.DefaultCommandRetries)
 	}
 
 	var err error
@@ -120,15 +573,171 @@ func (p *MongodbProvider) Configure(
 		return
 	}
 
+	// The hosts of the config file and of the environment are not checked at plan time
+	for i, host := range hosts {
+		err = validateHost(host)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("hosts").AtListIndex(i), "Invalid MongoDB host", err.Error())
+		}
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var compressors []string
+
+	resp.Diagnostics.Append(data.Compressors.ElementsAs(ctx, &compressors, false)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var zlibLevel *int
+
+	if !data.ZlibLevel.IsNull() {
+		level := int(data.ZlibLevel.ValueInt64())
+		zlibLevel = &level
+
+		if !slices.Contains(compressors, "zlib") {
+			resp.Diagnostics.AddAttributeWarning(
+				path.Root("zlib_level"),
+				"zlib compressor is not enabled",
+				"zlib_level has no effect unless \"zlib\" is listed in compressors",
+			)
+		}
+	}
+
+	if !data.MaxPoolSize.IsNull() && !data.MinPoolSize.IsNull() &&
+		data.MinPoolSize.ValueInt64() > data.MaxPoolSize.ValueInt64() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("min_pool_size"),
+			"Invalid connection pool size",
+			"min_pool_size must not be greater than max_pool_size",
+		)
+
+		return
+	}
+
+	if data.DirectConnection.ValueBool() {
+		directHosts, err := directConnectionHosts(hosts, uri)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("direct_connection"),
+				"Invalid direct connection configuration",
+				err.Error(),
+			)
+
+			return
+		}
+
+		if len(directHosts) != 1 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("direct_connection"),
+				"Invalid direct connection configuration",
+				fmt.Sprintf("Direct connection requires exactly one host, got %d", len(directHosts)),
+			)
+
+			return
+		}
+
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("direct_connection"),
+			"Direct connection",
+			fmt.Sprintf("All admin commands run against %q only, without the replica set topology discovery. "+
+				"Writes fail unless this host is the primary.", directHosts[0]),
+		)
+	}
+
+	var retryableCommands []string
+
+	if !data.RetryableCommands.IsNull() {
+		retryableCommands = []string{}
+
+		resp.Diagnostics.Append(data.RetryableCommands.ElementsAs(ctx, &retryableCommands, false)...)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	maxConnIdleTime := durationValue(data.MaxConnIdleTime, path.Root("max_conn_idle_time"), &resp.Diagnostics)
+
+	heartbeatInterval := durationValue(data.HeartbeatInterval, path.Root("heartbeat_interval"), &resp.Diagnostics)
+	if heartbeatInterval > 0 && heartbeatInterval < mongodb.MinHeartbeatInterval {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("heartbeat_interval"),
+			"Invalid heartbeat interval",
+			fmt.Sprintf("Expected at least %s, got: %s", mongodb.MinHeartbeatInterval, heartbeatInterval),
+		)
+	}
+
+	localThreshold := durationValue(data.LocalThreshold, path.Root("local_threshold"), &resp.Diagnostics)
+	commandRetryInterval := durationValue(
+		data.CommandRetryInterval,
+		path.Root("command_retry_interval"),
+		&resp.Diagnostics,
+	)
+	operationTimeout := durationValue(data.OperationTimeout, path.Root("operation_timeout"), &resp.Diagnostics)
+	connectRetryInterval := durationValue(
+		data.ConnectRetryInterval,
+		path.Root("connect_retry_interval"),
+		&resp.Diagnostics,
+	)
+
+	writeConcern, d := data.writeConcern(ctx)
+	resp.Diagnostics.Append(d...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Configure can be called more than once in the same provider process
+	err = p.Close(ctx)
+	if err != nil {
+		tflog.Warn(ctx, "failed to disconnect the previous MongoDB client", map[string]interface{}{
+			"err": err.Error(),
+		})
+	}
+
+	p.defaultDatabase = data.DefaultDatabase.ValueString()
+
 	p.client, err = mongodb.New(ctx, &mongodb.ClientOptions{
-		Hosts:              hosts,
-		Username:           data.Username.ValueString(),
-		Password:           data.Password.ValueString(),
-		AuthSource:         data.AuthSource.ValueString(),
-		ReplicaSet:         data.ReplicaSet.ValueString(),
-		TLS:                data.TLS.ValueBool(),
-		Certificate:        data.Certificate.ValueString(),
-		InsecureSkipVerify: data.InsecureSkipVerify.ValueBool(),
+		URI:                   uri,
+		Hosts:                 hosts,
+		Username:              data.Username.ValueString(),
+		Password:              data.Password.ValueString(),
+		AuthSource:            data.AuthSource.ValueString(),
+		ReplicaSet:            data.ReplicaSet.ValueString(),
+		Direct:                data.DirectConnection.ValueBool(),
+		TLS:                   data.TLS.ValueBool(),
+		Certificate:           data.Certificate.ValueString(),
+		CertificateFile:       data.CertificateFile.ValueString(),
+		ClientCertificateFile: data.TLSClientCertFile.ValueString(),
+		ClientKeyFile:         data.TLSClientKeyFile.ValueString(),
+		InsecureSkipVerify:    data.InsecureSkipVerify.ValueBool(),
+		WriteConcern:          writeConcern,
+		ReadPreference:        data.ReadPreference.ValueString(),
+		ReadConcern:           data.ReadConcern.ValueString(),
+		Compressors:           compressors,
+		ZlibLevel:             zlibLevel,
+		RetryWrites:           data.RetryWrites.ValueBoolPointer(),
+		RetryReads:            data.RetryReads.ValueBoolPointer(),
+		MaxPoolSize:           uint64(data.MaxPoolSize.ValueInt64()),
+		MinPoolSize:           uint64(data.MinPoolSize.ValueInt64()),
+		MaxConnIdleTime:       maxConnIdleTime,
+		HeartbeatInterval:     heartbeatInterval,
+		LocalThreshold:        localThreshold,
+		RetryableCommands:     retryableCommands,
+		CommandRetries:        int(data.CommandRetries.ValueInt64()),
+		CommandRetryInterval:  commandRetryInterval,
+		ConnectRetries:        int(data.ConnectRetries.ValueInt64()),
+		ConnectRetryInterval:  connectRetryInterval,
+		PoolMetrics:           data.PoolMetrics.ValueBool(),
+		ServerType:            data.ServerType.ValueString(),
+		OperationTimeout:      operationTimeout,
+		Comment:               data.OperationComment.ValueString(),
+		SkipPing:              data.SkipPing.ValueBool(),
 	})
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -137,17 +746,148 @@ func (p *MongodbProvider) Configure(
 		)
 	}
 
+	resp.DataSourceData = p
 	resp.ResourceData = p
 }
 
+// mergeEnvironment fills the connection attributes which are set neither in the provider block nor in the config file.
+func mergeEnvironment(data *MongodbProviderModel) {
+	if hosts := os.Getenv(hostsEnvVar); data.Hosts.IsNull() && hosts != "" {
+		values := []attr.Value{}
+
+		for _, host := range strings.Split(hosts, ",") {
+			if host = strings.TrimSpace(host); host != "" {
+				values = append(values, types.StringValue(host))
+			}
+		}
+
+		data.Hosts = types.ListValueMust(types.StringType, values)
+	}
+
+	if username := os.Getenv(usernameEnvVar); data.Username.IsNull() && username != "" {
+		data.Username = types.StringValue(username)
+	}
+
+	if password := os.Getenv(passwordEnvVar); data.Password.IsNull() && password != "" {
+		data.Password = types.StringValue(password)
+	}
+}
+
+// directConnectionHosts returns the hosts of a direct connection: the hosts attribute, which overrides
+// the connection string, or else the hosts of the connection string.
+func directConnectionHosts(hosts []string, uri string) ([]string, error) {
+	if len(hosts) > 0 || uri == "" {
+		return hosts, nil
+	}
+
+	// The SRV record is resolved to the replica set members, which the driver refuses to connect to directly
+	if strings.HasPrefix(uri, connstring.SchemeMongoDBSRV+"://") {
+		return nil, fmt.Errorf("direct connection can't be used with a %s connection string from %s",
+			connstring.SchemeMongoDBSRV, uriEnvVar)
+	}
+
+	connString, err := connstring.Parse(uri)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the hosts of %s: %w", uriEnvVar, err)
+	}
+
+	return connString.Hosts, nil
+}
+
+// validateRequiredAttributes checks the connection attributes, which can come from the provider block,
+// the config file or the environment. The connection string can provide all of them.
+func validateRequiredAttributes(data *MongodbProviderModel, uri string, diags *diag.Diagnostics) {
+	if uri != "" {
+		return
+	}
+
+	if data.Hosts.IsNull() || len(data.Hosts.Elements()) == 0 {
+		diags.AddAttributeError(
+			path.Root("hosts"),
+			"Missing MongoDB hosts",
+			fmt.Sprintf("At least one host must be set in the provider configuration, in the config file "+
+				"or with the %s or %s environment variable", hostsEnvVar, uriEnvVar),
+		)
+	}
+
+	if data.Username.IsNull() {
+		diags.AddAttributeError(
+			path.Root("username"),
+			"Missing MongoDB username",
+			fmt.Sprintf("Username must be set in the provider configuration, in the config file "+
+				"or with the %s environment variable", usernameEnvVar),
+		)
+	}
+
+	if data.Password.IsNull() {
+		diags.AddAttributeError(
+			path.Root("password"),
+			"Missing MongoDB password",
+			fmt.Sprintf("Password must be set in the provider configuration, in the config file "+
+				"or with the %s environment variable", passwordEnvVar),
+		)
+	}
+}
+
+// planDefaultDatabase sets the database of a new resource configured without database to default_database.
+// The database of an existing resource is kept by UseStateForUnknown, so default_database changes don't replace it.
+func planDefaultDatabase(ctx context.Context, config tfsdk.Config, plan *tfsdk.Plan, database string) diag.Diagnostics {
+	// The provider is not configured yet, e.g. its configuration depends on other resources
+	if database == "" {
+		return nil
+	}
+
+	var configured, planned types.String
+
+	diags := config.GetAttribute(ctx, path.Root("database"), &configured)
+	diags.Append(plan.GetAttribute(ctx, path.Root("database"), &planned)...)
+
+	if diags.HasError() || !configured.IsNull() || !planned.IsUnknown() {
+		return diags
+	}
+
+	diags.Append(plan.SetAttribute(ctx, path.Root("database"), types.StringValue(database))...)
+
+	return diags
+}
+
+// Close disconnects the MongoDB client. It's called when the provider server stops.
+func (p *MongodbProvider) Close(ctx context.Context) error {
+	err := p.client.Disconnect(ctx)
+	p.client = nil
+
+	return err
+}
+
 func (p *MongodbProvider) DataSources(_ context.Context) []func() datasource.DataSource {
-	return nil
+	return []func() datasource.DataSource{
+		NewQueryPlanDataSource,
+		NewUserDataSource,
+		NewIndexDataSource,
+		NewIndexesDataSource,
+		NewCollectionDataSource,
+		NewCollectionsDataSource,
+		NewConnectionHealthDataSource,
+		NewPingDataSource,
+		NewUsersDataSource,
+		NewRolesDataSource,
+		NewCommandDataSource,
+	}
 }
 
 func (p *MongodbProvider) Resources(_ context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewUserResource,
+		NewUserRolesResource,
 		NewRoleResource,
+		NewRolePrivilegesResource,
 		NewIndexResource,
+		NewCollectionResource,
+		NewCommandResource,
+		NewDocumentResource,
+		NewDefaultRWConcernResource,
+		NewParameterResource,
+		NewShardDatabaseResource,
+		NewShardCollectionResource,
 	}
 }
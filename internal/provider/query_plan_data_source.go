@@ -0,0 +1,164 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/megum1n/terraform-provider-mongodb/internal/mongodb"
+)
+
+var _ datasource.DataSource = &QueryPlanDataSource{}
+var _ datasource.DataSourceWithConfigure = &QueryPlanDataSource{}
+
+func NewQueryPlanDataSource() datasource.DataSource {
+	return &QueryPlanDataSource{}
+}
+
+type QueryPlanDataSource struct {
+	client *mongodb.Client
+}
+
+type QueryPlanDataSourceModel struct {
+	Database       types.String `tfsdk:"database"`
+	Collection     types.String `tfsdk:"collection"`
+	Filter         types.String `tfsdk:"filter"`
+	Sort           types.String `tfsdk:"sort"`
+	IndexName      types.String `tfsdk:"index_name"`
+	IndexNames     types.List   `tfsdk:"index_names"`
+	CollectionScan types.Bool   `tfsdk:"collection_scan"`
+}
+
+func (d *QueryPlanDataSourceModel) updateState(ctx context.Context, plan *mongodb.QueryPlan) diag.Diagnostics {
+	indexNames, diags := types.ListValueFrom(ctx, types.StringType, plan.IndexNames)
+	d.IndexNames = indexNames
+
+	d.IndexName = types.StringNull()
+	if len(plan.IndexNames) > 0 {
+		d.IndexName = types.StringValue(plan.IndexNames[0])
+	}
+
+	d.CollectionScan = types.BoolValue(plan.CollectionScan)
+
+	return diags
+}
+
+func (d *QueryPlanDataSource) Metadata(
+	_ context.Context,
+	req datasource.MetadataRequest,
+	resp *datasource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_query_plan"
+}
+
+func (d *QueryPlanDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Runs `explain` for a query and reports the plan chosen by the query planner. " +
+			"Useful to verify that hiding an index had the intended effect before dropping it.",
+
+		Attributes: map[string]schema.Attribute{
+			"database": schema.StringAttribute{
+				MarkdownDescription: "Database name",
+				Required:            true,
+			},
+			"collection": schema.StringAttribute{
+				MarkdownDescription: "Collection name",
+				Required:            true,
+			},
+			"filter": schema.StringAttribute{
+				MarkdownDescription: "Query filter as a JSON document. Defaults to `{}`",
+				Optional:            true,
+			},
+			"sort": schema.StringAttribute{
+				MarkdownDescription: "Sort specification as a JSON document",
+				Optional:            true,
+			},
+			"index_name": schema.StringAttribute{
+				MarkdownDescription: "Name of the first index scanned by the winning plan, null when no index is used",
+				Computed:            true,
+			},
+			"index_names": schema.ListAttribute{
+				MarkdownDescription: "Names of all indexes scanned by the winning plan",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"collection_scan": schema.BoolAttribute{
+				MarkdownDescription: "Whether the winning plan performs a collection scan",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *QueryPlanDataSource) Configure(
+	_ context.Context,
+	req datasource.ConfigureRequest,
+	resp *datasource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	p, ok := req.ProviderData.(*MongodbProvider)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *MongodbProvider, got: %T. "+
+				"Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = p.client
+}
+
+func (d *QueryPlanDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if d.client == nil {
+		resp.Diagnostics.AddError(
+			"MongoDB client is not configured",
+			"Expected configured MongoDB client. Please report this issue to the provider developers.",
+		)
+
+		return
+	}
+
+	var config QueryPlanDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan, err := d.client.ExplainQuery(ctx, &mongodb.ExplainQueryOptions{
+		Database:   config.Database.ValueString(),
+		Collection: config.Collection.ValueString(),
+		Filter:     config.Filter.ValueString(),
+		Sort:       config.Sort.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"failed to explain query",
+			err.Error(),
+		)
+
+		return
+	}
+
+	tflog.Debug(ctx, "query plan", map[string]interface{}{
+		"index_names":     plan.IndexNames,
+		"collection_scan": plan.CollectionScan,
+	})
+
+	resp.Diagnostics.Append(config.updateState(ctx, plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
+}
@@ -0,0 +1,420 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/megum1n/terraform-provider-mongodb/internal/mongodb"
+)
+
+var _ resource.Resource = &RolePrivilegesResource{}
+var _ resource.ResourceWithConfigure = &RolePrivilegesResource{}
+var _ resource.ResourceWithImportState = &RolePrivilegesResource{}
+var _ resource.ResourceWithModifyPlan = &RolePrivilegesResource{}
+var _ resource.ResourceWithValidateConfig = &RolePrivilegesResource{}
+
+func NewRolePrivilegesResource() resource.Resource {
+	return &RolePrivilegesResource{}
+}
+
+type RolePrivilegesResource struct {
+	client          *mongodb.Client
+	defaultDatabase string
+}
+
+type RolePrivilegesResourceModel struct {
+	Role                types.String `tfsdk:"role"`
+	Database            types.String `tfsdk:"database"`
+	Privileges          types.Set    `tfsdk:"privileges"`
+	AllowUnknownActions types.Bool   `tfsdk:"allow_unknown_actions"`
+	AllowAllResources   types.Bool   `tfsdk:"allow_all_resources"`
+}
+
+func (m *RolePrivilegesResourceModel) options(ctx context.Context) (*mongodb.RolePrivilegesOptions, diag.Diagnostics) {
+	options := &mongodb.RolePrivilegesOptions{
+		Name:       m.Role.ValueString(),
+		Database:   m.Database.ValueString(),
+		Privileges: mongodb.Privileges{},
+	}
+
+	diags := m.Privileges.ElementsAs(ctx, &options.Privileges, false)
+
+	return options, diags
+}
+
+// updateState keeps the managed actions which are still granted, the other privileges of the role are ignored.
+func (m *RolePrivilegesResourceModel) updateState(ctx context.Context, role *mongodb.Role) diag.Diagnostics {
+	options, diags := m.options(ctx)
+	if diags.HasError() {
+		return diags
+	}
+
+	privileges := options.Privileges.Intersect(role.Privileges)
+
+	set, d := privileges.ToTerraformSet(ctx)
+	diags.Append(d...)
+
+	if diags.HasError() {
+		return diags
+	}
+
+	m.Privileges = *set
+
+	return diags
+}
+
+func (r *RolePrivilegesResource) Metadata(
+	_ context.Context,
+	req resource.MetadataRequest,
+	resp *resource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_role_privileges"
+}
+
+func (r *RolePrivilegesResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	privileges := privilegesAttribute("The privileges granted to the role by this resource", false)
+	privileges.Validators = []validator.Set{
+		setvalidator.SizeAtLeast(1),
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Grants privileges to an existing role without owning all of its privileges, " +
+			"so that several modules can extend the same role. Only the actions listed here are " +
+			"granted, revoked and checked for drift. When the role is managed by `mongodb_role`, " +
+			"add `privileges` to its `ignore_changes` to keep it from revoking these privileges.",
+
+		Attributes: map[string]schema.Attribute{
+			"role": schema.StringAttribute{
+				MarkdownDescription: "Name of the role",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"database": schema.StringAttribute{
+				MarkdownDescription: "Database of the role. " +
+					"The provider `default_database` is used by default",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"privileges": privileges,
+			"allow_unknown_actions": schema.BoolAttribute{
+				MarkdownDescription: "Skip the check of the privilege actions against the documented MongoDB actions, " +
+					"for the actions added in newer MongoDB versions",
+				Optional: true,
+			},
+			"allow_all_resources": schema.BoolAttribute{
+				MarkdownDescription: "Allow the privilege resources with an empty db and collection, " +
+					"which grant the actions on every collection of every database",
+				Optional: true,
+			},
+		},
+	}
+}
+
+func (r *RolePrivilegesResource) ValidateConfig(
+	ctx context.Context,
+	req resource.ValidateConfigRequest,
+	resp *resource.ValidateConfigResponse,
+) {
+	var config RolePrivilegesResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	validatePrivileges(ctx, config.Privileges, config.AllowAllResources, &resp.Diagnostics)
+}
+
+func (r *RolePrivilegesResource) Configure(
+	_ context.Context,
+	req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	p, ok := req.ProviderData.(*MongodbProvider)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *MongodbProvider, got: %T. "+
+				"Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = p.client
+	r.defaultDatabase = p.defaultDatabase
+}
+
+// ModifyPlan sets default_database as the database of a new resource configured without database.
+func (r *RolePrivilegesResource) ModifyPlan(
+	ctx context.Context,
+	req resource.ModifyPlanRequest,
+	resp *resource.ModifyPlanResponse,
+) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	resp.Diagnostics.Append(planDefaultDatabase(ctx, req.Config, &resp.Plan, r.defaultDatabase)...)
+}
+
+func (r *RolePrivilegesResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if !r.checkClient(&resp.Diagnostics) {
+		return
+	}
+
+	var plan RolePrivilegesResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	options, d := plan.options(ctx)
+
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.GrantPrivilegesToRole(ctx, options)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			commandErrorSummary(err, "failed to grant privileges to role"),
+			err.Error(),
+		)
+
+		return
+	}
+
+	tflog.Trace(ctx, "Role privileges granted")
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *RolePrivilegesResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if !r.checkClient(&resp.Diagnostics) {
+		return
+	}
+
+	var state RolePrivilegesResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	role, err := r.client.GetRole(ctx, &mongodb.GetRoleOptions{
+		Name:     state.Role.ValueString(),
+		Database: state.Database.ValueString(),
+	})
+	if err != nil {
+		if !errors.As(err, &mongodb.NotFoundError{}) {
+			resp.Diagnostics.AddError(
+				"failed to get role",
+				err.Error(),
+			)
+
+			return
+		}
+
+		tflog.Debug(ctx, "role not found, removing role privileges from state")
+		resp.State.RemoveResource(ctx)
+
+		return
+	}
+
+	resp.Diagnostics.Append(state.updateState(ctx, role)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *RolePrivilegesResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if !r.checkClient(&resp.Diagnostics) {
+		return
+	}
+
+	var plan, state RolePrivilegesResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	planned, d := plan.options(ctx)
+	resp.Diagnostics.Append(d...)
+
+	current, d := state.options(ctx)
+	resp.Diagnostics.Append(d...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	revoked := &mongodb.RolePrivilegesOptions{
+		Name:       current.Name,
+		Database:   current.Database,
+		Privileges: current.Privileges.Subtract(planned.Privileges),
+	}
+
+	// Granting an action which is already granted is a no-op
+	err := r.client.GrantPrivilegesToRole(ctx, planned)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			commandErrorSummary(err, "failed to grant privileges to role"),
+			err.Error(),
+		)
+
+		return
+	}
+
+	err = r.client.RevokePrivilegesFromRole(ctx, revoked)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			commandErrorSummary(err, "failed to revoke privileges from role"),
+			err.Error(),
+		)
+
+		return
+	}
+
+	tflog.Trace(ctx, "Role privileges updated")
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *RolePrivilegesResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if !r.checkClient(&resp.Diagnostics) {
+		return
+	}
+
+	var state RolePrivilegesResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	options, d := state.options(ctx)
+
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.RevokePrivilegesFromRole(ctx, options)
+	if err != nil {
+		// The privileges of a dropped role are gone as well
+		if mongodb.IsNotFoundError(err) {
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			commandErrorSummary(err, "failed to revoke privileges from role"),
+			err.Error(),
+		)
+
+		return
+	}
+
+	tflog.Trace(ctx, "Role privileges revoked")
+}
+
+// ImportState imports all the privileges currently granted to the role, except the inherited ones.
+func (r *RolePrivilegesResource) ImportState(
+	ctx context.Context,
+	req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse,
+) {
+	if !r.checkClient(&resp.Diagnostics) {
+		return
+	}
+
+	idParts := strings.Split(req.ID, ".")
+
+	var name, database string
+
+	switch {
+	case len(idParts) == 2:
+		database = idParts[0]
+		name = idParts[1]
+	case len(idParts) == 1:
+		name = idParts[0]
+		database = r.defaultDatabase
+	default:
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: '[<db>.]<role>'. Got: %q", req.ID),
+		)
+
+		return
+	}
+
+	role, err := r.client.GetRole(ctx, &mongodb.GetRoleOptions{
+		Name:     name,
+		Database: database,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to get role",
+			err.Error(),
+		)
+
+		return
+	}
+
+	privileges, d := role.Privileges.ToTerraformSet(ctx)
+
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state := RolePrivilegesResourceModel{
+		Role:                types.StringValue(role.Name),
+		Database:            types.StringValue(role.Database),
+		Privileges:          *privileges,
+		AllowUnknownActions: types.BoolNull(),
+		AllowAllResources:   types.BoolNull(),
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *RolePrivilegesResource) checkClient(diags *diag.Diagnostics) bool {
+	if r.client == nil {
+		diags.AddError(
+			"MongoDB client is not configured",
+			"Expected configured MongoDB client. Please report this issue to the provider developers.",
+		)
+
+		return false
+	}
+
+	return true
+}
@@ -6,8 +6,9 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
-	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -15,7 +16,9 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 
 	"github.com/megum1n/terraform-provider-mongodb/internal/mongodb"
@@ -24,14 +27,17 @@ import (
 var _ resource.Resource = &RoleResource{}
 var _ resource.ResourceWithConfigure = &RoleResource{}
 var _ resource.ResourceWithImportState = &RoleResource{}
+var _ resource.ResourceWithModifyPlan = &RoleResource{}
 var _ resource.ResourceWithConfigValidators = &RoleResource{}
+var _ resource.ResourceWithValidateConfig = &RoleResource{}
 
 func NewRoleResource() resource.Resource {
 	return &RoleResource{}
 }
 
 type RoleResource struct {
-	client *mongodb.Client
+	client          *mongodb.Client
+	defaultDatabase string
 }
 
 type RoleResourceModel struct {
@@ -39,12 +45,21 @@ type RoleResourceModel struct {
 	Database   types.String `tfsdk:"database"`
 	Roles      types.Set    `tfsdk:"roles"`
 	Privileges types.Set    `tfsdk:"privileges"`
+	MaxTimeMS  types.Int64  `tfsdk:"max_time_ms"`
+
+	AuthenticationRestrictions types.List `tfsdk:"authentication_restrictions"`
+	AllowUnknownActions        types.Bool `tfsdk:"allow_unknown_actions"`
+	AllowAllResources          types.Bool `tfsdk:"allow_all_resources"`
 }
 
 func newRoleResourceModel() RoleResourceModel {
 	return RoleResourceModel{
 		Roles:      types.SetNull(types.ObjectType{AttrTypes: mongodb.ShortRoleAttributeTypes}),
 		Privileges: types.SetNull(types.ObjectType{AttrTypes: mongodb.PrivilegeAttributeTypes}),
+
+		AuthenticationRestrictions: types.ListNull(
+			types.ObjectType{AttrTypes: mongodb.AuthenticationRestrictionAttributeTypes},
+		),
 	}
 }
 
@@ -64,6 +79,12 @@ func (r *RoleResourceModel) updateState(ctx context.Context, role *mongodb.Role)
 	diags.Append(d...)
 	r.Privileges = *privileges
 
+	// Keep the restrictions null when none are configured
+	if len(role.AuthenticationRestrictions) > 0 || !r.AuthenticationRestrictions.IsNull() {
+		r.AuthenticationRestrictions, d = role.AuthenticationRestrictions.ToTerraformList(ctx)
+		diags.Append(d...)
+	}
+
 	return diags
 }
 
@@ -84,12 +105,12 @@ func (r *RoleResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 				},
 			},
 			"database": schema.StringAttribute{
-				MarkdownDescription: fmt.Sprintf("Target database name. "+
-					"%q is used by default", defaultDatabase),
+				MarkdownDescription: "Target database name. " +
+					"The provider `default_database` is used by default",
 				Optional: true,
 				Computed: true,
-				Default:  stringdefault.StaticString(defaultDatabase),
 				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
@@ -112,29 +133,29 @@ func (r *RoleResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 					},
 				},
 			},
-			"privileges": schema.SetNestedAttribute{
-				MarkdownDescription: "Set of the privileges to grant the role",
-				Optional:            true,
-				Computed:            true,
-				NestedObject: schema.NestedAttributeObject{
-					Attributes: map[string]schema.Attribute{
-						"resource": schema.ObjectAttribute{
-							MarkdownDescription: "A document that specifies the resources " +
-								"upon which the privilege actions apply",
-							AttributeTypes: map[string]attr.Type{
-								"db":         types.StringType,
-								"collection": types.StringType,
-							},
-							Required: true,
-						},
-						"actions": schema.SetAttribute{
-							MarkdownDescription: "An array of actions permitted on the resource",
-							ElementType:         types.StringType,
-							Required:            true,
-						},
-					},
+			"privileges": privilegesAttribute("Set of the privileges to grant the role", true),
+			"max_time_ms": schema.Int64Attribute{
+				MarkdownDescription: "Server-side time limit in milliseconds " +
+					"for the commands creating and updating the role",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
 				},
 			},
+			"authentication_restrictions": authenticationRestrictionsAttribute(
+				"Addresses the users granted the role can authenticate from and to. " +
+					"Removing the attribute clears the restrictions",
+			),
+			"allow_unknown_actions": schema.BoolAttribute{
+				MarkdownDescription: "Skip the check of the privilege actions against the documented MongoDB actions, " +
+					"for the actions added in newer MongoDB versions",
+				Optional: true,
+			},
+			"allow_all_resources": schema.BoolAttribute{
+				MarkdownDescription: "Allow the privilege resources with an empty db and collection, " +
+					"which grant the actions on every collection of every database",
+				Optional: true,
+			},
 		},
 	}
 }
@@ -156,6 +177,59 @@ func (r *RoleResource) Configure(_ context.Context, req resource.ConfigureReques
 	}
 
 	r.client = p.client
+	r.defaultDatabase = p.defaultDatabase
+}
+
+// ModifyPlan sets default_database as the database of a new resource configured without database.
+func (r *RoleResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	resp.Diagnostics.Append(planDefaultDatabase(ctx, req.Config, &resp.Plan, r.defaultDatabase)...)
+	if resp.Diagnostics.HasError() || req.State.Raw.IsNull() {
+		return
+	}
+
+	var state, plan RoleResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(resp.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	warnRoleReplacement(&state, &plan, &resp.Diagnostics)
+}
+
+// warnRoleReplacement explains that renaming or moving a role drops it, which revokes it from every user
+// and role it's granted to. The new role is not granted back to them.
+func warnRoleReplacement(state, plan *RoleResourceModel, diags *diag.Diagnostics) {
+	for _, attribute := range []struct {
+		name         string
+		state, value types.String
+	}{
+		{name: "database", state: state.Database, value: plan.Database},
+		{name: "name", state: state.Name, value: plan.Name},
+	} {
+		if attribute.value.IsUnknown() || attribute.value.Equal(attribute.state) {
+			continue
+		}
+
+		diags.AddAttributeWarning(
+			path.Root(attribute.name),
+			"Role will be replaced",
+			fmt.Sprintf("Changing %s drops the role %q of the %q database and creates %q in %q. "+
+				"Dropping a role revokes it from every user and role it's granted to, and the new role is "+
+				"not granted back: re-apply the grants of the dependent users and roles, e.g. by referencing "+
+				"this resource from them. Use lifecycle { prevent_destroy = true } to forbid the replacement.",
+				attribute.name, state.Name.ValueString(), state.Database.ValueString(),
+				plan.Name.ValueString(), plan.Database.ValueString()),
+		)
+
+		return
+	}
 }
 
 func (r *RoleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -190,15 +264,25 @@ func (r *RoleResource) Create(ctx context.Context, req resource.CreateRequest, r
 		}
 	}
 
+	restrictions, d := authenticationRestrictions(ctx, plan.AuthenticationRestrictions)
+
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	role, err := r.client.UpsertRole(ctx, &mongodb.Role{
 		Name:       plan.Name.ValueString(),
 		Database:   plan.Database.ValueString(),
 		Privileges: privileges,
 		Roles:      roles,
+		MaxTimeMS:  plan.MaxTimeMS.ValueInt64(),
+
+		AuthenticationRestrictions: restrictions,
 	})
 	if err != nil {
 		resp.Diagnostics.AddError(
-			"failed to upsert role",
+			commandErrorSummary(err, "failed to upsert role"),
 			err.Error(),
 		)
 
@@ -282,15 +366,32 @@ func (r *RoleResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		return
 	}
 
+	restrictions, d := authenticationRestrictions(ctx, plan.AuthenticationRestrictions)
+
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	restrictions, d = removedAuthenticationRestrictions(ctx, req.State, restrictions)
+
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	role, err := r.client.UpsertRole(ctx, &mongodb.Role{
 		Name:       plan.Name.ValueString(),
 		Database:   plan.Database.ValueString(),
 		Privileges: privileges,
 		Roles:      roles,
+		MaxTimeMS:  plan.MaxTimeMS.ValueInt64(),
+
+		AuthenticationRestrictions: restrictions,
 	})
 	if err != nil {
 		resp.Diagnostics.AddError(
-			"failed to upsert role",
+			commandErrorSummary(err, "failed to upsert role"),
 			err.Error(),
 		)
 
@@ -324,7 +425,7 @@ func (r *RoleResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 	})
 	if err != nil {
 		resp.Diagnostics.AddError(
-			"failed to delete role",
+			commandErrorSummary(err, "failed to delete role"),
 			err.Error(),
 		)
 	}
@@ -352,7 +453,7 @@ func (r *RoleResource) ImportState(
 		name = idParts[1]
 	case len(idParts) == 1:
 		name = idParts[0]
-		database = defaultDatabase
+		database = r.defaultDatabase
 	default:
 		resp.Diagnostics.AddError(
 			"Unexpected Import Identifier",
@@ -385,6 +486,213 @@ func (r *RoleResource) ImportState(
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
 
+func (r *RoleResource) ValidateConfig(
+	ctx context.Context,
+	req resource.ValidateConfigRequest,
+	resp *resource.ValidateConfigResponse,
+) {
+	config := newRoleResourceModel()
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	validatePrivileges(ctx, config.Privileges, config.AllowAllResources, &resp.Diagnostics)
+}
+
+// privilegesAttribute returns the schema of the privileges granted to a role, computed as well when optional.
+func privilegesAttribute(description string, optional bool) schema.SetNestedAttribute {
+	return schema.SetNestedAttribute{
+		MarkdownDescription: description,
+		Optional:            optional,
+		Computed:            optional,
+		Required:            !optional,
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"resource": schema.SingleNestedAttribute{
+					MarkdownDescription: "A document that specifies the resources " +
+						"upon which the privilege actions apply. Either db and collection, " +
+						"cluster or any_resource",
+					Required: true,
+					Attributes: map[string]schema.Attribute{
+						"db": schema.StringAttribute{
+							MarkdownDescription: "Database name, empty for every database. " +
+								"An empty db and collection require `allow_all_resources`",
+							Optional: true,
+						},
+						"collection": schema.StringAttribute{
+							MarkdownDescription: "Collection name, empty for every collection",
+							Optional:            true,
+						},
+						"cluster": schema.BoolAttribute{
+							MarkdownDescription: "Grant the actions on the cluster, e.g. listDatabases. " +
+								"Conflicts with db and collection",
+							Optional: true,
+						},
+						"any_resource": schema.BoolAttribute{
+							MarkdownDescription: "Grant the actions on every resource of the system. " +
+								"Intended for internal use. Conflicts with db and collection",
+							Optional: true,
+						},
+					},
+				},
+				"actions": schema.SetAttribute{
+					MarkdownDescription: "An array of actions permitted on the resource",
+					ElementType:         types.StringType,
+					Required:            true,
+					Validators: []validator.Set{
+						setvalidator.ValueStringsAre(knownAction()),
+					},
+				},
+			},
+		},
+	}
+}
+
+// validatePrivileges checks the resources of the privileges and the scope of their actions.
+// The resources of every database and collection are rejected unless allowAllResources is set.
+func validatePrivileges(ctx context.Context, set types.Set, allowAllResources types.Bool, diags *diag.Diagnostics) {
+	if set.IsNull() || set.IsUnknown() {
+		return
+	}
+
+	var privileges []types.Object
+
+	diags.Append(set.ElementsAs(ctx, &privileges, false)...)
+	if diags.HasError() {
+		return
+	}
+
+	for _, privilegeObject := range privileges {
+		if privilegeObject.IsUnknown() {
+			continue
+		}
+
+		var privilege struct {
+			Resource types.Object `tfsdk:"resource"`
+			Actions  types.Set    `tfsdk:"actions"`
+		}
+
+		diags.Append(privilegeObject.As(ctx, &privilege, basetypes.ObjectAsOptions{})...)
+		if diags.HasError() {
+			return
+		}
+
+		if privilege.Actions.IsUnknown() || privilege.Resource.IsUnknown() {
+			continue
+		}
+
+		resourceScope, d := validatePrivilegeResource(ctx, privilege.Resource,
+			allowAllResources.IsUnknown() || allowAllResources.ValueBool())
+
+		diags.Append(d...)
+		if diags.HasError() || resourceScope == mongodb.ActionScopeAny {
+			continue
+		}
+
+		var actions []types.String
+
+		diags.Append(privilege.Actions.ElementsAs(ctx, &actions, false)...)
+		if diags.HasError() {
+			return
+		}
+
+		for _, action := range actions {
+			validateActionScope(action, resourceScope, diags)
+		}
+	}
+}
+
+// validatePrivilegeResource checks that the resource is either a database and collection pair,
+// the cluster or any resource. The scope of the actions the resource accepts is returned.
+func validatePrivilegeResource(
+	ctx context.Context,
+	object types.Object,
+	allowAllResources bool,
+) (mongodb.ActionScope, diag.Diagnostics) {
+	var resource struct {
+		DB          types.String `tfsdk:"db"`
+		Collection  types.String `tfsdk:"collection"`
+		Cluster     types.Bool   `tfsdk:"cluster"`
+		AnyResource types.Bool   `tfsdk:"any_resource"`
+	}
+
+	diags := object.As(ctx, &resource, basetypes.ObjectAsOptions{})
+	if diags.HasError() {
+		return 0, diags
+	}
+
+	for attribute, value := range map[string]types.Bool{"cluster": resource.Cluster, "any_resource": resource.AnyResource} {
+		if !value.IsNull() && !value.IsUnknown() && !value.ValueBool() {
+			diags.AddAttributeError(
+				path.Root("privileges"),
+				"Invalid privilege resource",
+				fmt.Sprintf("%s must be true when set, remove it instead", attribute),
+			)
+		}
+	}
+
+	database := !resource.DB.IsNull() || !resource.Collection.IsNull()
+	cluster := resource.Cluster.ValueBool()
+	anyResource := resource.AnyResource.ValueBool()
+
+	switch {
+	case diags.HasError():
+		return 0, diags
+	case cluster && anyResource, (cluster || anyResource) && database:
+		diags.AddAttributeError(
+			path.Root("privileges"),
+			"Invalid privilege resource",
+			"Exactly one of db and collection, cluster or any_resource must be set",
+		)
+	case cluster:
+		return mongodb.ActionScopeCluster, diags
+	case anyResource:
+		return mongodb.ActionScopeAny, diags
+	case resource.DB.IsNull() || resource.Collection.IsNull():
+		// Unknown values are reported as null until they are known
+		if resource.DB.IsUnknown() || resource.Collection.IsUnknown() {
+			return mongodb.ActionScopeDatabase, diags
+		}
+
+		diags.AddAttributeError(
+			path.Root("privileges"),
+			"Invalid privilege resource",
+			"Both db and collection must be set for a database resource, use an empty string for all of them",
+		)
+	case !allowAllResources && resource.DB.ValueString() == "" && resource.Collection.ValueString() == "" &&
+		!resource.DB.IsUnknown() && !resource.Collection.IsUnknown():
+		diags.AddAttributeError(
+			path.Root("privileges"),
+			"Privilege resource grants every collection",
+			"A resource with an empty db and collection grants the actions on every collection of every database. "+
+				"Set allow_all_resources = true if this is intended",
+		)
+	}
+
+	return mongodb.ActionScopeDatabase, diags
+}
+
+// validateActionScope reports the actions which have no effect on the given kind of privilege resource.
+func validateActionScope(action types.String, resourceScope mongodb.ActionScope, diags *diag.Diagnostics) {
+	if action.IsNull() || action.IsUnknown() {
+		return
+	}
+
+	scope, ok := mongodb.GetActionScope(action.ValueString())
+	if !ok || scope == mongodb.ActionScopeAny || scope == resourceScope {
+		return
+	}
+
+	diags.AddAttributeError(
+		path.Root("privileges"),
+		"Invalid privilege action",
+		fmt.Sprintf("Action %q applies to the %s resource only and has no effect on a %s resource",
+			action.ValueString(), scope, resourceScope),
+	)
+}
+
 func (r *RoleResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
 	return []resource.ConfigValidator{
 		resourcevalidator.AtLeastOneOf(
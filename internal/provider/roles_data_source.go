@@ -0,0 +1,227 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/megum1n/terraform-provider-mongodb/internal/mongodb"
+)
+
+var _ datasource.DataSource = &RolesDataSource{}
+var _ datasource.DataSourceWithConfigure = &RolesDataSource{}
+
+func NewRolesDataSource() datasource.DataSource {
+	return &RolesDataSource{}
+}
+
+type RolesDataSource struct {
+	client          *mongodb.Client
+	defaultDatabase string
+}
+
+type RolesDataSourceModel struct {
+	Database       types.String        `tfsdk:"database"`
+	IncludeBuiltin types.Bool          `tfsdk:"include_builtin"`
+	Roles          []RolesElementModel `tfsdk:"roles"`
+}
+
+type RolesElementModel struct {
+	Name       types.String `tfsdk:"name"`
+	Database   types.String `tfsdk:"database"`
+	Builtin    types.Bool   `tfsdk:"builtin"`
+	Roles      types.Set    `tfsdk:"roles"`
+	Privileges types.Set    `tfsdk:"privileges"`
+}
+
+func (r *RolesElementModel) updateState(ctx context.Context, role *mongodb.Role) diag.Diagnostics {
+	diags := diag.Diagnostics{}
+
+	r.Name = types.StringValue(role.Name)
+	r.Database = types.StringValue(role.Database)
+	r.Builtin = types.BoolValue(role.IsBuiltin)
+
+	roles, d := role.Roles.ToTerraformSet(ctx)
+	diags.Append(d...)
+
+	if roles != nil {
+		r.Roles = *roles
+	}
+
+	privileges, d := role.Privileges.ToTerraformSet(ctx)
+	diags.Append(d...)
+
+	if privileges != nil {
+		r.Privileges = *privileges
+	}
+
+	return diags
+}
+
+func (d *RolesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_roles"
+}
+
+func (d *RolesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the roles of a MongoDB database with their privileges. " +
+			"Requires the `viewRole` privilege",
+
+		Attributes: map[string]schema.Attribute{
+			"database": schema.StringAttribute{
+				MarkdownDescription: "Database name. " +
+					"The provider `default_database` is used by default",
+				Optional: true,
+				Computed: true,
+			},
+			"include_builtin": schema.BoolAttribute{
+				MarkdownDescription: "Include the built-in roles. Only the custom roles are listed by default",
+				Optional:            true,
+			},
+			"roles": schema.ListNestedAttribute{
+				MarkdownDescription: "The roles defined in the database",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "Role name",
+							Computed:            true,
+						},
+						"database": schema.StringAttribute{
+							MarkdownDescription: "Database name",
+							Computed:            true,
+						},
+						"builtin": schema.BoolAttribute{
+							MarkdownDescription: "Whether the role is a built-in role",
+							Computed:            true,
+						},
+						"roles": schema.SetNestedAttribute{
+							MarkdownDescription: "Set of roles from which this role inherits privileges",
+							Computed:            true,
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"role": schema.StringAttribute{
+										MarkdownDescription: "Role name",
+										Computed:            true,
+									},
+									"db": schema.StringAttribute{
+										MarkdownDescription: "Target database name",
+										Computed:            true,
+									},
+								},
+							},
+						},
+						"privileges": schema.SetNestedAttribute{
+							MarkdownDescription: "Set of the privileges granted to the role",
+							Computed:            true,
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"resource": schema.ObjectAttribute{
+										MarkdownDescription: "A document that specifies the resources " +
+											"upon which the privilege actions apply",
+										AttributeTypes: mongodb.ResourceAttributeTypes,
+										Computed:       true,
+									},
+									"actions": schema.SetAttribute{
+										MarkdownDescription: "An array of actions permitted on the resource",
+										ElementType:         types.StringType,
+										Computed:            true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *RolesDataSource) Configure(
+	_ context.Context,
+	req datasource.ConfigureRequest,
+	resp *datasource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	p, ok := req.ProviderData.(*MongodbProvider)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *MongodbProvider, got: %T. "+
+				"Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = p.client
+	d.defaultDatabase = p.defaultDatabase
+}
+
+func (d *RolesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if d.client == nil {
+		resp.Diagnostics.AddError(
+			"MongoDB client is not configured",
+			"Expected configured MongoDB client. Please report this issue to the provider developers.",
+		)
+
+		return
+	}
+
+	var config RolesDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.Database.ValueString() == "" {
+		config.Database = types.StringValue(d.defaultDatabase)
+	}
+
+	roles, err := d.client.ListRoles(ctx, &mongodb.ListRolesOptions{
+		Database:       config.Database.ValueString(),
+		IncludeBuiltin: config.IncludeBuiltin.ValueBool(),
+	})
+	if err != nil {
+		if errors.As(err, &mongodb.UnauthorizedError{}) {
+			resp.Diagnostics.AddError(
+				"not authorized to list roles",
+				fmt.Sprintf("Listing the roles of %q requires the viewRole privilege on the database, "+
+					"e.g. granted by the userAdmin role: %s", config.Database.ValueString(), err),
+			)
+
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			"failed to list roles",
+			err.Error(),
+		)
+
+		return
+	}
+
+	config.Roles = make([]RolesElementModel, 0, len(roles))
+
+	for i := range roles {
+		var role RolesElementModel
+
+		resp.Diagnostics.Append(role.updateState(ctx, &roles[i])...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		config.Roles = append(config.Roles, role)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
+}
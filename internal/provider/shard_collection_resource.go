@@ -0,0 +1,497 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int32validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/megum1n/terraform-provider-mongodb/internal/mongodb"
+)
+
+var (
+	_ resource.Resource                   = &ShardCollectionResource{}
+	_ resource.ResourceWithConfigure      = &ShardCollectionResource{}
+	_ resource.ResourceWithImportState    = &ShardCollectionResource{}
+	_ resource.ResourceWithModifyPlan     = &ShardCollectionResource{}
+	_ resource.ResourceWithValidateConfig = &ShardCollectionResource{}
+)
+
+// shardKeyTypes are the key types of ranged and hashed shard keys.
+var shardKeyTypes = []string{"1", "hashed"}
+
+func NewShardCollectionResource() resource.Resource {
+	return &ShardCollectionResource{}
+}
+
+type ShardCollectionResource struct {
+	client *mongodb.Client
+}
+
+type ShardCollectionResourceModel struct {
+	ID               types.String `tfsdk:"id"`
+	Database         types.String `tfsdk:"database"`
+	Collection       types.String `tfsdk:"collection"`
+	Key              types.List   `tfsdk:"key"`
+	Unique           types.Bool   `tfsdk:"unique"`
+	NumInitialChunks types.Int32  `tfsdk:"num_initial_chunks"`
+}
+
+// shardKey returns the configured shard key, or nil while it is unknown.
+func (m *ShardCollectionResourceModel) shardKey(ctx context.Context) (mongodb.IndexKeys, diag.Diagnostics) {
+	if m.Key.IsNull() || m.Key.IsUnknown() {
+		return nil, nil
+	}
+
+	var models []IndexKeyModel
+
+	diags := m.Key.ElementsAs(ctx, &models, false)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	key := mongodb.IndexKeys{}
+
+	for _, model := range models {
+		if model.Field.IsUnknown() || model.Type.IsUnknown() {
+			return nil, diags
+		}
+
+		key = append(key, mongodb.NewIndexKey(model.Field.ValueString(), model.Type.ValueString()))
+	}
+
+	return key, diags
+}
+
+func (m *ShardCollectionResourceModel) updateState(
+	ctx context.Context,
+	collection *mongodb.ShardedCollection,
+) diag.Diagnostics {
+	key, diags := indexKeysListValue(ctx, collection.Key)
+	if diags.HasError() {
+		return diags
+	}
+
+	m.ID = types.StringValue(collection.Database + "." + collection.Collection)
+	m.Database = types.StringValue(collection.Database)
+	m.Collection = types.StringValue(collection.Collection)
+	m.Key = key
+	m.Unique = types.BoolValue(collection.Unique)
+
+	return diags
+}
+
+func (r *ShardCollectionResource) Metadata(
+	_ context.Context,
+	req resource.MetadataRequest,
+	resp *resource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_shard_collection"
+}
+
+func (r *ShardCollectionResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Shards a collection of a sharded cluster with `shardCollection`. " +
+			"Sharding must be enabled on the database first, e.g. with `mongodb_shard_database`. " +
+			"Changing the shard key reshards the collection with `reshardCollection`, from MongoDB 5.0. " +
+			"Destroying the resource only removes it from the Terraform state.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Namespace of the collection: `<database>.<collection>`",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"database": schema.StringAttribute{
+				MarkdownDescription: "Database name",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"collection": schema.StringAttribute{
+				MarkdownDescription: "Collection name",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"key": schema.ListNestedAttribute{
+				MarkdownDescription: "Shard key fields in order. Changing it reshards the collection, " +
+					"which copies all its documents and blocks the writes for up to two seconds at the end",
+				Required: true,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"field": schema.StringAttribute{
+							MarkdownDescription: "Shard key field name",
+							Required:            true,
+						},
+						"type": schema.StringAttribute{
+							MarkdownDescription: "`1` for a ranged shard key field or `hashed`",
+							Required:            true,
+							Validators: []validator.String{
+								stringvalidator.OneOf(shardKeyTypes...),
+							},
+						},
+					},
+				},
+			},
+			"unique": schema.BoolAttribute{
+				MarkdownDescription: "Enforce the uniqueness of the shard key. Not supported for hashed shard keys. " +
+					"Can't be changed once the collection is sharded, and a unique shard key can't be changed",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"num_initial_chunks": schema.Int32Attribute{
+				MarkdownDescription: "Number of chunks created for an empty collection with a hashed shard key. " +
+					"Only used when the collection is sharded or resharded",
+				Optional: true,
+				Validators: []validator.Int32{
+					int32validator.AtLeast(1),
+				},
+			},
+		},
+	}
+}
+
+func (r *ShardCollectionResource) ValidateConfig(
+	ctx context.Context,
+	req resource.ValidateConfigRequest,
+	resp *resource.ValidateConfigResponse,
+) {
+	var config ShardCollectionResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	key, d := config.shardKey(ctx)
+
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() || key == nil {
+		return
+	}
+
+	hashed := 0
+
+	for _, keyType := range key.Types() {
+		if keyType == "hashed" {
+			hashed++
+		}
+	}
+
+	if hashed > 1 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("key"),
+			"Invalid shard key",
+			"A shard key can have at most one hashed field",
+		)
+	}
+
+	if hashed > 0 && config.Unique.ValueBool() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("unique"),
+			"Invalid shard key",
+			"Hashed shard keys can't be unique",
+		)
+	}
+
+	if hashed == 0 && !config.NumInitialChunks.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("num_initial_chunks"),
+			"Invalid shard key",
+			"num_initial_chunks is only supported for hashed shard keys",
+		)
+	}
+}
+
+// ModifyPlan rejects the changes reshardCollection can't apply: the uniqueness of the shard key,
+// and the key of a unique shard key.
+func (r *ShardCollectionResource) ModifyPlan(
+	ctx context.Context,
+	req resource.ModifyPlanRequest,
+	resp *resource.ModifyPlanResponse,
+) {
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan, state ShardCollectionResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() || plan.Unique.IsUnknown() || plan.Key.IsUnknown() {
+		return
+	}
+
+	if !plan.Unique.Equal(state.Unique) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("unique"),
+			"Shard key uniqueness can't be changed",
+			fmt.Sprintf("The %s collection is already sharded, reshardCollection doesn't change "+
+				"the uniqueness of the shard key", state.ID.ValueString()),
+		)
+
+		return
+	}
+
+	if state.Unique.ValueBool() && !plan.Key.Equal(state.Key) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("key"),
+			"Unique shard key can't be changed",
+			fmt.Sprintf("The shard key of the %s collection is unique, reshardCollection only supports "+
+				"the shard keys which are not unique", state.ID.ValueString()),
+		)
+	}
+}
+
+func (r *ShardCollectionResource) Configure(
+	_ context.Context,
+	req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	p, ok := req.ProviderData.(*MongodbProvider)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *MongodbProvider, got: %T. "+
+				"Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = p.client
+}
+
+func (r *ShardCollectionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if !r.checkClient(&resp.Diagnostics) {
+		return
+	}
+
+	var plan ShardCollectionResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	key, d := plan.shardKey(ctx)
+
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	collection, err := r.client.ShardCollection(ctx, &mongodb.ShardCollectionOptions{
+		Database:         plan.Database.ValueString(),
+		Collection:       plan.Collection.ValueString(),
+		Key:              key,
+		Unique:           plan.Unique.ValueBool(),
+		NumInitialChunks: plan.NumInitialChunks.ValueInt32(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			commandErrorSummary(err, "failed to shard collection"),
+			err.Error(),
+		)
+
+		return
+	}
+
+	resp.Diagnostics.Append(plan.updateState(ctx, collection)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "Collection sharded")
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *ShardCollectionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if !r.checkClient(&resp.Diagnostics) {
+		return
+	}
+
+	var state ShardCollectionResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	collection, err := r.client.GetShardedCollection(ctx, &mongodb.GetShardedCollectionOptions{
+		Database:   state.Database.ValueString(),
+		Collection: state.Collection.ValueString(),
+	})
+	if err != nil {
+		if !errors.As(err, &mongodb.NotFoundError{}) {
+			resp.Diagnostics.AddError(
+				commandErrorSummary(err, "failed to get sharded collection"),
+				err.Error(),
+			)
+
+			return
+		}
+
+		tflog.Debug(ctx, "sharded collection not found, removing from state")
+		resp.State.RemoveResource(ctx)
+
+		return
+	}
+
+	resp.Diagnostics.Append(state.updateState(ctx, collection)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update reshards the collection when the shard key changes, num_initial_chunks is only stored otherwise.
+func (r *ShardCollectionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if !r.checkClient(&resp.Diagnostics) {
+		return
+	}
+
+	var plan, state ShardCollectionResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !plan.Key.Equal(state.Key) {
+		key, d := plan.shardKey(ctx)
+
+		resp.Diagnostics.Append(d...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		collection, err := r.client.ReshardCollection(ctx, &mongodb.ShardCollectionOptions{
+			Database:         plan.Database.ValueString(),
+			Collection:       plan.Collection.ValueString(),
+			Key:              key,
+			Unique:           plan.Unique.ValueBool(),
+			NumInitialChunks: plan.NumInitialChunks.ValueInt32(),
+		})
+		if err != nil {
+			resp.Diagnostics.AddError(
+				commandErrorSummary(err, "failed to reshard collection"),
+				err.Error(),
+			)
+
+			return
+		}
+
+		resp.Diagnostics.Append(plan.updateState(ctx, collection)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		tflog.Trace(ctx, "Collection resharded")
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *ShardCollectionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state ShardCollectionResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.AddWarning(
+		"Collection not unsharded",
+		fmt.Sprintf("The %s collection stays sharded, it was only removed from the Terraform state",
+			state.ID.ValueString()),
+	)
+}
+
+func (r *ShardCollectionResource) ImportState(
+	ctx context.Context,
+	req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse,
+) {
+	if !r.checkClient(&resp.Diagnostics) {
+		return
+	}
+
+	database, collection, ok := strings.Cut(req.ID, ".")
+	if !ok || database == "" || collection == "" {
+		resp.Diagnostics.AddError(
+			"Invalid import ID",
+			"Import ID should be in the format: database.collection",
+		)
+
+		return
+	}
+
+	sharded, err := r.client.GetShardedCollection(ctx, &mongodb.GetShardedCollectionOptions{
+		Database:   database,
+		Collection: collection,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error importing sharded collection",
+			fmt.Sprintf("Failed to read sharded collection %s: %s", req.ID, err),
+		)
+
+		return
+	}
+
+	state := ShardCollectionResourceModel{
+		NumInitialChunks: types.Int32Null(),
+	}
+
+	resp.Diagnostics.Append(state.updateState(ctx, sharded)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *ShardCollectionResource) checkClient(diags *diag.Diagnostics) bool {
+	if r.client == nil {
+		diags.AddError(
+			"MongoDB client is not configured",
+			"Expected configured MongoDB client. Please report this issue to the provider developers.",
+		)
+
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,82 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/megum1n/terraform-provider-mongodb/internal/mongodb"
+)
+
+func TestShardCollectionModifyPlan(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	shardResource := &ShardCollectionResource{}
+
+	schemaResp := &resource.SchemaResponse{}
+	shardResource.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	model := func(t *testing.T, keys mongodb.IndexKeys, unique bool) ShardCollectionResourceModel {
+		t.Helper()
+
+		key, diags := indexKeysListValue(ctx, keys)
+		if diags.HasError() {
+			t.Fatalf("invalid key: %v", diags)
+		}
+
+		return ShardCollectionResourceModel{
+			ID:               types.StringValue("app.users"),
+			Database:         types.StringValue("app"),
+			Collection:       types.StringValue("users"),
+			Key:              key,
+			Unique:           types.BoolValue(unique),
+			NumInitialChunks: types.Int32Null(),
+		}
+	}
+
+	ranged := mongodb.IndexKeys{{Key: "tenant", Value: int32(1)}}
+	hashed := mongodb.IndexKeys{{Key: "tenant", Value: "hashed"}}
+
+	tests := map[string]struct {
+		stateKey, planKey       mongodb.IndexKeys
+		stateUnique, planUnique bool
+		rejected                bool
+	}{
+		"key change":                {stateKey: ranged, planKey: hashed},
+		"no change":                 {stateKey: ranged, planKey: ranged},
+		"unique change":             {stateKey: ranged, planKey: ranged, planUnique: true, rejected: true},
+		"unique key change":         {stateKey: ranged, planKey: hashed, stateUnique: true, planUnique: true, rejected: true},
+		"unique key without change": {stateKey: ranged, planKey: ranged, stateUnique: true, planUnique: true},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			req := resource.ModifyPlanRequest{
+				Plan:  tfsdk.Plan{Schema: schemaResp.Schema},
+				State: tfsdk.State{Schema: schemaResp.Schema},
+			}
+
+			plan, state := model(t, test.planKey, test.planUnique), model(t, test.stateKey, test.stateUnique)
+
+			diags := req.Plan.Set(ctx, &plan)
+			diags.Append(req.State.Set(ctx, &state)...)
+
+			if diags.HasError() {
+				t.Fatalf("failed to set the plan: %v", diags)
+			}
+
+			resp := &resource.ModifyPlanResponse{Plan: req.Plan}
+			shardResource.ModifyPlan(ctx, req, resp)
+
+			if rejected := resp.Diagnostics.HasError(); rejected != test.rejected {
+				t.Errorf("expected the plan to be rejected: %t, got %v", test.rejected, resp.Diagnostics)
+			}
+		})
+	}
+}
@@ -0,0 +1,234 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/megum1n/terraform-provider-mongodb/internal/mongodb"
+)
+
+var (
+	_ resource.Resource                = &ShardDatabaseResource{}
+	_ resource.ResourceWithConfigure   = &ShardDatabaseResource{}
+	_ resource.ResourceWithImportState = &ShardDatabaseResource{}
+)
+
+func NewShardDatabaseResource() resource.Resource {
+	return &ShardDatabaseResource{}
+}
+
+type ShardDatabaseResource struct {
+	client *mongodb.Client
+}
+
+type ShardDatabaseResourceModel struct {
+	ID           types.String `tfsdk:"id"`
+	Name         types.String `tfsdk:"name"`
+	PrimaryShard types.String `tfsdk:"primary_shard"`
+}
+
+func (m *ShardDatabaseResourceModel) updateState(database *mongodb.ShardedDatabase) {
+	m.ID = types.StringValue(database.Name)
+	m.Name = types.StringValue(database.Name)
+	m.PrimaryShard = types.StringValue(database.Primary)
+}
+
+func (r *ShardDatabaseResource) Metadata(
+	_ context.Context,
+	req resource.MetadataRequest,
+	resp *resource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_shard_database"
+}
+
+func (r *ShardDatabaseResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Enables sharding on a database of a sharded cluster with `enableSharding`, " +
+			"so that its collections can be sharded with `mongodb_shard_collection`. " +
+			"Sharding can't be disabled, destroying the resource only removes it from the Terraform state.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Name of the database",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the database",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"primary_shard": schema.StringAttribute{
+				MarkdownDescription: "Shard holding the unsharded collections of the database",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *ShardDatabaseResource) Configure(
+	_ context.Context,
+	req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	p, ok := req.ProviderData.(*MongodbProvider)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *MongodbProvider, got: %T. "+
+				"Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = p.client
+}
+
+func (r *ShardDatabaseResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if !r.checkClient(&resp.Diagnostics) {
+		return
+	}
+
+	var plan ShardDatabaseResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	database, err := r.client.EnableSharding(ctx, plan.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			commandErrorSummary(err, "failed to enable sharding"),
+			err.Error(),
+		)
+
+		return
+	}
+
+	plan.updateState(database)
+
+	tflog.Trace(ctx, "Sharding enabled")
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *ShardDatabaseResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if !r.checkClient(&resp.Diagnostics) {
+		return
+	}
+
+	var state ShardDatabaseResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	database, err := r.client.GetShardedDatabase(ctx, state.Name.ValueString())
+	if err != nil {
+		if !errors.As(err, &mongodb.NotFoundError{}) {
+			resp.Diagnostics.AddError(
+				commandErrorSummary(err, "failed to get sharded database"),
+				err.Error(),
+			)
+
+			return
+		}
+
+		tflog.Debug(ctx, "sharded database not found, removing from state")
+		resp.State.RemoveResource(ctx)
+
+		return
+	}
+
+	state.updateState(database)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update is never called, every configurable attribute requires a replacement.
+func (r *ShardDatabaseResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan ShardDatabaseResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *ShardDatabaseResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state ShardDatabaseResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.AddWarning(
+		"Sharding not disabled",
+		fmt.Sprintf("Sharding can't be disabled on a database, the %s database was only removed "+
+			"from the Terraform state", state.Name.ValueString()),
+	)
+}
+
+func (r *ShardDatabaseResource) ImportState(
+	ctx context.Context,
+	req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse,
+) {
+	if !r.checkClient(&resp.Diagnostics) {
+		return
+	}
+
+	database, err := r.client.GetShardedDatabase(ctx, req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to get sharded database",
+			err.Error(),
+		)
+
+		return
+	}
+
+	var state ShardDatabaseResourceModel
+
+	state.updateState(database)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *ShardDatabaseResource) checkClient(diags *diag.Diagnostics) bool {
+	if r.client == nil {
+		diags.AddError(
+			"MongoDB client is not configured",
+			"Expected configured MongoDB client. Please report this issue to the provider developers.",
+		)
+
+		return false
+	}
+
+	return true
+}
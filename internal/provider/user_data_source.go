@@ -0,0 +1,159 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/megum1n/terraform-provider-mongodb/internal/mongodb"
+)
+
+var _ datasource.DataSource = &UserDataSource{}
+var _ datasource.DataSourceWithConfigure = &UserDataSource{}
+
+func NewUserDataSource() datasource.DataSource {
+	return &UserDataSource{}
+}
+
+type UserDataSource struct {
+	client          *mongodb.Client
+	defaultDatabase string
+}
+
+type UserDataSourceModel struct {
+	Username types.String `tfsdk:"username"`
+	Database types.String `tfsdk:"database"`
+	Roles    types.Set    `tfsdk:"roles"`
+}
+
+func (u *UserDataSourceModel) updateState(ctx context.Context, user *mongodb.User) diag.Diagnostics {
+	u.Username = types.StringValue(user.Username)
+	u.Database = types.StringValue(user.Database)
+
+	roles, diags := user.Roles.ToTerraformSet(ctx)
+	u.Roles = *roles
+
+	return diags
+}
+
+func (d *UserDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_user"
+}
+
+func (d *UserDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "MongoDB User data source",
+
+		Attributes: map[string]schema.Attribute{
+			"username": schema.StringAttribute{
+				MarkdownDescription: "The name of the user",
+				Required:            true,
+			},
+			"database": schema.StringAttribute{
+				MarkdownDescription: "Auth database name (auth source). " +
+					"The provider `default_database` is used by default",
+				Optional: true,
+				Computed: true,
+			},
+			"roles": schema.SetNestedAttribute{
+				MarkdownDescription: "The roles granted to the user",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"role": schema.StringAttribute{
+							MarkdownDescription: "Role name",
+							Computed:            true,
+						},
+						"db": schema.StringAttribute{
+							MarkdownDescription: "Target database name",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *UserDataSource) Configure(
+	_ context.Context,
+	req datasource.ConfigureRequest,
+	resp *datasource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	p, ok := req.ProviderData.(*MongodbProvider)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *MongodbProvider, got: %T. "+
+				"Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = p.client
+	d.defaultDatabase = p.defaultDatabase
+}
+
+func (d *UserDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if d.client == nil {
+		resp.Diagnostics.AddError(
+			"MongoDB client is not configured",
+			"Expected configured MongoDB client. Please report this issue to the provider developers.",
+		)
+
+		return
+	}
+
+	var config UserDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	database := config.Database.ValueString()
+	if database == "" {
+		database = d.defaultDatabase
+	}
+
+	user, err := d.client.GetUser(ctx, &mongodb.GetUserOptions{
+		Username: config.Username.ValueString(),
+		Database: database,
+	})
+	if err != nil {
+		if errors.As(err, &mongodb.NotFoundError{}) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("username"),
+				"user not found",
+				fmt.Sprintf("User %q does not exist in database %q", config.Username.ValueString(), database),
+			)
+
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			"failed to get user",
+			err.Error(),
+		)
+
+		return
+	}
+
+	resp.Diagnostics.Append(config.updateState(ctx, user)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
+}
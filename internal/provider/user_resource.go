@@ -4,48 +4,90 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
+	"slices"
 	"strings"
-
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/objectvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"go.mongodb.org/mongo-driver/v2/bson"
 
 	"github.com/megum1n/terraform-provider-mongodb/internal/mongodb"
 )
 
 const (
 	externalDatabase = "$external"
+
+	scramSHA1   = "SCRAM-SHA-1"
+	scramSHA256 = "SCRAM-SHA-256"
 )
 
 var _ resource.Resource = &UserResource{}
 var _ resource.ResourceWithConfigure = &UserResource{}
 var _ resource.ResourceWithImportState = &UserResource{}
+var _ resource.ResourceWithModifyPlan = &UserResource{}
+var _ resource.ResourceWithConfigValidators = &UserResource{}
+var _ resource.ResourceWithValidateConfig = &UserResource{}
 
 func NewUserResource() resource.Resource {
 	return &UserResource{}
 }
 
 type UserResource struct {
-	client *mongodb.Client
+	client          *mongodb.Client
+	defaultDatabase string
 }
 
 type UserResourceModel struct {
-	Username   types.String `tfsdk:"username"`
-	Password   types.String `tfsdk:"password"`
-	Database   types.String `tfsdk:"database"`
-	Roles      types.Set    `tfsdk:"roles"`
-	Mechanisms types.Set    `tfsdk:"mechanisms"`
+	Username types.String `tfsdk:"username"`
+	Password types.String `tfsdk:"password"`
+	Database types.String `tfsdk:"database"`
+	Roles    types.Set    `tfsdk:"roles"`
+	// DefaultRoleDB is the database of the roles configured without db, it's unrelated to Database
+	DefaultRoleDB types.String `tfsdk:"default_role_db"`
+	Mechanisms    types.Set    `tfsdk:"mechanisms"`
+	MaxTimeMS     types.Int64  `tfsdk:"max_time_ms"`
+	CheckRoles    types.Bool   `tfsdk:"check_roles"`
+	Enabled       types.Bool   `tfsdk:"enabled"`
+
+	AuthenticationRestrictions types.List   `tfsdk:"authentication_restrictions"`
+	CustomData                 types.String `tfsdk:"custom_data"`
+
+	PasswordLastChanged types.String `tfsdk:"password_last_changed"`
+	RotateTrigger       types.String `tfsdk:"rotate_trigger"`
+	PasswordDigestor    types.String `tfsdk:"password_digestor"`
+
+	// PasswordWO is write-only, so it is always null in the plan and the state
+	PasswordWO        types.String `tfsdk:"password_wo"`
+	PasswordWOVersion types.Int64  `tfsdk:"password_wo_version"`
 }
 
 func newUserResourceModel() UserResourceModel {
 	return UserResourceModel{
 		Roles:      types.SetNull(types.ObjectType{AttrTypes: mongodb.ShortRoleAttributeTypes}),
 		Mechanisms: types.SetNull(types.StringType),
+
+		AuthenticationRestrictions: types.ListNull(
+			types.ObjectType{AttrTypes: mongodb.AuthenticationRestrictionAttributeTypes},
+		),
 	}
 }
 
@@ -66,27 +108,145 @@ func (u *UserResourceModel) GetMechanisms(ctx context.Context, ptr *[]string) di
 	return diags
 }
 
-func (u *UserResourceModel) updateState(ctx context.Context, user *mongodb.User) diag.Diagnostics {
+// updateState keeps the planned mechanisms when keepMechanisms is set, as DocumentDB does not return them.
+func (u *UserResourceModel) updateState(ctx context.Context, user *mongodb.User, keepMechanisms bool) diag.Diagnostics {
 	diags := diag.Diagnostics{}
 
 	u.Username = types.StringValue(user.Username)
 	u.Database = types.StringValue(user.Database)
+	u.Enabled = types.BoolValue(!user.Disabled)
+
+	// Imported users and the states written before the attribute existed use the default
+	if u.DefaultRoleDB.IsNull() {
+		u.DefaultRoleDB = types.StringValue(defaultDatabase)
+	}
 
 	roles, d := user.Roles.ToTerraformSet(ctx)
 	diags.Append(d...)
 
 	u.Roles = *roles
 
-	// DocumentDB does not return mechanisms.
-	// If that's the case - keep the value same as in plan.
-	if len(user.Mechanisms) > 0 {
+	// Keep the planned value when the server omits mechanisms
+	if !keepMechanisms && len(user.Mechanisms) > 0 {
 		u.Mechanisms, d = types.SetValueFrom(ctx, types.StringType, user.Mechanisms)
 		diags.Append(d...)
 	}
 
+	// Keep the restrictions null when none are configured, and the configured ones while the user is disabled
+	if !user.Disabled && (len(user.AuthenticationRestrictions) > 0 || !u.AuthenticationRestrictions.IsNull()) {
+		u.AuthenticationRestrictions, d = user.AuthenticationRestrictions.ToTerraformList(ctx)
+		diags.Append(d...)
+	}
+
+	// Keep the configured JSON when it describes the same document
+	if len(user.CustomData) == 0 {
+		u.CustomData = types.StringNull()
+	} else {
+		customData, err := mongodb.DocumentJSON(user.CustomData)
+		if err != nil {
+			diags.AddError("Failed to parse user custom data", err.Error())
+
+			return diags
+		}
+
+		if !isSameJSON(u.CustomData, []byte(customData)) {
+			u.CustomData = types.StringValue(customData)
+		}
+	}
+
 	return diags
 }
 
+// GetCustomData returns nil when the custom data is not configured.
+func (u *UserResourceModel) GetCustomData() (bson.D, diag.Diagnostics) {
+	diags := diag.Diagnostics{}
+
+	if u.CustomData.IsNull() || u.CustomData.IsUnknown() {
+		return nil, diags
+	}
+
+	customData, err := mongodb.ParseDocumentJSON(u.CustomData.ValueString())
+	if err != nil {
+		diags.AddAttributeError(path.Root("custom_data"), "Failed to parse user custom data", err.Error())
+	}
+
+	return customData, diags
+}
+
+// authenticationRestrictions returns nil when the restrictions are not configured.
+func authenticationRestrictions(
+	ctx context.Context,
+	list types.List,
+) (mongodb.AuthenticationRestrictions, diag.Diagnostics) {
+	if list.IsNull() || list.IsUnknown() {
+		return nil, nil
+	}
+
+	restrictions := mongodb.AuthenticationRestrictions{}
+	diags := list.ElementsAs(ctx, &restrictions, false)
+
+	return restrictions, diags
+}
+
+// removedAuthenticationRestrictions reads the restrictions from the state on update.
+// Removing the attribute clears the restrictions, so an empty slice is returned for the ones not planned anymore.
+func removedAuthenticationRestrictions(
+	ctx context.Context,
+	state tfsdk.State,
+	planned mongodb.AuthenticationRestrictions,
+) (mongodb.AuthenticationRestrictions, diag.Diagnostics) {
+	var current types.List
+
+	diags := state.GetAttribute(ctx, path.Root("authentication_restrictions"), &current)
+	if planned == nil && !current.IsNull() {
+		return mongodb.AuthenticationRestrictions{}, diags
+	}
+
+	return planned, diags
+}
+
+// authenticationRestrictionsAttribute returns the schema of the addresses users can authenticate from and to.
+func authenticationRestrictionsAttribute(description string) schema.ListNestedAttribute {
+	return schema.ListNestedAttribute{
+		MarkdownDescription: description,
+		Optional:            true,
+		NestedObject: schema.NestedAttributeObject{
+			Validators: []validator.Object{
+				objectvalidator.AtLeastOneOf(
+					path.MatchRelative().AtName("client_source"),
+					path.MatchRelative().AtName("server_address"),
+				),
+			},
+			Attributes: map[string]schema.Attribute{
+				"client_source": schema.ListAttribute{
+					MarkdownDescription: "IP addresses or CIDR ranges the client can connect from",
+					ElementType:         types.StringType,
+					Optional:            true,
+					Validators: []validator.List{
+						listvalidator.SizeAtLeast(1),
+					},
+				},
+				"server_address": schema.ListAttribute{
+					MarkdownDescription: "IP addresses or CIDR ranges of the server the client can connect to",
+					ElementType:         types.StringType,
+					Optional:            true,
+					Validators: []validator.List{
+						listvalidator.SizeAtLeast(1),
+					},
+				},
+			},
+		},
+	}
+}
+
+// passwordChanged records the time of the password change made by the provider.
+func (u *UserResourceModel) passwordChanged() {
+	u.PasswordLastChanged = types.StringNull()
+	if u.Password.ValueString() != "" || !u.PasswordWOVersion.IsNull() {
+		u.PasswordLastChanged = types.StringValue(time.Now().UTC().Format(time.RFC3339))
+	}
+}
+
 func (r *UserResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_user"
 }
@@ -97,25 +257,29 @@ func (r *UserResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 
 		Attributes: map[string]schema.Attribute{
 			"username": schema.StringAttribute{
-				MarkdownDescription: "The name of the new user",
-				Required:            true,
+				MarkdownDescription: fmt.Sprintf("The name of the new user. For a user of the %q database, "+
+					"the full subject DN of its X.509 certificate, e.g. `CN=app,OU=apps,O=example`, "+
+					"or its LDAP user name", externalDatabase),
+				Required: true,
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
 			"password": schema.StringAttribute{
-				MarkdownDescription: fmt.Sprintf("The user's password. "+
-					"Must be empty for %q database", externalDatabase),
+				MarkdownDescription: fmt.Sprintf("The user's password. Must not be set for the %q database, "+
+					"whose users authenticate with X.509 or LDAP. MongoDB never returns the password, "+
+					"so changes made outside of Terraform are not detected, change rotate_trigger to set it again",
+					externalDatabase),
 				Optional:  true,
 				Sensitive: true,
 			},
 			"database": schema.StringAttribute{
-				MarkdownDescription: fmt.Sprintf("Auth database name (auth source). "+
-					"%q is used by default", defaultDatabase),
+				MarkdownDescription: "Auth database name (auth source). " +
+					"The provider `default_database` is used by default",
 				Optional: true,
 				Computed: true,
-				Default:  stringdefault.StaticString(defaultDatabase),
 				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
@@ -129,26 +293,295 @@ func (r *UserResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 							Required:            true,
 						},
 						"db": schema.StringAttribute{
-							MarkdownDescription: fmt.Sprintf("Target database name. "+
-								"%q is used by default", defaultDatabase),
-							Optional: true,
-							Computed: true,
-							Default:  stringdefault.StaticString(defaultDatabase),
+							MarkdownDescription: "Target database name. `default_role_db` is used by default",
+							Optional:            true,
+							Computed:            true,
 						},
 					},
 				},
 			},
+			"default_role_db": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("Target database of the roles without `db`. %q is used by default. "+
+					"It's independent from `database`, the auth database of the user, so a user authenticating "+
+					"against %q can hold roles in the application databases", defaultDatabase, defaultDatabase),
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(defaultDatabase),
+			},
 			"mechanisms": schema.SetAttribute{
-				MarkdownDescription: "Specify the specific SCRAM mechanism " +
-					"or mechanisms for creating SCRAM user credentials.",
+				MarkdownDescription: fmt.Sprintf("Specify the specific SCRAM mechanism "+
+					"or mechanisms for creating SCRAM user credentials: %q or %q. "+
+					"The mechanisms must be enabled on the server. Defaults to the SCRAM mechanisms enabled "+
+					"on the server when the user is created, the current mechanisms are kept "+
+					"when the password is set again, adding a mechanism requires setting the password again",
+					scramSHA1, scramSHA256),
 				ElementType: types.StringType,
 				Optional:    true,
 				Computed:    true,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(stringvalidator.OneOf(scramSHA1, scramSHA256)),
+				},
+			},
+			"max_time_ms": schema.Int64Attribute{
+				MarkdownDescription: "Server-side time limit in milliseconds " +
+					"for the commands creating and updating the user",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"check_roles": schema.BoolAttribute{
+				MarkdownDescription: "Verify on refresh that the granted roles still exist. " +
+					"A dropped role is revoked from its users, so the missing grants are reported " +
+					"as a warning to re-create the role and re-grant it",
+				Optional: true,
+			},
+			"enabled": schema.BoolAttribute{
+				MarkdownDescription: "Whether the user can authenticate. MongoDB has no account lock, so a disabled " +
+					"user gets an authentication restriction only allowing the broadcast address " +
+					"`255.255.255.255/32` as client source, which no client has. The roles are kept " +
+					"and the configured `authentication_restrictions` are restored when the user is enabled again. " +
+					"The sessions already authenticated are not closed",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(true),
+			},
+			"authentication_restrictions": authenticationRestrictionsAttribute(
+				"Addresses the user can authenticate from and to. " +
+					"Removing the attribute clears the restrictions",
+			),
+			"custom_data": schema.StringAttribute{
+				MarkdownDescription: "JSON encoded document with any information about the user, " +
+					"e.g. {\"owner\": \"team-a\"}. Removing the attribute clears the custom data",
+				Optional: true,
+			},
+			"password_wo": schema.StringAttribute{
+				MarkdownDescription: "The user's password, never stored in the state. " +
+					"Requires Terraform 1.11 or later. Conflicts with password. " +
+					"The password is only sent when the user is created or password_wo_version changes",
+				Optional:  true,
+				Sensitive: true,
+				WriteOnly: true,
+			},
+			"password_wo_version": schema.Int64Attribute{
+				MarkdownDescription: "Version of password_wo. Change it to set the write-only password",
+				Optional:            true,
+			},
+			"password_digestor": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("Whether the `%s` or the `%s` digests the password. "+
+					"Client side digestion only supports the SCRAM-SHA-1 mechanism. "+
+					"The server digests the password by default",
+					mongodb.PasswordDigestorServer, mongodb.PasswordDigestorClient),
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(mongodb.PasswordDigestorServer, mongodb.PasswordDigestorClient),
+				},
+			},
+			"rotate_trigger": schema.StringAttribute{
+				MarkdownDescription: "Any value, e.g. a date. Changing it sets the password again " +
+					"even when the password itself is unchanged, for scheduled credential rotation. " +
+					"Use password_wo_version with password_wo instead",
+				Optional: true,
+			},
+			"password_last_changed": schema.StringAttribute{
+				MarkdownDescription: "RFC 3339 timestamp of the last password change made by the provider. " +
+					"MongoDB does not track it, so the value is kept in the state only and is empty for imported users",
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 		},
 	}
 }
 
+// userRoleModel is a role of the plan, whose db may not be known yet.
+type userRoleModel struct {
+	Role types.String `tfsdk:"role"`
+	DB   types.String `tfsdk:"db"`
+}
+
+// planRoleDatabases sets the database of the planned roles without db to default_role_db.
+func planRoleDatabases(ctx context.Context, plan *tfsdk.Plan) diag.Diagnostics {
+	var (
+		roles         types.Set
+		defaultRoleDB types.String
+	)
+
+	diags := plan.GetAttribute(ctx, path.Root("roles"), &roles)
+	diags.Append(plan.GetAttribute(ctx, path.Root("default_role_db"), &defaultRoleDB)...)
+
+	if diags.HasError() || roles.IsNull() || roles.IsUnknown() || defaultRoleDB.IsUnknown() {
+		return diags
+	}
+
+	for _, element := range roles.Elements() {
+		if element.IsUnknown() {
+			return diags
+		}
+	}
+
+	var models []userRoleModel
+
+	diags.Append(roles.ElementsAs(ctx, &models, false)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	for i := range models {
+		if models[i].DB.IsNull() || models[i].DB.IsUnknown() {
+			models[i].DB = defaultRoleDB
+		}
+	}
+
+	planned, d := types.SetValueFrom(ctx, roles.ElementType(ctx), models)
+
+	diags.Append(d...)
+	if diags.HasError() {
+		return diags
+	}
+
+	diags.Append(plan.SetAttribute(ctx, path.Root("roles"), planned)...)
+
+	return diags
+}
+
+// ModifyPlan sets the database of the user and of the roles without db, and marks password_last_changed as unknown
+// when the password is about to change or be set again.
+func (r *UserResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	resp.Diagnostics.Append(planDefaultDatabase(ctx, req.Config, &resp.Plan, r.defaultDatabase)...)
+	resp.Diagnostics.Append(planRoleDatabases(ctx, &resp.Plan)...)
+
+	if req.State.Raw.IsNull() || resp.Diagnostics.HasError() {
+		return
+	}
+
+	var planVersion, stateVersion types.Int64
+
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("password_wo_version"), &planVersion)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("password_wo_version"), &stateVersion)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !planVersion.Equal(stateVersion) {
+		resp.Diagnostics.Append(
+			resp.Plan.SetAttribute(ctx, path.Root("password_last_changed"), types.StringUnknown())...,
+		)
+
+		return
+	}
+
+	for _, attribute := range []string{"password", "rotate_trigger"} {
+		var plan, state types.String
+
+		resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root(attribute), &plan)...)
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root(attribute), &state)...)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		if !plan.Equal(state) {
+			resp.Diagnostics.Append(
+				resp.Plan.SetAttribute(ctx, path.Root("password_last_changed"), types.StringUnknown())...,
+			)
+
+			return
+		}
+	}
+}
+
+func (r *UserResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.Conflicting(
+			path.MatchRoot("password"),
+			path.MatchRoot("password_wo"),
+		),
+		resourcevalidator.RequiredTogether(
+			path.MatchRoot("password_wo"),
+			path.MatchRoot("password_wo_version"),
+		),
+	}
+}
+
+func (r *UserResource) ValidateConfig(
+	ctx context.Context,
+	req resource.ValidateConfigRequest,
+	resp *resource.ValidateConfigResponse,
+) {
+	var config = newUserResourceModel()
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.Database.ValueString() == externalDatabase {
+		validateExternalUser(&config, &resp.Diagnostics)
+	}
+
+	if config.PasswordDigestor.ValueString() != mongodb.PasswordDigestorClient || config.Mechanisms.IsUnknown() {
+		return
+	}
+
+	var mechanisms []string
+
+	resp.Diagnostics.Append(config.GetMechanisms(ctx, &mechanisms)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !slices.Equal(mechanisms, []string{scramSHA1}) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("mechanisms"),
+			"Invalid password digestion",
+			fmt.Sprintf("Client side password digestion requires mechanisms = [%q]", scramSHA1),
+		)
+	}
+}
+
+// validateExternalUser rejects the password settings of a $external user, which is authenticated
+// by its X.509 certificate subject or by LDAP and has no credentials stored by MongoDB.
+func validateExternalUser(config *UserResourceModel, diags *diag.Diagnostics) {
+	attributes := map[string]attr.Value{
+		"password":            config.Password,
+		"password_wo":         config.PasswordWO,
+		"password_wo_version": config.PasswordWOVersion,
+		"password_digestor":   config.PasswordDigestor,
+		"rotate_trigger":      config.RotateTrigger,
+		"mechanisms":          config.Mechanisms,
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(attributes)) {
+		if attributes[name].IsNull() {
+			continue
+		}
+
+		diags.AddAttributeError(
+			path.Root(name),
+			"Invalid $external user configuration",
+			fmt.Sprintf("%s can't be set for a user of the %q database, which has no password. "+
+				"The username is the certificate subject or the LDAP user name", name, externalDatabase),
+		)
+	}
+}
+
+// writeOnlyPassword reads password_wo from the configuration, as write-only values are not in the plan.
+func writeOnlyPassword(ctx context.Context, config tfsdk.Config) (string, diag.Diagnostics) {
+	var password types.String
+
+	diags := config.GetAttribute(ctx, path.Root("password_wo"), &password)
+
+	return password.ValueString(), diags
+}
+
 func (r *UserResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
@@ -166,6 +599,7 @@ func (r *UserResource) Configure(_ context.Context, req resource.ConfigureReques
 	}
 
 	r.client = p.client
+	r.defaultDatabase = p.defaultDatabase
 }
 
 func (r *UserResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -198,27 +632,60 @@ func (r *UserResource) Create(ctx context.Context, req resource.CreateRequest, r
 		}
 	}
 
+	restrictions, d := authenticationRestrictions(ctx, plan.AuthenticationRestrictions)
+
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	customData, d := plan.GetCustomData()
+
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	password := plan.Password.ValueString()
+
+	if !plan.PasswordWOVersion.IsNull() {
+		password, d = writeOnlyPassword(ctx, req.Config)
+
+		resp.Diagnostics.Append(d...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
 	user, err := r.client.UpsertUser(ctx, &mongodb.User{
 		Username:   plan.Username.ValueString(),
-		Password:   plan.Password.ValueString(),
+		Password:   password,
 		Database:   plan.Database.ValueString(),
 		Roles:      roles,
 		Mechanisms: mechanisms,
+		MaxTimeMS:  plan.MaxTimeMS.ValueInt64(),
+
+		PasswordDigestor:           plan.PasswordDigestor.ValueString(),
+		AuthenticationRestrictions: restrictions,
+		CustomData:                 customData,
+		Disabled:                   !plan.Enabled.ValueBool(),
 	})
 	if err != nil {
 		resp.Diagnostics.AddError(
-			"failed to upsert user",
+			commandErrorSummary(err, "failed to upsert user"),
 			err.Error(),
 		)
 
 		return
 	}
 
-	resp.Diagnostics.Append(plan.updateState(ctx, user)...)
+	resp.Diagnostics.Append(plan.updateState(ctx, user, r.client.IsDocumentDB())...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	plan.passwordChanged()
+
 	tflog.Trace(ctx, "user created")
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
@@ -255,7 +722,14 @@ func (r *UserResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
-	resp.Diagnostics.Append(plan.updateState(ctx, user)...)
+	if plan.CheckRoles.ValueBool() {
+		resp.Diagnostics.Append(r.checkRoles(ctx, &plan, user)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(plan.updateState(ctx, user, r.client.IsDocumentDB())...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -293,27 +767,90 @@ func (r *UserResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		}
 	}
 
+	restrictions, d := authenticationRestrictions(ctx, plan.AuthenticationRestrictions)
+
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	customData, d := plan.GetCustomData()
+
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	password := plan.Password.ValueString()
+
+	// The write-only password is only sent when its version changes
+	var stateVersion types.Int64
+
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("password_wo_version"), &stateVersion)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !plan.PasswordWOVersion.IsNull() && !plan.PasswordWOVersion.Equal(stateVersion) {
+		password, d = writeOnlyPassword(ctx, req.Config)
+
+		resp.Diagnostics.Append(d...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	restrictions, d = removedAuthenticationRestrictions(ctx, req.State, restrictions)
+
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Removing the attribute clears the custom data
+	var stateCustomData types.String
+
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("custom_data"), &stateCustomData)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if customData == nil && !stateCustomData.IsNull() {
+		customData = bson.D{}
+	}
+
+	// The password attribute is sent on every update, so a changed rotate_trigger sets it again
 	user, err := r.client.UpsertUser(ctx, &mongodb.User{
 		Username:   plan.Username.ValueString(),
-		Password:   plan.Password.ValueString(),
+		Password:   password,
 		Database:   plan.Database.ValueString(),
 		Roles:      roles,
 		Mechanisms: mechanisms,
+		MaxTimeMS:  plan.MaxTimeMS.ValueInt64(),
+
+		PasswordDigestor:           plan.PasswordDigestor.ValueString(),
+		AuthenticationRestrictions: restrictions,
+		CustomData:                 customData,
+		Disabled:                   !plan.Enabled.ValueBool(),
 	})
 	if err != nil {
 		resp.Diagnostics.AddError(
-			"failed to upsert user",
+			commandErrorSummary(err, "failed to upsert user"),
 			err.Error(),
 		)
 
 		return
 	}
 
-	resp.Diagnostics.Append(plan.updateState(ctx, user)...)
+	resp.Diagnostics.Append(plan.updateState(ctx, user, r.client.IsDocumentDB())...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	if plan.PasswordLastChanged.IsUnknown() {
+		plan.passwordChanged()
+	}
+
 	tflog.Trace(ctx, "user updated")
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
@@ -336,7 +873,7 @@ func (r *UserResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 	})
 	if err != nil {
 		resp.Diagnostics.AddError(
-			"failed to delete user",
+			commandErrorSummary(err, "failed to delete user"),
 			err.Error(),
 		)
 	}
@@ -354,18 +891,8 @@ func (r *UserResource) ImportState(
 		return
 	}
 
-	idParts := strings.Split(req.ID, ".")
-
-	var username, database string
-
-	switch {
-	case len(idParts) == 2:
-		database = idParts[0]
-		username = idParts[1]
-	case len(idParts) == 1:
-		username = idParts[0]
-		database = defaultDatabase
-	default:
+	database, username, ok := parseUserImportID(req.ID, r.defaultDatabase)
+	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Import Identifier",
 			fmt.Sprintf("Expected import identifier with format: '[<db>.]<username>'. Got: %q", req.ID),
@@ -389,7 +916,7 @@ func (r *UserResource) ImportState(
 		return
 	}
 
-	resp.Diagnostics.Append(plan.updateState(ctx, user)...)
+	resp.Diagnostics.Append(plan.updateState(ctx, user, r.client.IsDocumentDB())...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -397,6 +924,69 @@ func (r *UserResource) ImportState(
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
 
+// parseUserImportID splits the [<db>.]<username> import identifier. Database names can't contain dots,
+// so the username is everything after the first one, e.g. the distinguished name of a $external user.
+func parseUserImportID(id, defaultDatabase string) (database, username string, ok bool) {
+	database, username, found := strings.Cut(id, ".")
+	if !found {
+		database, username = defaultDatabase, id
+	}
+
+	return database, username, database != "" && username != ""
+}
+
+// checkRoles reports the roles which are granted in the state, but do not exist anymore.
+func (r *UserResource) checkRoles(
+	ctx context.Context,
+	state *UserResourceModel,
+	user *mongodb.User,
+) diag.Diagnostics {
+	diags := diag.Diagnostics{}
+
+	var stateRoles []mongodb.ShortRole
+
+	if !state.Roles.IsNull() && !state.Roles.IsUnknown() {
+		diags.Append(state.Roles.ElementsAs(ctx, &stateRoles, false)...)
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	roles := append(mongodb.ShortRoles{}, user.Roles...)
+
+	for _, role := range stateRoles {
+		if !slices.Contains(roles, role) {
+			roles = append(roles, role)
+		}
+	}
+
+	for _, role := range roles {
+		_, err := r.client.GetRole(ctx, &mongodb.GetRoleOptions{
+			Name:     role.Role,
+			Database: role.DB,
+		})
+		if err == nil {
+			continue
+		}
+
+		if !errors.As(err, &mongodb.NotFoundError{}) {
+			diags.AddError("failed to get role", err.Error())
+
+			return diags
+		}
+
+		diags.AddAttributeWarning(
+			path.Root("roles"),
+			"Granted role does not exist",
+			fmt.Sprintf("Role %q in database %q granted to user %q does not exist. "+
+				"It was probably dropped or renamed: re-create the role and apply to grant it again.",
+				role.Role, role.DB, user.Username),
+		)
+	}
+
+	return diags
+}
+
 func (r *UserResource) checkClient(diag diag.Diagnostics) bool {
 	if r.client == nil {
 		diag.AddError(
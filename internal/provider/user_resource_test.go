@@ -0,0 +1,294 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/megum1n/terraform-provider-mongodb/internal/mongodb"
+)
+
+func TestUserMechanismsUpdateState(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	mechanisms := func(values ...string) types.Set {
+		elements := make([]attr.Value, 0, len(values))
+		for _, value := range values {
+			elements = append(elements, types.StringValue(value))
+		}
+
+		return types.SetValueMust(types.StringType, elements)
+	}
+
+	tests := map[string]struct {
+		planned        types.Set
+		server         []string
+		keepMechanisms bool
+		expected       types.Set
+	}{
+		"mongodb reads the mechanisms": {
+			planned:  mechanisms("SCRAM-SHA-1", "SCRAM-SHA-256"),
+			server:   []string{"SCRAM-SHA-256"},
+			expected: mechanisms("SCRAM-SHA-256"),
+		},
+		"mongodb reads the mechanisms of an imported user": {
+			planned:  types.SetNull(types.StringType),
+			server:   []string{"SCRAM-SHA-1", "SCRAM-SHA-256"},
+			expected: mechanisms("SCRAM-SHA-1", "SCRAM-SHA-256"),
+		},
+		"mongodb keeps the planned mechanisms when the server omits them": {
+			planned:  mechanisms("SCRAM-SHA-256"),
+			expected: mechanisms("SCRAM-SHA-256"),
+		},
+		"documentdb keeps the planned mechanisms": {
+			planned:        mechanisms("SCRAM-SHA-1"),
+			server:         []string{"SCRAM-SHA-1", "SCRAM-SHA-256"},
+			keepMechanisms: true,
+			expected:       mechanisms("SCRAM-SHA-1"),
+		},
+		"documentdb keeps the mechanisms null": {
+			planned:        types.SetNull(types.StringType),
+			server:         []string{"SCRAM-SHA-1"},
+			keepMechanisms: true,
+			expected:       types.SetNull(types.StringType),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			model := newUserResourceModel()
+			model.Mechanisms = test.planned
+
+			diags := model.updateState(ctx, &mongodb.User{
+				Username:   "user",
+				Database:   "admin",
+				Mechanisms: test.server,
+			}, test.keepMechanisms)
+			if diags.HasError() {
+				t.Fatalf("updateState failed: %v", diags)
+			}
+
+			if !model.Mechanisms.Equal(test.expected) {
+				t.Errorf("expected mechanisms %s, got %s", test.expected, model.Mechanisms)
+			}
+		})
+	}
+}
+
+func TestValidateExternalUser(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		config    UserResourceModel
+		attribute string
+	}{
+		"certificate user": {
+			config: UserResourceModel{Username: types.StringValue("CN=app,OU=eng.example.com,O=Example")},
+		},
+		"password": {
+			config:    UserResourceModel{Password: types.StringValue("secret")},
+			attribute: "password",
+		},
+		"write only password": {
+			config:    UserResourceModel{PasswordWOVersion: types.Int64Value(1)},
+			attribute: "password_wo_version",
+		},
+		"mechanisms": {
+			config: UserResourceModel{
+				Mechanisms: types.SetValueMust(types.StringType, []attr.Value{types.StringValue(scramSHA256)}),
+			},
+			attribute: "mechanisms",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var diags diag.Diagnostics
+
+			validateExternalUser(&test.config, &diags)
+
+			if test.attribute == "" {
+				if diags.HasError() {
+					t.Errorf("unexpected error: %v", diags)
+				}
+
+				return
+			}
+
+			if diags.ErrorsCount() != 1 {
+				t.Fatalf("expected one error on %s, got %v", test.attribute, diags)
+			}
+
+			withPath, ok := diags.Errors()[0].(diag.DiagnosticWithPath)
+			if !ok || !withPath.Path().Equal(path.Root(test.attribute)) {
+				t.Errorf("expected the error on %s, got %v", test.attribute, diags)
+			}
+		})
+	}
+}
+
+func TestParseUserImportIDExternal(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		id                 string
+		database, username string
+	}{
+		"X.509 DN": {
+			id:       "$external.CN=app,OU=eng.example.com,O=Example Inc.,C=US",
+			database: "$external",
+			username: "CN=app,OU=eng.example.com,O=Example Inc.,C=US",
+		},
+		"LDAP user": {
+			id:       "$external.jane.doe@example.com",
+			database: "$external",
+			username: "jane.doe@example.com",
+		},
+		"default database": {
+			id:       "app",
+			database: "admin",
+			username: "app",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			database, username, ok := parseUserImportID(test.id, "admin")
+			if !ok || database != test.database || username != test.username {
+				t.Errorf("expected %s and %s, got %s and %s (%t)", test.database, test.username, database, username, ok)
+			}
+		})
+	}
+}
+
+func TestPlanRoleDatabasesAdminUser(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	schemaResp := &resource.SchemaResponse{}
+	(&UserResource{}).Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	roleType := types.ObjectType{AttrTypes: mongodb.ShortRoleAttributeTypes}
+	role := func(name string, db types.String) attr.Value {
+		return types.ObjectValueMust(mongodb.ShortRoleAttributeTypes, map[string]attr.Value{
+			"role": types.StringValue(name),
+			"db":   db,
+		})
+	}
+
+	tests := map[string]struct {
+		defaultRoleDB string
+		roles         []attr.Value
+		expected      []attr.Value
+	}{
+		"application database": {
+			defaultRoleDB: "admin",
+			roles:         []attr.Value{role("readWrite", types.StringValue("app"))},
+			expected:      []attr.Value{role("readWrite", types.StringValue("app"))},
+		},
+		"role without db": {
+			defaultRoleDB: "admin",
+			roles: []attr.Value{
+				role("readWrite", types.StringValue("app")),
+				role("clusterMonitor", types.StringNull()),
+			},
+			expected: []attr.Value{
+				role("readWrite", types.StringValue("app")),
+				role("clusterMonitor", types.StringValue("admin")),
+			},
+		},
+		"default_role_db": {
+			defaultRoleDB: "app",
+			roles: []attr.Value{
+				role("readWrite", types.StringNull()),
+				role("clusterMonitor", types.StringValue("admin")),
+			},
+			expected: []attr.Value{
+				role("readWrite", types.StringValue("app")),
+				role("clusterMonitor", types.StringValue("admin")),
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			model := newUserResourceModel()
+			model.Username = types.StringValue("app")
+			model.Database = types.StringValue("admin")
+			model.DefaultRoleDB = types.StringValue(test.defaultRoleDB)
+			model.Roles = types.SetValueMust(roleType, test.roles)
+
+			plan := tfsdk.Plan{Schema: schemaResp.Schema}
+
+			diags := plan.Set(ctx, &model)
+			diags.Append(planRoleDatabases(ctx, &plan)...)
+
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+
+			var roles types.Set
+
+			diags = plan.GetAttribute(ctx, path.Root("roles"), &roles)
+			if diags.HasError() {
+				t.Fatalf("failed to read the roles: %v", diags)
+			}
+
+			if expected := types.SetValueMust(roleType, test.expected); !roles.Equal(expected) {
+				t.Errorf("expected roles %s, got %s", expected, roles)
+			}
+		})
+	}
+}
+
+func TestUserUpdateStateAdminUserRoles(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	model := newUserResourceModel()
+
+	diags := model.updateState(ctx, &mongodb.User{
+		Username: "app",
+		Database: "admin",
+		Roles: mongodb.ShortRoles{
+			{Role: "readWrite", DB: "app"},
+			{Role: "read", DB: "reporting"},
+			{Role: "readWrite", DB: "app"},
+		},
+	}, false)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	expected, diags := types.SetValueFrom(ctx, model.Roles.ElementType(ctx),
+		[]mongodb.ShortRole{{Role: "read", DB: "reporting"}, {Role: "readWrite", DB: "app"}})
+	if diags.HasError() {
+		t.Fatalf("failed to build the roles: %v", diags)
+	}
+
+	if !model.Roles.Equal(expected) {
+		t.Errorf("expected roles %s, got %s", expected, model.Roles)
+	}
+
+	if model.Database.ValueString() != "admin" || model.DefaultRoleDB.ValueString() != defaultDatabase {
+		t.Errorf("expected the admin auth database and the default role database, got %s and %s",
+			model.Database, model.DefaultRoleDB)
+	}
+}
@@ -0,0 +1,407 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/megum1n/terraform-provider-mongodb/internal/mongodb"
+)
+
+var _ resource.Resource = &UserRolesResource{}
+var _ resource.ResourceWithConfigure = &UserRolesResource{}
+var _ resource.ResourceWithImportState = &UserRolesResource{}
+var _ resource.ResourceWithModifyPlan = &UserRolesResource{}
+
+func NewUserRolesResource() resource.Resource {
+	return &UserRolesResource{}
+}
+
+type UserRolesResource struct {
+	client          *mongodb.Client
+	defaultDatabase string
+}
+
+type UserRolesResourceModel struct {
+	Username types.String `tfsdk:"username"`
+	Database types.String `tfsdk:"database"`
+	Roles    types.Set    `tfsdk:"roles"`
+}
+
+func (m *UserRolesResourceModel) options(ctx context.Context) (*mongodb.UserRolesOptions, diag.Diagnostics) {
+	options := &mongodb.UserRolesOptions{
+		Username: m.Username.ValueString(),
+		Database: m.Database.ValueString(),
+		Roles:    mongodb.ShortRoles{},
+	}
+
+	diags := m.Roles.ElementsAs(ctx, &options.Roles, false)
+
+	return options, diags
+}
+
+// updateState keeps the managed roles which are still granted, the other roles of the user are ignored.
+func (m *UserRolesResourceModel) updateState(ctx context.Context, user *mongodb.User) diag.Diagnostics {
+	var managed mongodb.ShortRoles
+
+	diags := m.Roles.ElementsAs(ctx, &managed, false)
+	if diags.HasError() {
+		return diags
+	}
+
+	roles := mongodb.ShortRoles{}
+
+	for _, role := range managed {
+		if slices.Contains(user.Roles, role) {
+			roles = append(roles, role)
+		}
+	}
+
+	set, d := roles.ToTerraformSet(ctx)
+	diags.Append(d...)
+
+	if diags.HasError() {
+		return diags
+	}
+
+	m.Roles = *set
+
+	return diags
+}
+
+func (r *UserRolesResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_user_roles"
+}
+
+func (r *UserRolesResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Grants roles to an existing user without owning all of its roles, " +
+			"so that several modules can grant roles to the same user. Only the roles listed here are " +
+			"granted, revoked and checked for drift. When the user is managed by `mongodb_user`, " +
+			"add `roles` to its `ignore_changes` to keep it from revoking these roles.",
+
+		Attributes: map[string]schema.Attribute{
+			"username": schema.StringAttribute{
+				MarkdownDescription: "Name of the user",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"database": schema.StringAttribute{
+				MarkdownDescription: "Auth database of the user. " +
+					"The provider `default_database` is used by default",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"roles": schema.SetNestedAttribute{
+				MarkdownDescription: "The roles granted to the user by this resource",
+				Required:            true,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"role": schema.StringAttribute{
+							MarkdownDescription: "Role name",
+							Required:            true,
+						},
+						"db": schema.StringAttribute{
+							MarkdownDescription: fmt.Sprintf("Target database name. "+
+								"%q is used by default", defaultDatabase),
+							Optional: true,
+							Computed: true,
+							Default:  stringdefault.StaticString(defaultDatabase),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *UserRolesResource) Configure(
+	_ context.Context,
+	req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	p, ok := req.ProviderData.(*MongodbProvider)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *MongodbProvider, got: %T. "+
+				"Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = p.client
+	r.defaultDatabase = p.defaultDatabase
+}
+
+// ModifyPlan sets default_database as the database of a new resource configured without database.
+func (r *UserRolesResource) ModifyPlan(
+	ctx context.Context,
+	req resource.ModifyPlanRequest,
+	resp *resource.ModifyPlanResponse,
+) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	resp.Diagnostics.Append(planDefaultDatabase(ctx, req.Config, &resp.Plan, r.defaultDatabase)...)
+}
+
+func (r *UserRolesResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if !r.checkClient(&resp.Diagnostics) {
+		return
+	}
+
+	var plan UserRolesResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	options, d := plan.options(ctx)
+
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.GrantRolesToUser(ctx, options)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			commandErrorSummary(err, "failed to grant roles to user"),
+			err.Error(),
+		)
+
+		return
+	}
+
+	tflog.Trace(ctx, "User roles granted")
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *UserRolesResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if !r.checkClient(&resp.Diagnostics) {
+		return
+	}
+
+	var state UserRolesResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	user, err := r.client.GetUser(ctx, &mongodb.GetUserOptions{
+		Username: state.Username.ValueString(),
+		Database: state.Database.ValueString(),
+	})
+	if err != nil {
+		if !errors.As(err, &mongodb.NotFoundError{}) {
+			resp.Diagnostics.AddError(
+				"failed to get user",
+				err.Error(),
+			)
+
+			return
+		}
+
+		tflog.Debug(ctx, "user not found, removing user roles from state")
+		resp.State.RemoveResource(ctx)
+
+		return
+	}
+
+	resp.Diagnostics.Append(state.updateState(ctx, user)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *UserRolesResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if !r.checkClient(&resp.Diagnostics) {
+		return
+	}
+
+	var plan, state UserRolesResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	planned, d := plan.options(ctx)
+	resp.Diagnostics.Append(d...)
+
+	current, d := state.options(ctx)
+	resp.Diagnostics.Append(d...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	revoked := &mongodb.UserRolesOptions{
+		Username: current.Username,
+		Database: current.Database,
+		Roles:    mongodb.ShortRoles{},
+	}
+
+	for _, role := range current.Roles {
+		if !slices.Contains(planned.Roles, role) {
+			revoked.Roles = append(revoked.Roles, role)
+		}
+	}
+
+	// Granting a role which is already granted is a no-op
+	err := r.client.GrantRolesToUser(ctx, planned)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			commandErrorSummary(err, "failed to grant roles to user"),
+			err.Error(),
+		)
+
+		return
+	}
+
+	err = r.client.RevokeRolesFromUser(ctx, revoked)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			commandErrorSummary(err, "failed to revoke roles from user"),
+			err.Error(),
+		)
+
+		return
+	}
+
+	tflog.Trace(ctx, "User roles updated")
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *UserRolesResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if !r.checkClient(&resp.Diagnostics) {
+		return
+	}
+
+	var state UserRolesResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	options, d := state.options(ctx)
+
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.RevokeRolesFromUser(ctx, options)
+	if err != nil {
+		// The roles of a dropped user are gone as well
+		if mongodb.IsNotFoundError(err) {
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			commandErrorSummary(err, "failed to revoke roles from user"),
+			err.Error(),
+		)
+
+		return
+	}
+
+	tflog.Trace(ctx, "User roles revoked")
+}
+
+// ImportState imports all the roles currently granted to the user.
+func (r *UserRolesResource) ImportState(
+	ctx context.Context,
+	req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse,
+) {
+	if !r.checkClient(&resp.Diagnostics) {
+		return
+	}
+
+	database, username, ok := parseUserImportID(req.ID, r.defaultDatabase)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: '[<db>.]<username>'. Got: %q", req.ID),
+		)
+
+		return
+	}
+
+	user, err := r.client.GetUser(ctx, &mongodb.GetUserOptions{
+		Username: username,
+		Database: database,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to get user",
+			err.Error(),
+		)
+
+		return
+	}
+
+	roles, d := user.Roles.ToTerraformSet(ctx)
+
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state := UserRolesResourceModel{
+		Username: types.StringValue(user.Username),
+		Database: types.StringValue(user.Database),
+		Roles:    *roles,
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *UserRolesResource) checkClient(diags *diag.Diagnostics) bool {
+	if r.client == nil {
+		diags.AddError(
+			"MongoDB client is not configured",
+			"Expected configured MongoDB client. Please report this issue to the provider developers.",
+		)
+
+		return false
+	}
+
+	return true
+}
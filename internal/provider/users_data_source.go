@@ -0,0 +1,164 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/megum1n/terraform-provider-mongodb/internal/mongodb"
+)
+
+var _ datasource.DataSource = &UsersDataSource{}
+var _ datasource.DataSourceWithConfigure = &UsersDataSource{}
+
+func NewUsersDataSource() datasource.DataSource {
+	return &UsersDataSource{}
+}
+
+type UsersDataSource struct {
+	client          *mongodb.Client
+	defaultDatabase string
+}
+
+type UsersDataSourceModel struct {
+	Database types.String          `tfsdk:"database"`
+	Users    []UserDataSourceModel `tfsdk:"users"`
+}
+
+func (d *UsersDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_users"
+}
+
+func (d *UsersDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the users of a MongoDB database. Requires the `viewUser` privilege",
+
+		Attributes: map[string]schema.Attribute{
+			"database": schema.StringAttribute{
+				MarkdownDescription: "Auth database name (auth source). " +
+					"The provider `default_database` is used by default",
+				Optional: true,
+				Computed: true,
+			},
+			"users": schema.ListNestedAttribute{
+				MarkdownDescription: "The users defined in the database",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"username": schema.StringAttribute{
+							MarkdownDescription: "The name of the user",
+							Computed:            true,
+						},
+						"database": schema.StringAttribute{
+							MarkdownDescription: "Auth database name (auth source)",
+							Computed:            true,
+						},
+						"roles": schema.SetNestedAttribute{
+							MarkdownDescription: "The roles granted to the user",
+							Computed:            true,
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"role": schema.StringAttribute{
+										MarkdownDescription: "Role name",
+										Computed:            true,
+									},
+									"db": schema.StringAttribute{
+										MarkdownDescription: "Target database name",
+										Computed:            true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *UsersDataSource) Configure(
+	_ context.Context,
+	req datasource.ConfigureRequest,
+	resp *datasource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	p, ok := req.ProviderData.(*MongodbProvider)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *MongodbProvider, got: %T. "+
+				"Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = p.client
+	d.defaultDatabase = p.defaultDatabase
+}
+
+func (d *UsersDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if d.client == nil {
+		resp.Diagnostics.AddError(
+			"MongoDB client is not configured",
+			"Expected configured MongoDB client. Please report this issue to the provider developers.",
+		)
+
+		return
+	}
+
+	var config UsersDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.Database.ValueString() == "" {
+		config.Database = types.StringValue(d.defaultDatabase)
+	}
+
+	users, err := d.client.ListUsers(ctx, &mongodb.ListUsersOptions{
+		Database: config.Database.ValueString(),
+	})
+	if err != nil {
+		if errors.As(err, &mongodb.UnauthorizedError{}) {
+			resp.Diagnostics.AddError(
+				"not authorized to list users",
+				fmt.Sprintf("Listing the users of %q requires the viewUser privilege on the database, "+
+					"e.g. granted by the userAdmin role: %s", config.Database.ValueString(), err),
+			)
+
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			"failed to list users",
+			err.Error(),
+		)
+
+		return
+	}
+
+	config.Users = make([]UserDataSourceModel, 0, len(users))
+
+	for i := range users {
+		var user UserDataSourceModel
+
+		resp.Diagnostics.Append(user.updateState(ctx, &users[i])...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		config.Users = append(config.Users, user)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
+}
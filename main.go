@@ -4,7 +4,9 @@ import (
 	"context"
 	"flag"
 	"log"
+	"time"
 
+	tfprovider "github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 
 	"github.com/megum1n/terraform-provider-mongodb/internal/provider"
@@ -14,6 +16,8 @@ import (
 
 var version = "dev"
 
+const disconnectTimeout = 10 * time.Second
+
 func main() {
 	var debug bool
 
@@ -25,7 +29,21 @@ func main() {
 		Debug:   debug,
 	}
 
-	err := providerserver.Serve(context.Background(), provider.New(version), opts)
+	mongodbProvider := provider.New(version)().(*provider.MongodbProvider)
+
+	err := providerserver.Serve(context.Background(), func() tfprovider.Provider {
+		return mongodbProvider
+	}, opts)
+
+	// Terraform has finished with the provider, tear down the connection pool
+	ctx, cancel := context.WithTimeout(context.Background(), disconnectTimeout)
+	closeErr := mongodbProvider.Close(ctx)
+
+	cancel()
+
+	if closeErr != nil {
+		log.Printf("failed to disconnect from MongoDB: %s", closeErr)
+	}
 
 	if err != nil {
 		log.Fatal(err.Error())